@@ -166,6 +166,7 @@ import (
 	"github.com/crypto-org-chain/cronos/v2/x/cronos/middleware"
 	cronostypes "github.com/crypto-org-chain/cronos/v2/x/cronos/types"
 	e2eekeyring "github.com/crypto-org-chain/cronos/v2/x/e2ee/keyring"
+	"github.com/crypto-org-chain/cronos/versiondb"
 
 	e2ee "github.com/crypto-org-chain/cronos/v2/x/e2ee"
 	e2eekeeper "github.com/crypto-org-chain/cronos/v2/x/e2ee/keeper"
@@ -352,6 +353,8 @@ type App struct {
 	configurator module.Configurator
 
 	qms storetypes.RootMultiStore
+	// the versiondb streaming service, to close the changeset sinks
+	versionDBStreaming *versiondb.StreamingService
 
 	blockProposalHandler *ProposalHandler
 
@@ -436,11 +439,8 @@ func New(
 
 	blockSTMEnabled := cast.ToString(appOpts.Get(srvflags.EVMBlockExecutor)) == "block-stm"
 
-	var cacheSize int
-	if !blockSTMEnabled {
-		// only enable memiavl cache if block-stm is not enabled, because it's not concurrency-safe.
-		cacheSize = cast.ToInt(appOpts.Get(memiavlstore.FlagCacheSize))
-	}
+	// the memiavl cache is concurrency-safe, so it's also enabled with the block-stm executor.
+	cacheSize := cast.ToInt(appOpts.Get(memiavlstore.FlagCacheSize))
 	baseAppOptions = memiavlstore.SetupMemIAVL(logger, homePath, appOpts, false, false, cacheSize, baseAppOptions)
 
 	// enable optimistic execution
@@ -685,6 +685,15 @@ func New(
 			func(ctx sdk.Context, rules ethparams.Rules) vm.PrecompiledContract {
 				return cronosprecompiles.NewIcaContract(ctx, app.ICAControllerKeeper, &app.CronosKeeper, appCodec, gasConfig)
 			},
+			func(_ sdk.Context, rules ethparams.Rules) vm.PrecompiledContract {
+				return cronosprecompiles.NewBankContract(app.BankKeeper, &app.CronosKeeper, appCodec, gasConfig)
+			},
+			func(_ sdk.Context, rules ethparams.Rules) vm.PrecompiledContract {
+				return cronosprecompiles.NewStakingContract(app.StakingKeeper, app.DistrKeeper, &app.CronosKeeper, appCodec, gasConfig)
+			},
+			func(_ sdk.Context, rules ethparams.Rules) vm.PrecompiledContract {
+				return cronosprecompiles.NewGovContract(&app.GovKeeper, &app.CronosKeeper, appCodec, gasConfig)
+			},
 		},
 	)
 
@@ -995,7 +1004,7 @@ func New(
 	// wire up the versiondb's `StreamingService` and `MultiStore`.
 	if cast.ToBool(appOpts.Get("versiondb.enable")) {
 		var err error
-		app.qms, err = app.setupVersionDB(homePath, keys, tkeys, memKeys, okeys)
+		app.qms, err = app.setupVersionDB(homePath, appOpts, keys, tkeys, memKeys, okeys)
 		if err != nil {
 			panic(err)
 		}
@@ -1450,6 +1459,11 @@ func VerifyAddressFormat(bz []byte) error {
 func (app *App) Close() error {
 	errs := []error{app.BaseApp.Close()}
 
+	// finish the changeset files written by the versiondb sinks
+	if app.versionDBStreaming != nil {
+		errs = append(errs, app.versionDBStreaming.Close())
+	}
+
 	// flush the versiondb
 	if closer, ok := app.qms.(io.Closer); ok {
 		errs = append(errs, closer.Close())
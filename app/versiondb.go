@@ -1,19 +1,45 @@
-//go:build rocksdb
-// +build rocksdb
-
 package app
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 
 	storetypes "cosmossdk.io/store/types"
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+	"github.com/spf13/cast"
+
+	"github.com/crypto-org-chain/cronos/store/rootmulti"
 	"github.com/crypto-org-chain/cronos/versiondb"
-	"github.com/crypto-org-chain/cronos/versiondb/tsrocksdb"
+	versiondbclient "github.com/crypto-org-chain/cronos/versiondb/client"
+	"github.com/crypto-org-chain/cronos/versiondb/tsleveldb"
+)
+
+const (
+	FlagVersionDBBackend                    = "versiondb.backend"
+	FlagVersionDBRetainBlocks               = "versiondb.retain-blocks"
+	FlagVersionDBPruneInterval              = "versiondb.prune-interval"
+	FlagVersionDBIndexes                    = "versiondb.indexes"
+	FlagVersionDBChangeSetSinkDir           = "versiondb.changeset-sink-dir"
+	FlagVersionDBChangeSetSinkBlocksPerFile = "versiondb.changeset-sink-blocks-per-file"
+	FlagVersionDBChangeSetSinkZlibLevel     = "versiondb.changeset-sink-zlib-level"
+	FlagVersionDBProofSnapshotDir           = "versiondb.proof-snapshot-dir"
+	FlagVersionDBProofChangeSetDir          = "versiondb.proof-changeset-dir"
+	FlagVersionDBProofCacheSize             = "versiondb.proof-cache-size"
+)
+
+const (
+	// VersionDBBackendRocksDB is the default backend, it requires the binary to be built with the `rocksdb` tag.
+	VersionDBBackendRocksDB = "rocksdb"
+	// VersionDBBackendGoLevelDB is implemented in pure go, it don't need cgo.
+	VersionDBBackendGoLevelDB = "goleveldb"
 )
 
 func (app *App) setupVersionDB(
 	homePath string,
+	appOpts servertypes.AppOptions,
 	keys map[string]*storetypes.KVStoreKey,
 	tkeys map[string]*storetypes.TransientStoreKey,
 	memKeys map[string]*storetypes.MemoryStoreKey,
@@ -24,7 +50,11 @@ func (app *App) setupVersionDB(
 		return nil, err
 	}
 
-	versionDB, err := tsrocksdb.NewStore(dataDir)
+	indexes, err := versionDBIndexes(app.appCodec, cast.ToStringSlice(appOpts.Get(FlagVersionDBIndexes)))
+	if err != nil {
+		return nil, err
+	}
+	versionDB, err := openVersionStore(dataDir, cast.ToString(appOpts.Get(FlagVersionDBBackend)), indexes)
 	if err != nil {
 		return nil, err
 	}
@@ -35,16 +65,24 @@ func (app *App) setupVersionDB(
 		exposedKeys = append(exposedKeys, key)
 	}
 
-	// see: https://github.com/crypto-org-chain/cronos/issues/1683
-	versionDB.SetSkipVersionZero(true)
-
 	app.CommitMultiStore().AddListeners(exposedKeys)
 
+	streamingService := versiondb.NewStreamingService(versionDB)
+	if retainBlocks := cast.ToInt64(appOpts.Get(FlagVersionDBRetainBlocks)); retainBlocks > 0 {
+		if _, ok := versionDB.(versiondb.HistoryPruner); !ok {
+			return nil, errors.New("versiondb backend don't support pruning")
+		}
+		streamingService.SetRetainBlocks(retainBlocks)
+		streamingService.SetPruneInterval(cast.ToInt64(appOpts.Get(FlagVersionDBPruneInterval)))
+	}
+	if err := setupChangeSetSink(streamingService, versionDB, appOpts); err != nil {
+		return nil, err
+	}
+	app.versionDBStreaming = streamingService
+
 	// register in app streaming manager
 	sm := app.StreamingManager()
-	sm.ABCIListeners = append(sm.ABCIListeners,
-		versiondb.NewStreamingService(versionDB),
-	)
+	sm.ABCIListeners = append(sm.ABCIListeners, streamingService)
 	app.SetStreamingManager(sm)
 
 	delegatedStoreKeys := make(map[storetypes.StoreKey]struct{})
@@ -58,7 +96,78 @@ func (app *App) setupVersionDB(
 		delegatedStoreKeys[k] = struct{}{}
 	}
 
+	// the secondary index lookups
+	versiondb.RegisterQueryServer(app.GRPCQueryRouter(), versiondb.NewQueryServer(versionDB, indexes))
+
 	verDB := versiondb.NewMultiStore(app.CommitMultiStore(), versionDB, keys, delegatedStoreKeys)
 	app.SetQueryMultiStore(verDB)
+
+	setupArchivedTrees(app.CommitMultiStore(), appOpts, keys)
 	return verDB, nil
 }
+
+// openVersionStore opens the versiondb with the backend and the secondary indexes, default to rocksdb.
+func openVersionStore(dir, backend string, indexes versiondb.Indexes) (versiondb.VersionStore, error) {
+	switch backend {
+	case "", VersionDBBackendRocksDB:
+		return openRocksDBVersionStore(dir, indexes)
+	case VersionDBBackendGoLevelDB:
+		return tsleveldb.NewStoreWithIndexes(dir, indexes)
+	default:
+		return nil, fmt.Errorf("unknown versiondb backend: %s", backend)
+	}
+}
+
+// setupArchivedTrees rebuilds the merkle trees at the heights pruned from memiavl on demand,
+// so the proofs of historical queries are still available, it's only supported with memiavl.
+func setupArchivedTrees(cms storetypes.CommitMultiStore, appOpts servertypes.AppOptions, keys map[string]*storetypes.KVStoreKey) {
+	snapshotDir := cast.ToString(appOpts.Get(FlagVersionDBProofSnapshotDir))
+	changeSetDir := cast.ToString(appOpts.Get(FlagVersionDBProofChangeSetDir))
+	if len(snapshotDir) == 0 || len(changeSetDir) == 0 {
+		return
+	}
+
+	rs, ok := cms.(*rootmulti.Store)
+	if !ok {
+		panic("versiondb proof generation is only supported with memiavl")
+	}
+
+	stores := make([]string, 0, len(keys))
+	for name := range keys {
+		stores = append(stores, name)
+	}
+	sort.Strings(stores)
+
+	cacheSize := cast.ToInt(appOpts.Get(FlagVersionDBProofCacheSize))
+	rs.SetArchivedTrees(versiondbclient.NewArchivedTrees(snapshotDir, changeSetDir, stores, cacheSize))
+}
+
+// setupChangeSetSink registers a sink to write the change set files continuously, in the same layout as the
+// `changeset dump` command, the existing files must be up to date with the versiondb.
+func setupChangeSetSink(
+	streamingService *versiondb.StreamingService,
+	versionDB versiondb.VersionStore,
+	appOpts servertypes.AppOptions,
+) error {
+	dir := cast.ToString(appOpts.Get(FlagVersionDBChangeSetSinkDir))
+	if len(dir) == 0 {
+		return nil
+	}
+
+	blocksPerFile := cast.ToInt64(appOpts.Get(FlagVersionDBChangeSetSinkBlocksPerFile))
+	if blocksPerFile <= 0 {
+		blocksPerFile = versiondbclient.DefaultChunkSize
+	}
+	latestVersion, err := versionDB.GetLatestVersion()
+	if err != nil {
+		return err
+	}
+	sink, err := versiondbclient.NewChangeSetFileSink(
+		dir, blocksPerFile, cast.ToInt(appOpts.Get(FlagVersionDBChangeSetSinkZlibLevel)), latestVersion,
+	)
+	if err != nil {
+		return err
+	}
+	streamingService.AddSink(sink)
+	return nil
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"fmt"
+
+	"cosmossdk.io/collections"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	ethermint "github.com/evmos/ethermint/types"
+
+	"github.com/crypto-org-chain/cronos/versiondb"
+)
+
+// VersionDBIndexContractsByCodeHash indexes the contract accounts by the code hash, to find all the instances of a
+// contract code at a height.
+const VersionDBIndexContractsByCodeHash = "contracts-by-code-hash"
+
+// VersionDBIndexDenomHolders indexes the balances in bank store by the denom, to find all the holders of a denom at a
+// height.
+const VersionDBIndexDenomHolders = "denom-holders"
+
+// versionDBIndexes returns the secondary indexes selected by names.
+func versionDBIndexes(cdc codec.Codec, names []string) (versiondb.Indexes, error) {
+	indexes := make(versiondb.Indexes, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case VersionDBIndexContractsByCodeHash:
+			indexes = append(indexes, versiondb.Index{
+				Name:    name,
+				Store:   authtypes.StoreKey,
+				Extract: contractCodeHashExtractor(cdc),
+			})
+		case VersionDBIndexDenomHolders:
+			indexes = append(indexes, versiondb.Index{
+				Name:    name,
+				Store:   banktypes.StoreKey,
+				Extract: denomHoldersExtractor(),
+			})
+		default:
+			return nil, fmt.Errorf("unknown versiondb index: %s", name)
+		}
+	}
+	return indexes, indexes.Validate()
+}
+
+// contractCodeHashExtractor extracts the code hash of the contract accounts in auth store.
+func contractCodeHashExtractor(cdc codec.Codec) versiondb.IndexExtractor {
+	prefix := authtypes.AddressStoreKeyPrefix.Bytes()
+	return func(key, value []byte) [][]byte {
+		if len(key) <= len(prefix) || string(key[:len(prefix)]) != string(prefix) {
+			return nil
+		}
+		var acc sdk.AccountI
+		if err := cdc.UnmarshalInterface(value, &acc); err != nil {
+			return nil
+		}
+		ethAcc, ok := acc.(ethermint.EthAccountI)
+		if !ok || ethAcc.Type() != ethermint.AccountTypeContract {
+			return nil
+		}
+		return [][]byte{ethAcc.GetCodeHash().Bytes()}
+	}
+}
+
+// denomHoldersExtractor extracts the denom of the balances in bank store, the key is `0x02 || len(address) || address
+// || denom`, the zero balances are deleted by the bank module.
+func denomHoldersExtractor() versiondb.IndexExtractor {
+	prefix := banktypes.BalancesPrefix.Bytes()
+	keyCodec := collections.PairKeyCodec(sdk.AccAddressKey, collections.StringKey)
+	return func(key, _ []byte) [][]byte {
+		if len(key) <= len(prefix) || string(key[:len(prefix)]) != string(prefix) {
+			return nil
+		}
+		_, pair, err := keyCodec.Decode(key[len(prefix):])
+		if err != nil {
+			return nil
+		}
+		return [][]byte{[]byte(pair.K2())}
+	}
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/ethereum/go-ethereum/common"
+	evmenc "github.com/evmos/ethermint/encoding"
+	ethermint "github.com/evmos/ethermint/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractCodeHashExtractor(t *testing.T) {
+	cdc := evmenc.MakeConfig().Codec
+	extract := contractCodeHashExtractor(cdc)
+
+	addr := sdk.AccAddress(common.HexToAddress("0x1000000000000000000000000000000000000001").Bytes())
+	key := append(authtypes.AddressStoreKeyPrefix.Bytes(), address.MustLengthPrefix(addr)...)
+
+	codeHash := common.BytesToHash([]byte("code"))
+	contract := &ethermint.EthAccount{
+		BaseAccount: authtypes.NewBaseAccountWithAddress(addr),
+		CodeHash:    codeHash.Hex(),
+	}
+	bz, err := cdc.MarshalInterface(contract)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{codeHash.Bytes()}, extract(key, bz))
+
+	// the other stores keys are not indexed
+	require.Nil(t, extract(append(authtypes.AccountNumberStoreKeyPrefix.Bytes(), addr...), bz))
+
+	// the externally owned accounts are not indexed
+	eoa := ethermint.ProtoAccount()
+	require.NoError(t, eoa.SetAddress(addr))
+	bz, err = cdc.MarshalInterface(eoa)
+	require.NoError(t, err)
+	require.Nil(t, extract(key, bz))
+
+	_, err = versionDBIndexes(cdc, []string{"not-exists"})
+	require.Error(t, err)
+}
+
+func TestDenomHoldersExtractor(t *testing.T) {
+	extract := denomHoldersExtractor()
+
+	addr := sdk.AccAddress(common.HexToAddress("0x1000000000000000000000000000000000000001").Bytes())
+	prefix := append(banktypes.BalancesPrefix.Bytes(), address.MustLengthPrefix(addr)...)
+	require.Equal(t, [][]byte{[]byte("basetcro")}, extract(append(prefix, "basetcro"...), []byte("1")))
+	require.Equal(t, [][]byte{[]byte("ibc/ABCD")}, extract(append(prefix, "ibc/ABCD"...), []byte("1")))
+
+	// the other stores keys are not indexed
+	require.Nil(t, extract(append(banktypes.DenomAddressPrefix.Bytes(), "basetcro"...), nil))
+	// the truncated address is not indexed
+	require.Nil(t, extract(prefix[:len(prefix)-1], []byte("1")))
+
+	indexes, err := versionDBIndexes(evmenc.MakeConfig().Codec, []string{VersionDBIndexContractsByCodeHash, VersionDBIndexDenomHolders})
+	require.NoError(t, err)
+	require.Len(t, indexes, 2)
+}
@@ -6,15 +6,9 @@ package app
 import (
 	"errors"
 
-	storetypes "cosmossdk.io/store/types"
+	"github.com/crypto-org-chain/cronos/versiondb"
 )
 
-func (app *App) setupVersionDB(
-	homePath string,
-	keys map[string]*storetypes.KVStoreKey,
-	tkeys map[string]*storetypes.TransientStoreKey,
-	memKeys map[string]*storetypes.MemoryStoreKey,
-	okeys map[string]*storetypes.ObjectStoreKey,
-) (storetypes.RootMultiStore, error) {
-	return nil, errors.New("versiondb is not supported in this binary")
+func openRocksDBVersionStore(string, versiondb.Indexes) (versiondb.VersionStore, error) {
+	return nil, errors.New("versiondb rocksdb backend is not supported in this binary, use the goleveldb backend instead")
 }
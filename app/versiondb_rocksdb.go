@@ -0,0 +1,28 @@
+//go:build rocksdb
+// +build rocksdb
+
+package app
+
+import (
+	"github.com/crypto-org-chain/cronos/versiondb"
+	"github.com/crypto-org-chain/cronos/versiondb/tsrocksdb"
+)
+
+func openRocksDBVersionStore(dir string, indexes versiondb.Indexes) (versiondb.VersionStore, error) {
+	var (
+		versionDB tsrocksdb.Store
+		err       error
+	)
+	if len(indexes) > 0 {
+		versionDB, err = tsrocksdb.NewStoreWithIndexes(dir, indexes)
+	} else {
+		versionDB, err = tsrocksdb.NewStore(dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// see: https://github.com/crypto-org-chain/cronos/issues/1683
+	versionDB.SetSkipVersionZero(true)
+	return versionDB, nil
+}
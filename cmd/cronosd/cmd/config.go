@@ -3,11 +3,36 @@ package cmd
 type VersionDBConfig struct {
 	// Enable defines if the versiondb should be enabled.
 	Enable bool `mapstructure:"enable"`
+	// Backend defines the db backend of versiondb, "rocksdb" or "goleveldb".
+	Backend string `mapstructure:"backend"`
+	// RetainBlocks defines the number of recent blocks of history to keep, 0 means keep all.
+	RetainBlocks uint64 `mapstructure:"retain-blocks"`
+	// PruneInterval defines the number of blocks between two background pruning runs.
+	PruneInterval uint64 `mapstructure:"prune-interval"`
+	// Indexes defines the secondary indexes to maintain, see `app.versionDBIndexes` for the supported ones.
+	Indexes []string `mapstructure:"indexes"`
+	// ChangeSetSinkDir defines the directory to write the change set files continuously, empty means disabled.
+	ChangeSetSinkDir string `mapstructure:"changeset-sink-dir"`
+	// ChangeSetSinkBlocksPerFile defines the number of blocks in each change set file.
+	ChangeSetSinkBlocksPerFile int64 `mapstructure:"changeset-sink-blocks-per-file"`
+	// ChangeSetSinkZlibLevel defines the zlib compression level of the change set files, 0 means no compression.
+	ChangeSetSinkZlibLevel int `mapstructure:"changeset-sink-zlib-level"`
+	// ProofSnapshotDir defines the directory of the memiavl snapshots (named `snapshot-<version>`) used to rebuild
+	// the merkle trees at the heights pruned from memiavl, so the historical queries can still be proved.
+	ProofSnapshotDir string `mapstructure:"proof-snapshot-dir"`
+	// ProofChangeSetDir defines the directory of the change set files replayed on top of the snapshots.
+	ProofChangeSetDir string `mapstructure:"proof-changeset-dir"`
+	// ProofCacheSize defines the max number of rebuilt versions cached in memory.
+	ProofCacheSize int `mapstructure:"proof-cache-size"`
 }
 
 func DefaultVersionDBConfig() VersionDBConfig {
 	return VersionDBConfig{
-		Enable: false,
+		Enable:                     false,
+		Backend:                    "rocksdb",
+		PruneInterval:              1000,
+		ChangeSetSinkBlocksPerFile: 1000000,
+		ProofCacheSize:             8,
 	}
 }
 
@@ -15,4 +40,42 @@ var DefaultVersionDBTemplate = `
 [versiondb]
 # Enable defines if the versiondb should be enabled.
 enable = {{ .VersionDB.Enable }}
+
+# Backend defines the db backend of versiondb, "rocksdb" or "goleveldb", rocksdb requires the binary to be built with
+# the rocksdb build tag, goleveldb is implemented in pure go, the backend can't be changed for an existing db.
+backend = "{{ .VersionDB.Backend }}"
+
+# RetainBlocks defines the number of recent blocks of history to keep, the older versions are pruned in background
+# periodically, the newest value of each key is always preserved, 0 means keep all.
+retain-blocks = {{ .VersionDB.RetainBlocks }}
+
+# PruneInterval defines the number of blocks between two background pruning runs when retain-blocks is set.
+prune-interval = {{ .VersionDB.PruneInterval }}
+
+# Indexes defines the secondary indexes to maintain, which are queried with the "versiondb.Query/IndexedKeys" grpc
+# service, an index added to an existing db is only available from the next block, supported indexes:
+# - "contracts-by-code-hash": the contract accounts by the code hash.
+# - "denom-holders": the balances by the denom.
+indexes = [{{ range .VersionDB.Indexes }}{{ printf "%q, " . }}{{end}}]
+
+# ChangeSetSinkDir defines the directory to write the change set files of the committed blocks continuously, in the
+# same layout as the "changeset dump" command, leave it empty to disable.
+changeset-sink-dir = "{{ .VersionDB.ChangeSetSinkDir }}"
+
+# ChangeSetSinkBlocksPerFile defines the number of blocks in each change set file.
+changeset-sink-blocks-per-file = {{ .VersionDB.ChangeSetSinkBlocksPerFile }}
+
+# ChangeSetSinkZlibLevel defines the zlib compression level of the change set files, 0 means no compression.
+changeset-sink-zlib-level = {{ .VersionDB.ChangeSetSinkZlibLevel }}
+
+# ProofSnapshotDir defines the directory of the memiavl snapshots (named "snapshot-<version>") used to rebuild
+# the merkle trees at the heights pruned from memiavl, so the historical queries can still be proved,
+# it requires memiavl, leave it empty to disable.
+proof-snapshot-dir = "{{ .VersionDB.ProofSnapshotDir }}"
+
+# ProofChangeSetDir defines the directory of the change set files replayed on top of the snapshots.
+proof-changeset-dir = "{{ .VersionDB.ProofChangeSetDir }}"
+
+# ProofCacheSize defines the max number of rebuilt versions cached in memory.
+proof-cache-size = {{ .VersionDB.ProofCacheSize }}
 `
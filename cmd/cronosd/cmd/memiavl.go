@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/crypto-org-chain/cronos/memiavl"
+)
+
+// MemIAVLCmd returns the group of the memiavl maintenance commands.
+func MemIAVLCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "memiavl",
+		Short: "memiavl maintenance commands",
+	}
+	cmd.AddCommand(
+		VerifySnapshotCmd(),
+		CompressSnapshotCmd(),
+		ExportDirCmd(),
+		ImportDirCmd(),
+	)
+	return cmd
+}
+
+func VerifySnapshotCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify-snapshot snapshot-dir",
+		Short: "Verify the checksums of all the files in a memiavl snapshot directory (e.g. data/memiavl.db/snapshot-100), snapshots in format 0 don't have checksums",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := memiavl.VerifyMultiTreeSnapshot(args[0]); err != nil {
+				return err
+			}
+			_, err := fmt.Fprintln(cmd.OutOrStdout(), "snapshot verified:", args[0])
+			return err
+		},
+	}
+}
+
+func CompressSnapshotCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "compress-snapshot snapshot-dir",
+		Short: "Compress the kvs files of a memiavl snapshot directory, it also upgrades the format 0 snapshots to the latest format, don't run it on the current snapshot of a running node",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return memiavl.CompressMultiTreeSnapshot(args[0])
+		},
+	}
+}
+
+const (
+	flagHeight      = "height"
+	flagWriterLimit = "writer-limit"
+)
+
+func ExportDirCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export db-dir export-dir",
+		Short: "Export the trees of a memiavl db (e.g. data/memiavl.db) at a height into per-store files in parallel",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) (returnErr error) {
+			height, err := cmd.Flags().GetUint32(flagHeight)
+			if err != nil {
+				return err
+			}
+			writerLimit, err := cmd.Flags().GetInt(flagWriterLimit)
+			if err != nil {
+				return err
+			}
+			if height == 0 {
+				latest, err := memiavl.GetLatestVersion(args[0])
+				if err != nil {
+					return err
+				}
+				height = uint32(latest)
+			}
+
+			exporter, err := memiavl.NewMultiTreeExporter(args[0], height, true)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				returnErr = errors.Join(returnErr, exporter.Close())
+			}()
+			if err := exporter.ExportToDir(args[1], writerLimit); err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), "exported height:", height)
+			return err
+		},
+	}
+	cmd.Flags().Uint32(flagHeight, 0, "the height to export, default to the latest one")
+	cmd.Flags().Int(flagWriterLimit, memiavl.DefaultSnapshotWriterLimit, "the number of trees exported concurrently")
+	return cmd
+}
+
+func ImportDirCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import export-dir db-dir",
+		Short: "Import the per-store files written by the export command into a memiavl db in parallel, the node must not be running",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) (returnErr error) {
+			height, err := cmd.Flags().GetUint64(flagHeight)
+			if err != nil {
+				return err
+			}
+			if height == 0 {
+				return errors.New("--height is required")
+			}
+			writerLimit, err := cmd.Flags().GetInt(flagWriterLimit)
+			if err != nil {
+				return err
+			}
+
+			importer, err := memiavl.NewMultiTreeImporter(args[1], height, writerLimit)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				returnErr = errors.Join(returnErr, importer.Close())
+			}()
+			if err := importer.ImportDir(args[0]); err != nil {
+				return err
+			}
+			return importer.Finalize()
+		},
+	}
+	cmd.Flags().Uint64(flagHeight, 0, "the height of the exported trees")
+	cmd.Flags().Int(flagWriterLimit, memiavl.DefaultSnapshotWriterLimit, "the number of trees imported concurrently")
+	return cmd
+}
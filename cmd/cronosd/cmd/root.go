@@ -177,10 +177,9 @@ func initRootCmd(
 	}
 	ethermintserver.AddCommands(rootCmd, opts, appExport, addModuleInitFlags)
 
-	changeSetCmd := ChangeSetCmd()
-	if changeSetCmd != nil {
-		rootCmd.AddCommand(changeSetCmd)
-	}
+	rootCmd.AddCommand(MemIAVLCmd())
+
+	rootCmd.AddCommand(ChangeSetCmd())
 
 	// add keybase, auxiliary RPC, query, and tx child commands
 	rootCmd.AddCommand(
@@ -1,6 +1,3 @@
-//go:build rocksdb
-// +build rocksdb
-
 package cmd
 
 import (
@@ -9,7 +6,6 @@ import (
 	"github.com/crypto-org-chain/cronos/v2/app"
 	"github.com/crypto-org-chain/cronos/v2/cmd/cronosd/opendb"
 	versiondbclient "github.com/crypto-org-chain/cronos/versiondb/client"
-	"github.com/linxGnu/grocksdb"
 	"github.com/spf13/cobra"
 )
 
@@ -24,8 +20,6 @@ func ChangeSetCmd() *cobra.Command {
 	return versiondbclient.ChangeSetGroupCmd(versiondbclient.Options{
 		DefaultStores:  storeNames,
 		OpenReadOnlyDB: opendb.OpenReadOnlyDB,
-		AppRocksDBOptions: func(sstFileWriter bool) *grocksdb.Options {
-			return opendb.NewRocksdbOptions(nil, sstFileWriter)
-		},
+		RocksDBOptions: rocksDBOptions(),
 	})
 }
@@ -4,9 +4,9 @@
 package cmd
 
 import (
-	"github.com/spf13/cobra"
+	versiondbclient "github.com/crypto-org-chain/cronos/versiondb/client"
 )
 
-func ChangeSetCmd() *cobra.Command {
-	return nil
+func rocksDBOptions() versiondbclient.RocksDBOptions {
+	return versiondbclient.RocksDBOptions{}
 }
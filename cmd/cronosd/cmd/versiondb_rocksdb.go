@@ -0,0 +1,18 @@
+//go:build rocksdb
+// +build rocksdb
+
+package cmd
+
+import (
+	"github.com/crypto-org-chain/cronos/v2/cmd/cronosd/opendb"
+	versiondbclient "github.com/crypto-org-chain/cronos/versiondb/client"
+	"github.com/linxGnu/grocksdb"
+)
+
+func rocksDBOptions() versiondbclient.RocksDBOptions {
+	return versiondbclient.RocksDBOptions{
+		AppRocksDBOptions: func(sstFileWriter bool) *grocksdb.Options {
+			return opendb.NewRocksdbOptions(nil, sstFileWriter)
+		},
+	}
+}
@@ -36,4 +36,20 @@ contract TestBank is ERC20 {
         _transfer(msg.sender, recipient, amount);
         return bank.transfer(msg.sender, recipient, amount);
     }
-}
\ No newline at end of file
+
+    function nativeTotalSupply() public returns (uint256) {
+        return bank.totalSupply(address(this));
+    }
+
+    function setNativeMetadata() public returns (bool) {
+        return bank.setDenomMetadata(name(), symbol(), decimals());
+    }
+
+    function nativeSend(address recipient, string calldata denom, uint256 amount) public returns (bool) {
+        return bank.send(address(this), recipient, denom, amount);
+    }
+
+    function nativeSendFrom(address from, address recipient, string calldata denom, uint256 amount) public returns (bool) {
+        return bank.send(from, recipient, denom, amount);
+    }
+}
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.4;
+
+import {IGovModule} from "./src/Gov.sol";
+import {Cosmos} from "./src/CosmosTypes.sol";
+
+contract TestGov {
+    address constant govContract = 0x0000000000000000000000000000000000000068;
+    IGovModule gov = IGovModule(govContract);
+
+    function submitProposal(bytes calldata data) public returns (uint64) {
+        return gov.submitProposal(data);
+    }
+
+    function deposit(uint64 proposalId, string memory denom, uint256 amount) public returns (bool) {
+        Cosmos.Coin[] memory coins = new Cosmos.Coin[](1);
+        coins[0] = Cosmos.Coin(amount, denom);
+        return gov.deposit(proposalId, coins);
+    }
+
+    function vote(uint64 proposalId, int32 option) public returns (bool) {
+        return gov.vote(proposalId, option, "");
+    }
+
+    function voteWeighted(uint64 proposalId, IGovModule.WeightedVoteOption[] calldata options) public returns (bool) {
+        return gov.voteWeighted(proposalId, options, "");
+    }
+
+    function proposal(uint64 proposalId) public view returns (IGovModule.Proposal memory) {
+        return gov.proposal(proposalId);
+    }
+
+    function tally(uint64 proposalId) public view returns (IGovModule.TallyResult memory) {
+        return gov.tally(proposalId);
+    }
+}
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.4;
+
+import {IStakingModule} from "./src/Staking.sol";
+import {Cosmos} from "./src/CosmosTypes.sol";
+
+contract TestStaking {
+    address constant stakingContract = 0x0000000000000000000000000000000000000067;
+    IStakingModule staking = IStakingModule(stakingContract);
+
+    function delegate(string memory validator, uint256 amount) public returns (bool) {
+        return staking.delegate(validator, amount);
+    }
+
+    function undelegate(string memory validator, uint256 amount) public returns (int64) {
+        return staking.undelegate(validator, amount);
+    }
+
+    function redelegate(string memory srcValidator, string memory dstValidator, uint256 amount) public returns (int64) {
+        return staking.redelegate(srcValidator, dstValidator, amount);
+    }
+
+    function claimRewards(string memory validator) public returns (Cosmos.Coin[] memory) {
+        return staking.claimRewards(validator);
+    }
+
+    function delegateRevert(string memory validator, uint256 amount) public {
+        delegate(validator, amount);
+        revert("test");
+    }
+
+    function delegation(string memory validator) public view returns (IStakingModule.Delegation memory) {
+        return staking.delegation(address(this), validator);
+    }
+
+    function delegations() public view returns (IStakingModule.Delegation[] memory) {
+        return staking.delegations(address(this));
+    }
+
+    function validators() public view returns (IStakingModule.Validator[] memory) {
+        return staking.validators();
+    }
+}
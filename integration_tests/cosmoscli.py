@@ -335,6 +335,18 @@ class CosmosCLI:
             self.raw("query", "bank", "total", output="json", node=self.node_rpc)
         )
 
+    def query_denom_metadata(self, denom):
+        return json.loads(
+            self.raw(
+                "query",
+                "bank",
+                "denom-metadata",
+                denom,
+                output="json",
+                node=self.node_rpc,
+            )
+        )["metadata"]
+
     def validator(self, addr):
         return json.loads(
             self.raw(
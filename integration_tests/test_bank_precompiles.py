@@ -11,13 +11,18 @@ from .utils import (
     send_transaction,
 )
 
-pytest.skip("skipping bank precompile tests", allow_module_level=True)
-
 
 def get_balance(cli, addr, denom):
     return cli.balance(eth_to_bech32(addr), denom)
 
 
+def get_total_supply(cli, denom):
+    supply = {
+        coin["denom"]: int(coin["amount"]) for coin in cli.total_supply()["supply"]
+    }
+    return supply.get(denom, 0)
+
+
 def test_call(cronos):
     w3 = cronos.w3
     cli = cronos.cosmos_cli()
@@ -84,3 +89,41 @@ def test_call(cronos):
     amt4 = 20
     with pytest.raises(web3.exceptions.ContractLogicError):
         contract.functions.nativeTransfer(recipient, amt4).build_transaction(data)
+
+    # test total supply
+    assert get_total_supply(cli, denom) == contract.caller.nativeTotalSupply()
+    assert amt1 - amt2 == contract.caller.nativeTotalSupply()
+
+    # test denom metadata
+    tx = contract.functions.setNativeMetadata().build_transaction(data)
+    receipt = send_transaction(w3, tx, keys)
+    assert receipt.status == 1
+    metadata = cli.query_denom_metadata(denom)
+    assert metadata["base"] == denom
+    assert metadata["symbol"] == "MAX"
+    assert metadata["denom_units"][1]["denom"] == "max"
+    assert metadata["denom_units"][1]["exponent"] == 18
+
+    # test send of the native denom of the contract
+    amt5 = 5
+    tx = contract.functions.nativeTransfer(contract.address, amt5).build_transaction(
+        data
+    )
+    receipt = send_transaction(w3, tx, keys)
+    assert receipt.status == 1
+    assert amt5 == get_balance(cli, contract.address, denom)
+    tx = contract.functions.nativeSend(addr2, denom, amt5).build_transaction(data)
+    receipt = send_transaction(w3, tx, keys)
+    assert receipt.status == 1
+    assert 0 == get_balance(cli, contract.address, denom)
+    assert balance2 + amt5 == get_balance(cli, addr2, denom)
+
+    # test send of the coins of a third-party account
+    with pytest.raises(web3.exceptions.ContractLogicError):
+        contract.functions.nativeSendFrom(addr, addr2, denom, amt5).build_transaction(
+            data
+        )
+
+    # test send of the denom not owned by the contract
+    with pytest.raises(web3.exceptions.ContractLogicError):
+        contract.functions.nativeSend(addr2, "basetcro", amt5).build_transaction(data)
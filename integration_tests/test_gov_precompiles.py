@@ -0,0 +1,119 @@
+import pytest
+import web3
+from google.protobuf.internal.encoder import _VarintBytes
+
+from .protobuf.cosmos.base.v1beta1.coin_pb2 import Coin
+from .utils import (
+    ADDRS,
+    CONTRACTS,
+    KEYS,
+    deploy_contract,
+    eth_to_bech32,
+    send_transaction,
+    wait_for_new_blocks,
+)
+
+GOV_CONTRACT = "0x0000000000000000000000000000000000000068"
+SUBMIT_PROPOSAL_EVENT = "SubmitProposal(uint64,address,string)"
+PROPOSAL_DEPOSIT_EVENT = "ProposalDeposit(uint64,address,(uint256,string)[])"
+PROPOSAL_VOTE_EVENT = "ProposalVote(uint64,address,string)"
+VOTE_OPTION_YES = 1
+VOTE_OPTION_ABSTAIN = 2
+VOTE_OPTION_NO = 3
+
+
+def encode_field(number, value):
+    return _VarintBytes(number << 3 | 2) + _VarintBytes(len(value)) + value
+
+
+def encode_submit_proposal(proposer, deposit, title, summary):
+    "encode the text proposal as cosmos.gov.v1.MsgSubmitProposal"
+    return b"".join(
+        [
+            encode_field(2, deposit.SerializeToString()),
+            encode_field(3, proposer.encode()),
+            encode_field(5, title.encode()),
+            encode_field(6, summary.encode()),
+        ]
+    )
+
+
+def find_log(w3, receipt, signature):
+    topic = w3.keccak(text=signature)
+    return [
+        log
+        for log in receipt.logs
+        if log.address == GOV_CONTRACT and log.topics[0] == topic
+    ]
+
+
+def test_gov(cronos):
+    w3 = cronos.w3
+    cli = cronos.cosmos_cli()
+    keys = KEYS["signer1"]
+    data = {"from": ADDRS["signer1"]}
+    contract = deploy_contract(w3, CONTRACTS["TestGov"], (), keys)
+    proposer = eth_to_bech32(contract.address)
+    denom = "basetcro"
+    rsp = cli.transfer(eth_to_bech32(ADDRS["signer1"]), proposer, f"100{denom}")
+    assert rsp["code"] == 0, rsp["raw_log"]
+
+    # the proposer must be the caller
+    msg = encode_submit_proposal(
+        eth_to_bech32(ADDRS["signer1"]), Coin(denom=denom, amount="1"), "t", "s"
+    )
+    with pytest.raises(web3.exceptions.ContractLogicError):
+        contract.functions.submitProposal(msg).build_transaction(data)
+
+    # test submit proposal
+    msg = encode_submit_proposal(
+        proposer, Coin(denom=denom, amount="1"), "title", "summary"
+    )
+    tx = contract.functions.submitProposal(msg).build_transaction(data)
+    receipt = send_transaction(w3, tx, keys)
+    assert receipt.status == 1
+    logs = find_log(w3, receipt, SUBMIT_PROPOSAL_EVENT)
+    assert len(logs) == 1
+    proposal_id = int.from_bytes(logs[0].topics[1], "big")
+    proposal = contract.caller.proposal(proposal_id)
+    assert proposal[0] == proposal_id
+    assert proposal[2] == contract.address
+    assert proposal[3] == "title"
+    assert proposal[4] == "summary"
+    assert proposal[7] == [(1, denom)]
+    assert cli.query_proposal(proposal_id)["title"] == "title"
+
+    # test deposit
+    tx = contract.functions.deposit(proposal_id, denom, 10).build_transaction(data)
+    receipt = send_transaction(w3, tx, keys)
+    assert receipt.status == 1
+    assert len(find_log(w3, receipt, PROPOSAL_DEPOSIT_EVENT)) == 1
+    assert contract.caller.proposal(proposal_id)[7] == [(11, denom)]
+
+    # test vote
+    tx = contract.functions.vote(proposal_id, VOTE_OPTION_YES).build_transaction(data)
+    receipt = send_transaction(w3, tx, keys)
+    assert receipt.status == 1
+    assert len(find_log(w3, receipt, PROPOSAL_VOTE_EVENT)) == 1
+
+    # test weighted vote
+    options = [(VOTE_OPTION_YES, "0.5"), (VOTE_OPTION_NO, "0.5")]
+    tx = contract.functions.voteWeighted(proposal_id, options).build_transaction(data)
+    receipt = send_transaction(w3, tx, keys)
+    assert receipt.status == 1
+    assert len(find_log(w3, receipt, PROPOSAL_VOTE_EVENT)) == 1
+
+    # test invalid weighted vote
+    options = [(VOTE_OPTION_YES, "0.5"), (VOTE_OPTION_ABSTAIN, "0.6")]
+    with pytest.raises(web3.exceptions.ContractLogicError):
+        contract.functions.voteWeighted(proposal_id, options).build_transaction(data)
+
+    # test tally of the proposal in voting period
+    for i in range(len(cronos.config["validators"])):
+        rsp = cronos.cosmos_cli(i).gov_vote("validator", proposal_id, "yes")
+        assert rsp["code"] == 0, rsp["raw_log"]
+    wait_for_new_blocks(cli, 1)
+    tally = contract.caller.tally(proposal_id)
+    assert tally[0] == int(cli.query_tally(proposal_id)["yes_count"]) > 0
+    # the votes are not removed by the query
+    assert tally == contract.caller.tally(proposal_id)
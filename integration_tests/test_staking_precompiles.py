@@ -0,0 +1,97 @@
+import pytest
+import web3
+
+from .utils import (
+    ADDRS,
+    CONTRACTS,
+    KEYS,
+    deploy_contract,
+    eth_to_bech32,
+    send_transaction,
+    wait_for_new_blocks,
+)
+
+STAKING_CONTRACT = "0x0000000000000000000000000000000000000067"
+DELEGATE_EVENT = "Delegate(address,string,(uint256,string)[],string)"
+UNBOND_EVENT = "Unbond(address,string,(uint256,string)[],string)"
+REDELEGATE_EVENT = "Redelegate(string,string,(uint256,string)[],string)"
+WITHDRAW_REWARDS_EVENT = "WithdrawRewards(address,string,(uint256,string)[])"
+
+
+def get_delegation(cli, delegator, validator):
+    for item in cli.get_delegated_amount(delegator)["delegation_responses"]:
+        if item["delegation"]["validator_address"] == validator:
+            return int(item["balance"]["amount"])
+    return 0
+
+
+def find_log(w3, receipt, signature):
+    topic = w3.keccak(text=signature)
+    return [
+        log
+        for log in receipt.logs
+        if log.address == STAKING_CONTRACT and log.topics[0] == topic
+    ]
+
+
+def test_staking(cronos):
+    w3 = cronos.w3
+    cli = cronos.cosmos_cli()
+    keys = KEYS["signer1"]
+    data = {"from": ADDRS["signer1"]}
+    contract = deploy_contract(w3, CONTRACTS["TestStaking"], (), keys)
+    delegator = eth_to_bech32(contract.address)
+    rsp = cli.transfer(eth_to_bech32(ADDRS["signer1"]), delegator, "1000stake")
+    assert rsp["code"] == 0, rsp["raw_log"]
+    validators = [v["operator_address"] for v in cli.validators()]
+    val1, val2 = validators[0], validators[1]
+
+    # test delegate
+    amt1 = 100
+    tx = contract.functions.delegate(val1, amt1).build_transaction(data)
+    receipt = send_transaction(w3, tx, keys)
+    assert receipt.status == 1
+    assert len(find_log(w3, receipt, DELEGATE_EVENT)) == 1
+    assert get_delegation(cli, delegator, val1) == amt1
+    delegation = contract.caller.delegation(val1)
+    assert delegation[0] == val1
+    assert delegation[2] == amt1
+    assert len(contract.caller.delegations()) == 1
+    assert set(v[0] for v in contract.caller.validators()) == set(validators)
+
+    # test exception revert
+    tx = contract.functions.delegateRevert(val1, amt1).build_transaction(
+        {"from": ADDRS["signer1"], "gas": 500000}
+    )
+    receipt = send_transaction(w3, tx, keys)
+    assert receipt.status == 0
+    assert get_delegation(cli, delegator, val1) == amt1
+
+    # test delegate more than the balance
+    with pytest.raises(web3.exceptions.ContractLogicError):
+        contract.functions.delegate(val1, 10000).build_transaction(data)
+
+    # test claim rewards
+    wait_for_new_blocks(cli, 2)
+    tx = contract.functions.claimRewards(val1).build_transaction(data)
+    receipt = send_transaction(w3, tx, keys)
+    assert receipt.status == 1
+    assert len(find_log(w3, receipt, WITHDRAW_REWARDS_EVENT)) == 1
+
+    # test redelegate
+    amt2 = 30
+    tx = contract.functions.redelegate(val1, val2, amt2).build_transaction(data)
+    receipt = send_transaction(w3, tx, keys)
+    assert receipt.status == 1
+    assert len(find_log(w3, receipt, REDELEGATE_EVENT)) == 1
+    assert get_delegation(cli, delegator, val1) == amt1 - amt2
+    assert get_delegation(cli, delegator, val2) == amt2
+    assert len(contract.caller.delegations()) == 2
+
+    # test undelegate
+    amt3 = 20
+    tx = contract.functions.undelegate(val1, amt3).build_transaction(data)
+    receipt = send_transaction(w3, tx, keys)
+    assert receipt.status == 1
+    assert len(find_log(w3, receipt, UNBOND_EVENT)) == 1
+    assert get_delegation(cli, delegator, val1) == amt1 - amt2 - amt3
@@ -61,6 +61,8 @@ TEST_CONTRACTS = {
     "CosmosERC20": "CosmosToken.sol",
     "TestBank": "TestBank.sol",
     "TestICA": "TestICA.sol",
+    "TestStaking": "TestStaking.sol",
+    "TestGov": "TestGov.sol",
     "Random": "Random.sol",
     "TestRelayer": "TestRelayer.sol",
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"math/rand"
 	"sort"
+	"sync"
 	"testing"
 
 	iavlcache "github.com/cosmos/iavl/cache"
@@ -151,6 +152,91 @@ func BenchmarkRandomGet(b *testing.B) {
 	})
 }
 
+func BenchmarkParallelGet(b *testing.B) {
+	amount := 100000
+	items := genRandItems(amount)
+
+	tree := New(0)
+	for _, item := range items {
+		tree.set(item.key, item.value)
+	}
+
+	snapshotDir := b.TempDir()
+	require.NoError(b, tree.WriteSnapshot(snapshotDir))
+	snapshot, err := OpenSnapshot(snapshotDir)
+	require.NoError(b, err)
+	defer snapshot.Close()
+
+	// read a hot subset of the keys, so the cache hits most of the time
+	hotKeys := make([][]byte, 1000)
+	for i := range hotKeys {
+		hotKeys[i] = items[i*amount/len(hotKeys)].key
+	}
+
+	bench := func(b *testing.B, diskTree *Tree) {
+		for _, key := range hotKeys {
+			require.NotNil(b, diskTree.Get(key))
+		}
+
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			i := rand.Intn(len(hotKeys))
+			for pb.Next() {
+				_ = diskTree.Get(hotKeys[i%len(hotKeys)])
+				i++
+			}
+		})
+	}
+
+	b.Run("no-cache", func(b *testing.B) {
+		bench(b, NewFromSnapshot(snapshot, true, 0))
+	})
+	b.Run("sharded-cache", func(b *testing.B) {
+		bench(b, NewFromSnapshot(snapshot, true, amount))
+	})
+	b.Run("global-lock-cache", func(b *testing.B) {
+		diskTree := NewFromSnapshot(snapshot, true, 0)
+		diskTree.cache = &lockedCache{cache: iavlcache.New(amount)}
+		bench(b, diskTree)
+	})
+}
+
+// lockedCache protects the whole lru cache with a single lock, as a baseline of the sharded cache.
+type lockedCache struct {
+	mtx   sync.Mutex
+	cache iavlcache.Cache
+}
+
+func (c *lockedCache) Add(node iavlcache.Node) iavlcache.Node {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.cache.Add(node)
+}
+
+func (c *lockedCache) Get(key []byte) iavlcache.Node {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.cache.Get(key)
+}
+
+func (c *lockedCache) Has(key []byte) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.cache.Has(key)
+}
+
+func (c *lockedCache) Remove(key []byte) iavlcache.Node {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.cache.Remove(key)
+}
+
+func (c *lockedCache) Len() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.cache.Len()
+}
+
 func BenchmarkRandomSet(b *testing.B) {
 	items := genRandItems(1000000)
 	b.ResetTimer()
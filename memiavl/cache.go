@@ -0,0 +1,81 @@
+package memiavl
+
+import (
+	"hash/maphash"
+	"sync"
+
+	"github.com/cosmos/iavl/cache"
+)
+
+// cacheShards is the number of shards of the tree cache, must be a power of 2.
+const cacheShards = 64
+
+var _ cache.Cache = (*shardedCache)(nil)
+
+// shardedCache is a concurrency-safe lru cache, the keys are distributed into independent shards to reduce the lock
+// contention, so the concurrent readers (e.g. block-stm executors) can share the cache, the lru order is maintained
+// within each shard.
+type shardedCache struct {
+	seed   maphash.Seed
+	shards [cacheShards]cacheShard
+}
+
+type cacheShard struct {
+	mtx   sync.Mutex
+	cache cache.Cache
+	// avoid false sharing between the shards
+	_ [40]byte
+}
+
+// newShardedCache creates a cache which holds at most `cacheSize` items approximately.
+func newShardedCache(cacheSize int) *shardedCache {
+	c := &shardedCache{seed: maphash.MakeSeed()}
+	shardSize := (cacheSize + cacheShards - 1) / cacheShards
+	for i := range c.shards {
+		c.shards[i].cache = cache.New(shardSize)
+	}
+	return c
+}
+
+func (c *shardedCache) shard(key []byte) *cacheShard {
+	return &c.shards[maphash.Bytes(c.seed, key)&(cacheShards-1)]
+}
+
+func (c *shardedCache) Add(node cache.Node) cache.Node {
+	shard := c.shard(node.GetKey())
+	shard.mtx.Lock()
+	defer shard.mtx.Unlock()
+	return shard.cache.Add(node)
+}
+
+func (c *shardedCache) Get(key []byte) cache.Node {
+	shard := c.shard(key)
+	shard.mtx.Lock()
+	defer shard.mtx.Unlock()
+	return shard.cache.Get(key)
+}
+
+func (c *shardedCache) Has(key []byte) bool {
+	shard := c.shard(key)
+	shard.mtx.Lock()
+	defer shard.mtx.Unlock()
+	return shard.cache.Has(key)
+}
+
+func (c *shardedCache) Remove(key []byte) cache.Node {
+	shard := c.shard(key)
+	shard.mtx.Lock()
+	defer shard.mtx.Unlock()
+	return shard.cache.Remove(key)
+}
+
+func (c *shardedCache) Len() int {
+	var n int
+	for i := range c.shards {
+		shard := &c.shards[i]
+		shard.mtx.Lock()
+		n += shard.cache.Len()
+		shard.mtx.Unlock()
+	}
+	return n
+}
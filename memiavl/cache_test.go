@@ -0,0 +1,58 @@
+package memiavl
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardedCache(t *testing.T) {
+	c := newShardedCache(cacheShards * 2)
+	for i := 0; i < cacheShards*10; i++ {
+		c.Add(&cacheNode{key: []byte(fmt.Sprintf("key-%d", i)), value: []byte("value")})
+	}
+	// each shard is bounded
+	require.LessOrEqual(t, c.Len(), cacheShards*2)
+
+	key := []byte("hello")
+	require.Nil(t, c.Get(key))
+	c.Add(&cacheNode{key: key, value: []byte("world")})
+	require.True(t, c.Has(key))
+	require.Equal(t, []byte("world"), c.Get(key).(*cacheNode).value)
+	require.NotNil(t, c.Remove(key))
+	require.False(t, c.Has(key))
+}
+
+func TestTreeConcurrentGet(t *testing.T) {
+	tree := New(0)
+	for _, changes := range ChangeSets {
+		tree.ApplyChangeSet(changes)
+		_, _, err := tree.SaveVersion(true)
+		require.NoError(t, err)
+	}
+
+	snapshotDir := t.TempDir()
+	require.NoError(t, tree.WriteSnapshot(snapshotDir))
+	snapshot, err := OpenSnapshot(snapshotDir)
+	require.NoError(t, err)
+	defer snapshot.Close()
+
+	diskTree := NewFromSnapshot(snapshot, false, 4)
+	expected := ExpectItems[len(ChangeSets)]
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				for _, pair := range expected {
+					require.Equal(t, pair.value, diskTree.Get(pair.key))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -60,6 +60,28 @@ type DB struct {
 	// make sure only one snapshot rewrite is running
 	pruneSnapshotLock      sync.Mutex
 	triggerStateSyncExport func(height int64)
+	// the versions pinned by the ongoing incremental exports, the snapshots and the WAL entries after them are
+	// not pruned, see `pinVersion`.
+	pinsMtx sync.Mutex
+	pins    map[int64]int
+
+	// if not empty, the truncated WAL entries are archived into change set files in the directory
+	walArchiveDir         string
+	walArchiveCompression string
+	walArchiveChunkSize   int64
+
+	// compress the kvs files of the snapshots kept by `snapshotKeepRecent`
+	compressColdSnapshots bool
+
+	// the read-only views of the recent versions, see `ViewAt`,
+	// lock order: `mtx` before `viewsMtx`.
+	viewsMtx sync.Mutex
+	views    map[int64]*View
+	// the view of the latest committed version, nil if not created yet, it's replaced or reset on each commit
+	latestView      *View
+	viewBase        *viewBase
+	retiredViews    map[*viewBase]struct{}
+	viewsKeepRecent uint32
 
 	// invariant: the LastIndex always match the current version of MultiTree
 	wal         *wal.Log
@@ -107,6 +129,24 @@ type Options struct {
 	LoadForOverwriting bool
 
 	SnapshotWriterLimit int
+
+	// WALArchiveDir if not empty, the WAL entries are converted into per-store change set files in the directory
+	// before they are truncated.
+	WALArchiveDir string
+	// WALArchiveCompression is the compression algorithm of the archived change set files: zlib, zstd or none,
+	// default to zlib.
+	WALArchiveCompression string
+	// WALArchiveChunkSize is the max number of blocks in each archived change set file, the same as the
+	// `--chunk-size` of the `changeset dump` command, 0 means no limit.
+	WALArchiveChunkSize int64
+
+	// CompressColdSnapshots if true, the kvs files of the old snapshots kept by `SnapshotKeepRecent` are converted
+	// into the block-compressed encoding to save disk space, the current snapshot is not affected.
+	CompressColdSnapshots bool
+
+	// ViewsKeepRecent if not zero, a view is created on each commit and the views of the recent versions are kept
+	// to serve `ViewAt`, otherwise only the latest version is served on demand.
+	ViewsKeepRecent uint32
 }
 
 func (opts Options) Validate() error {
@@ -118,6 +158,10 @@ func (opts Options) Validate() error {
 		return errors.New("can't rollback db in read-only mode")
 	}
 
+	if err := validateWALArchiveCompression(opts.WALArchiveCompression); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -246,6 +290,14 @@ func Load(dir string, opts Options) (*DB, error) {
 		snapshotInterval:       opts.SnapshotInterval,
 		triggerStateSyncExport: opts.TriggerStateSyncExport,
 		snapshotWriterPool:     workerPool,
+		walArchiveDir:          opts.WALArchiveDir,
+		walArchiveCompression:  opts.WALArchiveCompression,
+		walArchiveChunkSize:    opts.WALArchiveChunkSize,
+		compressColdSnapshots:  opts.CompressColdSnapshots,
+		views:                  make(map[int64]*View),
+		viewBase:               &viewBase{},
+		retiredViews:           make(map[*viewBase]struct{}),
+		viewsKeepRecent:        opts.ViewsKeepRecent,
 	}
 
 	if !db.readOnly && db.Version() == 0 && len(opts.InitialStores) > 0 {
@@ -485,6 +537,9 @@ func (db *DB) pruneSnapshots() {
 	// wait until last prune finish
 	db.pruneSnapshotLock.Lock()
 
+	// the multi tree could be switched concurrently
+	initialVersion := db.initialVersion
+
 	go func() {
 		defer db.pruneSnapshotLock.Unlock()
 
@@ -494,6 +549,7 @@ func (db *DB) pruneSnapshots() {
 			return
 		}
 
+		pinnedVersion := db.minPinnedVersion()
 		counter := db.snapshotKeepRecent
 		if err := traverseSnapshots(db.dir, false, func(version int64) (bool, error) {
 			if version >= currentVersion {
@@ -501,8 +557,21 @@ func (db *DB) pruneSnapshots() {
 				return false, nil
 			}
 
+			// used by the ongoing incremental exports
+			pinned := pinnedVersion >= 0 && version >= pinnedVersion
+
 			if counter > 0 {
 				counter--
+				if db.compressColdSnapshots && !pinned {
+					name := snapshotName(version)
+					if err := CompressMultiTreeSnapshot(filepath.Join(db.dir, name)); err != nil {
+						db.logger.Error("failed to compress snapshot", "name", name, "err", err)
+					}
+				}
+				return false, nil
+			}
+
+			if pinned {
 				return false, nil
 			}
 
@@ -524,8 +593,18 @@ func (db *DB) pruneSnapshots() {
 		if err != nil {
 			db.logger.Error("failed to find first snapshot", "err", err)
 		}
+		if pinnedVersion >= 0 && pinnedVersion < earliestVersion {
+			earliestVersion = pinnedVersion
+		}
+
+		if len(db.walArchiveDir) > 0 {
+			if err := db.archiveWAL(initialVersion, earliestVersion); err != nil {
+				db.logger.Error("failed to archive wal, skip truncation", "err", err, "version", earliestVersion)
+				return
+			}
+		}
 
-		if err := db.wal.TruncateFront(walIndex(earliestVersion+1, db.initialVersion)); err != nil {
+		if err := db.wal.TruncateFront(walIndex(earliestVersion+1, initialVersion)); err != nil {
 			db.logger.Error("failed to truncate wal", "err", err, "version", earliestVersion+1)
 		}
 	}()
@@ -573,6 +652,14 @@ func (db *DB) Commit() (int64, error) {
 	}
 	db.rewriteIfApplicable(v)
 
+	db.viewsMtx.Lock()
+	if db.viewsKeepRecent > 0 {
+		db.newView()
+	} else {
+		db.latestView = nil
+	}
+	db.viewsMtx.Unlock()
+
 	return v, nil
 }
 
@@ -648,6 +735,9 @@ func (db *DB) copy(cacheSize int) *DB {
 		logger:             db.logger,
 		dir:                db.dir,
 		snapshotWriterPool: db.snapshotWriterPool,
+		views:              make(map[int64]*View),
+		viewBase:           &viewBase{},
+		retiredViews:       make(map[*viewBase]struct{}),
 	}
 }
 
@@ -693,7 +783,8 @@ func (db *DB) reload() error {
 }
 
 func (db *DB) reloadMultiTree(mtree *MultiTree) error {
-	if err := db.MultiTree.Close(); err != nil {
+	// the old trees are closed after the views referencing them are released
+	if err := db.retireViews(); err != nil {
 		return err
 	}
 
@@ -786,7 +877,12 @@ func (db *DB) Close() error {
 		db.snapshotRewriteCancel = nil
 	}
 
+	// wait for the ongoing snapshots pruning, which truncates the wal
+	db.pruneSnapshotLock.Lock()
+	defer db.pruneSnapshotLock.Unlock()
+
 	errs = append(errs,
+		db.closeViews(),
 		db.MultiTree.Close(),
 		db.wal.Close(),
 	)
@@ -118,6 +118,53 @@ func TestRewriteSnapshotBackground(t *testing.T) {
 	require.Equal(t, 4, len(entries))
 }
 
+func TestCompressColdSnapshots(t *testing.T) {
+	db, err := Load(t.TempDir(), Options{
+		CreateIfMissing:       true,
+		InitialStores:         []string{"test"},
+		SnapshotKeepRecent:    1,
+		CompressColdSnapshots: true,
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	for _, changes := range ChangeSets {
+		require.NoError(t, db.ApplyChangeSets([]*NamedChangeSet{{Name: "test", Changeset: changes}}))
+		_, err := db.Commit()
+		require.NoError(t, err)
+
+		require.NoError(t, db.RewriteSnapshotBackground())
+		for db.snapshotRewriteChan != nil {
+			require.NoError(t, db.checkAsyncTasks())
+		}
+	}
+
+	db.pruneSnapshotLock.Lock()
+	defer db.pruneSnapshotLock.Unlock()
+
+	current, err := currentVersion(db.dir)
+	require.NoError(t, err)
+	var cold []int64
+	require.NoError(t, traverseSnapshots(db.dir, true, func(version int64) (bool, error) {
+		if version < current {
+			cold = append(cold, version)
+		}
+		return false, nil
+	}))
+	require.Len(t, cold, 1)
+
+	_, err = os.Stat(filepath.Join(db.dir, snapshotName(cold[0]), "test", FileNameCompressedKVs))
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(currentPath(db.dir), "test", FileNameKVs))
+	require.NoError(t, err)
+	require.NoError(t, VerifyMultiTreeSnapshot(filepath.Join(db.dir, snapshotName(cold[0]))))
+
+	mtree, err := LoadMultiTree(filepath.Join(db.dir, snapshotName(cold[0])), false, 0)
+	require.NoError(t, err)
+	require.Equal(t, RefHashes[cold[0]-1], mtree.TreeByName("test").RootHash())
+	require.NoError(t, mtree.Close())
+}
+
 func TestWAL(t *testing.T) {
 	dir := t.TempDir()
 	db, err := Load(dir, Options{CreateIfMissing: true, InitialStores: []string{"test", "delete"}})
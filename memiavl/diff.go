@@ -0,0 +1,245 @@
+package memiavl
+
+import (
+	"bytes"
+	"sort"
+)
+
+// DiffType is the kind of a difference found by `Tree.Diff`.
+type DiffType uint8
+
+const (
+	// DiffInsert means the key only exists in the other tree.
+	DiffInsert DiffType = iota + 1
+	// DiffUpdate means the key exists in both trees with different values.
+	DiffUpdate
+	// DiffDelete means the key only exists in the tree itself.
+	DiffDelete
+)
+
+func (t DiffType) String() string {
+	switch t {
+	case DiffInsert:
+		return "insert"
+	case DiffUpdate:
+		return "update"
+	case DiffDelete:
+		return "delete"
+	default:
+		return "unknown"
+	}
+}
+
+// Diff compares the tree with the other one in the key range `[start, end)`, nil means unbounded, and calls the
+// callback on each different key in ascending order, the `KVPair` is the change to apply to the tree to turn it into
+// the other one, the value is the new value for insertion and update, and nil for deletion.
+// If the callback function returns false, the diff will be stopped.
+//
+// The subtrees shared by both trees are skipped by comparing the node versions and hashes, so it's efficient to diff
+// two versions of the same tree, the cost is proportional to the size of the changes rather than the tree.
+func (t *Tree) Diff(other *Tree, start, end []byte, callback func(DiffType, *KVPair) bool) {
+	zeroCopy := t.zeroCopy && other.zeroCopy
+	diffNodes(t.root, other.root, start, end, func(typ DiffType, key, value []byte) bool {
+		if !zeroCopy {
+			key = bytes.Clone(key)
+			value = bytes.Clone(value)
+		}
+		return callback(typ, &KVPair{Key: key, Value: value, Delete: typ == DiffDelete})
+	})
+}
+
+// diffCursor traverses the subtrees of a tree in key order, from the left to the right, the subtrees are expanded
+// on demand, so the shared subtrees can be skipped as a whole.
+type diffCursor struct {
+	start, end []byte
+	// the pending subtrees in reversed key order, with their smallest keys
+	stack []diffEntry
+}
+
+type diffEntry struct {
+	node Node
+	// the smallest key in the subtree
+	key []byte
+}
+
+func newDiffCursor(root Node, start, end []byte) *diffCursor {
+	c := &diffCursor{start: start, end: end}
+	if root != nil {
+		c.push(root, leftmostKey(root))
+	}
+	return c
+}
+
+func leftmostKey(node Node) []byte {
+	for !node.IsLeaf() {
+		node = node.Left()
+	}
+	return node.Key()
+}
+
+func (c *diffCursor) push(node Node, key []byte) {
+	c.stack = append(c.stack, diffEntry{node, key})
+}
+
+// top returns the leftmost pending subtree overlapping with the range, nil if finished.
+func (c *diffCursor) top() *diffEntry {
+	for len(c.stack) > 0 {
+		entry := &c.stack[len(c.stack)-1]
+		if c.end != nil && bytes.Compare(entry.key, c.end) >= 0 {
+			// the rest are all out of range
+			c.stack = c.stack[:0]
+			return nil
+		}
+		if entry.node.IsLeaf() && c.start != nil && bytes.Compare(entry.key, c.start) < 0 {
+			c.pop()
+			continue
+		}
+		return entry
+	}
+	return nil
+}
+
+func (c *diffCursor) pop() {
+	c.stack = c.stack[:len(c.stack)-1]
+}
+
+// expand replaces the top branch node with its children, skips the children out of range.
+func (c *diffCursor) expand() {
+	entry := c.stack[len(c.stack)-1]
+	c.pop()
+
+	// the key of a branch node is the smallest key of its right subtree
+	node, splitKey := entry.node, entry.node.Key()
+	if c.end == nil || bytes.Compare(splitKey, c.end) < 0 {
+		c.push(node.Right(), splitKey)
+	}
+	if c.start == nil || bytes.Compare(c.start, splitKey) < 0 {
+		c.push(node.Left(), entry.key)
+	}
+}
+
+// drain reports all the leaves in the top subtree.
+func (c *diffCursor) drain(typ DiffType, callback func(DiffType, []byte, []byte) bool) bool {
+	for entry := c.top(); entry != nil; entry = c.top() {
+		if !entry.node.IsLeaf() {
+			c.expand()
+			continue
+		}
+		var value []byte
+		if typ != DiffDelete {
+			value = entry.node.Value()
+		}
+		if !callback(typ, entry.key, value) {
+			return false
+		}
+		c.pop()
+	}
+	return true
+}
+
+func diffNodes(a, b Node, start, end []byte, callback func(DiffType, []byte, []byte) bool) {
+	ca, cb := newDiffCursor(a, start, end), newDiffCursor(b, start, end)
+	for {
+		ea, eb := ca.top(), cb.top()
+		switch {
+		case ea == nil:
+			cb.drain(DiffInsert, callback)
+			return
+		case eb == nil:
+			ca.drain(DiffDelete, callback)
+			return
+		}
+
+		switch bytes.Compare(ea.key, eb.key) {
+		case -1:
+			// the keys before the other subtree only exist in the old tree
+			if !ea.node.IsLeaf() {
+				ca.expand()
+				continue
+			}
+			if !callback(DiffDelete, ea.key, nil) {
+				return
+			}
+			ca.pop()
+		case 1:
+			if !eb.node.IsLeaf() {
+				cb.expand()
+				continue
+			}
+			if !callback(DiffInsert, eb.key, eb.node.Value()) {
+				return
+			}
+			cb.pop()
+		default:
+			// the version is part of the node hash, compare it first to avoid loading the hash in most cases
+			if ea.node.Version() == eb.node.Version() && bytes.Equal(ea.node.Hash(), eb.node.Hash()) {
+				// shared subtree
+				ca.pop()
+				cb.pop()
+				continue
+			}
+
+			leafA, leafB := ea.node.IsLeaf(), eb.node.IsLeaf()
+			if leafA && leafB {
+				if value := eb.node.Value(); !bytes.Equal(ea.node.Value(), value) {
+					if !callback(DiffUpdate, eb.key, value) {
+						return
+					}
+				}
+				ca.pop()
+				cb.pop()
+				continue
+			}
+
+			// expand the higher subtree, or both if in the same height, so the shared subtrees are aligned
+			heightA, heightB := ea.node.Height(), eb.node.Height()
+			if heightA >= heightB && !leafA {
+				ca.expand()
+			}
+			if heightB >= heightA && !leafB {
+				cb.expand()
+			}
+		}
+	}
+}
+
+// Diff compares the trees with the same names in the two multi trees, and calls the callback on each difference,
+// the trees only exist in one of them are reported as insertions or deletions of all the keys, the trees are visited
+// in the order of names, see `Tree.Diff` for the details.
+func (t *MultiTree) Diff(other *MultiTree, callback func(name string, typ DiffType, pair *KVPair) bool) {
+	names := make(map[string]struct{}, len(t.trees)+len(other.trees))
+	for _, entry := range t.trees {
+		names[entry.Name] = struct{}{}
+	}
+	for _, entry := range other.trees {
+		names[entry.Name] = struct{}{}
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	empty := New(0)
+	for _, name := range sorted {
+		a, b := t.TreeByName(name), other.TreeByName(name)
+		if a == nil {
+			a = empty
+		}
+		if b == nil {
+			b = empty
+		}
+
+		stopped := false
+		a.Diff(b, nil, nil, func(typ DiffType, pair *KVPair) bool {
+			if !callback(name, typ, pair) {
+				stopped = true
+				return false
+			}
+			return true
+		})
+		if stopped {
+			return
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package memiavl
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type diffEvent struct {
+	typ        DiffType
+	key, value string
+}
+
+func collectDiff(a, b *Tree, start, end []byte) []diffEvent {
+	var events []diffEvent
+	a.Diff(b, start, end, func(typ DiffType, pair *KVPair) bool {
+		events = append(events, diffEvent{typ, string(pair.Key), string(pair.Value)})
+		return true
+	})
+	return events
+}
+
+// bruteForceDiff compares the trees by iterating all the keys.
+func bruteForceDiff(a, b *Tree, start, end []byte) []diffEvent {
+	old := make(map[string]string)
+	for _, p := range collectIter(a.Iterator(start, end, true)) {
+		old[string(p.key)] = string(p.value)
+	}
+	var events []diffEvent
+	for _, p := range collectIter(b.Iterator(start, end, true)) {
+		value, ok := old[string(p.key)]
+		delete(old, string(p.key))
+		switch {
+		case !ok:
+			events = append(events, diffEvent{DiffInsert, string(p.key), string(p.value)})
+		case value != string(p.value):
+			events = append(events, diffEvent{DiffUpdate, string(p.key), string(p.value)})
+		}
+	}
+	for key := range old {
+		events = append(events, diffEvent{DiffDelete, key, ""})
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].key < events[j].key
+	})
+	return events
+}
+
+func TestTreeDiff(t *testing.T) {
+	rnd := rand.New(rand.NewSource(0))
+	randomChanges := func(n int) ChangeSet {
+		var cs ChangeSet
+		for i := 0; i < n; i++ {
+			key := []byte(fmt.Sprintf("key-%03d", rnd.Intn(300)))
+			if rnd.Intn(4) == 0 {
+				cs.Pairs = append(cs.Pairs, &KVPair{Key: key, Delete: true})
+			} else {
+				cs.Pairs = append(cs.Pairs, &KVPair{Key: key, Value: []byte(fmt.Sprintf("value-%d", rnd.Intn(3)))})
+			}
+		}
+		return cs
+	}
+
+	tree := New(0)
+	tree.ApplyChangeSet(randomChanges(500))
+	_, _, err := tree.SaveVersion(true)
+	require.NoError(t, err)
+
+	// diff against the persisted nodes
+	snapshotDir := t.TempDir()
+	require.NoError(t, tree.WriteSnapshot(snapshotDir))
+	snapshot, err := OpenSnapshot(snapshotDir)
+	require.NoError(t, err)
+	base := NewFromSnapshot(snapshot, false, 0)
+	defer base.Close()
+
+	current := base.Copy(0)
+	for i := 0; i < 10; i++ {
+		current.ApplyChangeSet(randomChanges(20))
+		_, _, err := current.SaveVersion(true)
+		require.NoError(t, err)
+
+		ranges := [][2][]byte{
+			{nil, nil},
+			{[]byte("key-100"), nil},
+			{nil, []byte("key-200")},
+			{[]byte("key-050"), []byte("key-0505")},
+			{[]byte("key-123"), []byte("key-234")},
+		}
+		for _, r := range ranges {
+			expected := bruteForceDiff(base, current, r[0], r[1])
+			require.Equal(t, expected, collectDiff(base, current, r[0], r[1]))
+
+			// reversed direction
+			reversed := bruteForceDiff(current, base, r[0], r[1])
+			require.Equal(t, reversed, collectDiff(current, base, r[0], r[1]))
+		}
+	}
+
+	// identical trees
+	require.Empty(t, collectDiff(current, current, nil, nil))
+
+	// diff against an empty tree
+	require.Equal(t, bruteForceDiff(New(0), current, nil, nil), collectDiff(New(0), current, nil, nil))
+
+	// stop early
+	var count int
+	base.Diff(current, nil, nil, func(DiffType, *KVPair) bool {
+		count++
+		return count < 3
+	})
+	require.Equal(t, 3, count)
+}
+
+func TestTreeDiffSkipSharedSubtrees(t *testing.T) {
+	tree := New(0)
+	var cs ChangeSet
+	for i := 0; i < 1000; i++ {
+		cs.Pairs = append(cs.Pairs, &KVPair{Key: []byte(fmt.Sprintf("key-%04d", i)), Value: []byte("value")})
+	}
+	tree.ApplyChangeSet(cs)
+	_, _, err := tree.SaveVersion(true)
+	require.NoError(t, err)
+
+	old := tree.Copy(0)
+	tree.ApplyChangeSet(ChangeSet{Pairs: []*KVPair{{Key: []byte("key-0500"), Value: []byte("new")}}})
+	_, _, err = tree.SaveVersion(true)
+	require.NoError(t, err)
+
+	// count the nodes expanded by the diff
+	counter := &countingNode{}
+	var events []diffEvent
+	diffNodes(counter.wrap(old.root), counter.wrap(tree.root), nil, nil, func(typ DiffType, key, value []byte) bool {
+		events = append(events, diffEvent{typ, string(key), string(value)})
+		return true
+	})
+	require.Equal(t, []diffEvent{{DiffUpdate, "key-0500", "new"}}, events)
+	// only the nodes on the path to the changed leaf and their siblings are visited
+	require.Less(t, counter.visited, 100)
+}
+
+// countingNode wraps the nodes to count the visited ones.
+type countingNode struct {
+	Node
+	counter *countingNode
+	visited int
+}
+
+func (c *countingNode) wrap(node Node) Node {
+	if node == nil {
+		return nil
+	}
+	c.visited++
+	return &countingNode{Node: node, counter: c}
+}
+
+func (n *countingNode) Left() Node {
+	return n.counter.wrap(n.Node.Left())
+}
+
+func (n *countingNode) Right() Node {
+	return n.counter.wrap(n.Node.Right())
+}
+
+func TestMultiTreeDiff(t *testing.T) {
+	db, err := Load(t.TempDir(), Options{
+		CreateIfMissing: true,
+		InitialStores:   []string{"test", "deleted"},
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.ApplyChangeSets(mockNameChangeSet("test", "hello", "world")))
+	require.NoError(t, db.ApplyChangeSets(mockNameChangeSet("deleted", "hello", "world")))
+	_, err = db.Commit()
+	require.NoError(t, err)
+	old := db.MultiTree.Copy(0)
+
+	require.NoError(t, db.ApplyUpgrades([]*TreeNameUpgrade{{Name: "deleted", Delete: true}, {Name: "new"}}))
+	require.NoError(t, db.ApplyChangeSets(mockNameChangeSet("new", "hello", "world")))
+	require.NoError(t, db.ApplyChangeSets(mockNameChangeSet("test", "hello", "world1")))
+	_, err = db.Commit()
+	require.NoError(t, err)
+
+	var result []string
+	old.Diff(&db.MultiTree, func(name string, typ DiffType, pair *KVPair) bool {
+		result = append(result, fmt.Sprintf("%s %s %s=%s", name, typ, pair.Key, pair.Value))
+		return true
+	})
+	require.Equal(t, []string{
+		"deleted delete hello=",
+		"new insert hello=world",
+		"test update hello=world1",
+	}, result)
+}
@@ -0,0 +1,167 @@
+package memiavl
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/alitto/pond"
+)
+
+// ExportFileSuffix is the suffix of the per-tree export files written by `MultiTreeExporter.ExportToDir`, the file
+// name is the tree name plus the suffix.
+//
+// The export file contains the nodes in the same order as the `Exporter`:
+//
+// ```
+// height: int8
+// version: uvarint
+// keyLen: uvarint
+// key
+// valueLen: uvarint // only for leaf nodes
+// value
+// *repeat*
+// ```
+const ExportFileSuffix = ".export"
+
+// ExportToDir exports the trees into per-tree files in the directory in parallel, `writerLimit` bounds the number of
+// trees exported concurrently, defaults to `DefaultSnapshotWriterLimit` if not positive.
+func (mte *MultiTreeExporter) ExportToDir(dir string, writerLimit int) error {
+	if writerLimit <= 0 {
+		writerLimit = DefaultSnapshotWriterLimit
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	pool := pond.New(writerLimit, writerLimit*10)
+	defer pool.StopAndWait()
+
+	group, ctx := pool.GroupContext(context.Background())
+	for _, entry := range mte.trees() {
+		tree, name := entry.Tree, entry.Name
+		group.Submit(func() error {
+			if err := writeExportFile(ctx, filepath.Join(dir, name+ExportFileSuffix), tree); err != nil {
+				return fmt.Errorf("export tree %s failed: %w", name, err)
+			}
+			return nil
+		})
+	}
+	return group.Wait()
+}
+
+// writeExportFile writes the nodes of the tree into a tmp file, then renames it atomically.
+func writeExportFile(ctx context.Context, path string, tree *Tree) (returnErr error) {
+	tmpPath := path + TmpSuffix
+	fp, err := createFile(tmpPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := fp.Close(); returnErr == nil {
+			returnErr = err
+		}
+		if returnErr == nil {
+			returnErr = os.Rename(tmpPath, path)
+		}
+	}()
+
+	exporter := tree.Export()
+	defer exporter.Close()
+
+	w := bufio.NewWriter(fp)
+	var buf []byte
+	for {
+		node, err := exporter.Next()
+		if err != nil {
+			if err == ErrorExportDone {
+				break
+			}
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		buf = append(buf[:0], byte(node.Height))
+		buf = binary.AppendUvarint(buf, uint64(node.Version))
+		buf = binary.AppendUvarint(buf, uint64(len(node.Key)))
+		buf = append(buf, node.Key...)
+		if node.Height == 0 {
+			buf = binary.AppendUvarint(buf, uint64(len(node.Value)))
+			buf = append(buf, node.Value...)
+		}
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return fp.Sync()
+}
+
+// readExportFile decodes the nodes from the export file, calls the callback on each of them.
+func readExportFile(r *bufio.Reader, callback func(*ExportNode)) error {
+	readBytes := func() ([]byte, error) {
+		size, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, err
+		}
+		bz := make([]byte, size)
+		_, err = io.ReadFull(r, bz)
+		return bz, err
+	}
+
+	for {
+		height, err := r.ReadByte()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		node := &ExportNode{Height: int8(height)}
+		version, err := binary.ReadUvarint(r)
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		node.Version = int64(version)
+		if node.Key, err = readBytes(); err != nil {
+			return unexpectedEOF(err)
+		}
+		if node.Height == 0 {
+			if node.Value, err = readBytes(); err != nil {
+				return unexpectedEOF(err)
+			}
+		}
+		callback(node)
+	}
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// importExportFile imports a tree snapshot from the export file.
+func importExportFile(path, dir string, version int64) error {
+	fp, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+
+	importer := NewTreeImporter(dir, version)
+	err = readExportFile(bufio.NewReader(fp), importer.Add)
+	return errors.Join(err, importer.Close())
+}
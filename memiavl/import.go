@@ -7,22 +7,35 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"strings"
+
+	"github.com/alitto/pond"
 )
 
 const NodeChannelBuffer = 2048
 
+// MultiTreeImporter imports the trees into a new snapshot, the trees are imported concurrently in a bounded worker
+// pool, a tree is written in background once the nodes of the next tree start coming in.
 type MultiTreeImporter struct {
 	dir         string
 	snapshotDir string
 	height      int64
 	importer    *TreeImporter
-	fileLock    FileLock
+	// the importers of the previous trees, which could be still writing the snapshots
+	pending  []*TreeImporter
+	pool     *pond.WorkerPool
+	fileLock FileLock
 }
 
-func NewMultiTreeImporter(dir string, height uint64) (*MultiTreeImporter, error) {
+// NewMultiTreeImporter creates an importer of the db directory at the height, `writerLimit` bounds the number of
+// trees imported concurrently, defaults to `DefaultSnapshotWriterLimit` if not positive.
+func NewMultiTreeImporter(dir string, height uint64, writerLimit int) (*MultiTreeImporter, error) {
 	if height > math.MaxUint32 {
 		return nil, fmt.Errorf("version overflows uint32: %d", height)
 	}
+	if writerLimit <= 0 {
+		writerLimit = DefaultSnapshotWriterLimit
+	}
 
 	var fileLock FileLock
 	fileLock, err := LockFile(filepath.Join(dir, LockFileName))
@@ -34,6 +47,7 @@ func NewMultiTreeImporter(dir string, height uint64) (*MultiTreeImporter, error)
 		dir:         dir,
 		height:      int64(height),
 		snapshotDir: snapshotName(int64(height)),
+		pool:        pond.New(writerLimit, writerLimit*10),
 		fileLock:    fileLock,
 	}, nil
 }
@@ -55,12 +69,8 @@ func (mti *MultiTreeImporter) Add(item interface{}) error {
 }
 
 func (mti *MultiTreeImporter) AddTree(name string) error {
-	if mti.importer != nil {
-		if err := mti.importer.Close(); err != nil {
-			return err
-		}
-	}
-	mti.importer = NewTreeImporter(filepath.Join(mti.tmpDir(), name), mti.height)
+	mti.finishTree()
+	mti.importer = newTreeImporter(mti.pool.Submit, filepath.Join(mti.tmpDir(), name), mti.height)
 	return nil
 }
 
@@ -68,13 +78,45 @@ func (mti *MultiTreeImporter) AddNode(node *ExportNode) {
 	mti.importer.Add(node)
 }
 
-func (mti *MultiTreeImporter) Finalize() error {
+// finishTree stops feeding the current tree, it continues to be written in background.
+func (mti *MultiTreeImporter) finishTree() {
 	if mti.importer != nil {
-		if err := mti.importer.Close(); err != nil {
-			return err
-		}
+		mti.importer.closeInput()
+		mti.pending = append(mti.pending, mti.importer)
 		mti.importer = nil
 	}
+}
+
+// ImportDir imports the per-tree export files written by `MultiTreeExporter.ExportToDir` in parallel, it can be
+// combined with the trees added by `Add`, as long as the tree names don't conflict.
+func (mti *MultiTreeImporter) ImportDir(exportDir string) error {
+	entries, err := os.ReadDir(exportDir)
+	if err != nil {
+		return err
+	}
+
+	group, _ := mti.pool.GroupContext(context.Background())
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ExportFileSuffix)
+		if e.IsDir() || !ok {
+			continue
+		}
+		path := filepath.Join(exportDir, e.Name())
+		group.Submit(func() error {
+			if err := importExportFile(path, filepath.Join(mti.tmpDir(), name), mti.height); err != nil {
+				return fmt.Errorf("import tree %s failed: %w", name, err)
+			}
+			return nil
+		})
+	}
+	return group.Wait()
+}
+
+// Finalize waits for all the trees to be written, then switches the db to the imported snapshot.
+func (mti *MultiTreeImporter) Finalize() error {
+	if err := mti.waitTrees(); err != nil {
+		return err
+	}
 
 	tmpDir := mti.tmpDir()
 	if err := updateMetadataFile(tmpDir, mti.height); err != nil {
@@ -88,11 +130,19 @@ func (mti *MultiTreeImporter) Finalize() error {
 	return updateCurrentSymlink(mti.dir, mti.snapshotDir)
 }
 
-func (mti *MultiTreeImporter) Close() error {
-	var err error
-	if mti.importer != nil {
-		err = mti.importer.Close()
+func (mti *MultiTreeImporter) waitTrees() error {
+	mti.finishTree()
+	errs := make([]error, 0, len(mti.pending))
+	for _, importer := range mti.pending {
+		errs = append(errs, importer.Close())
 	}
+	mti.pending = nil
+	return errors.Join(errs...)
+}
+
+func (mti *MultiTreeImporter) Close() error {
+	err := mti.waitTrees()
+	mti.pool.StopAndWait()
 	return errors.Join(err, mti.fileLock.Unlock(), mti.fileLock.Destroy())
 }
 
@@ -103,12 +153,21 @@ type TreeImporter struct {
 }
 
 func NewTreeImporter(dir string, version int64) *TreeImporter {
+	return newTreeImporter(func(task func()) { go task() }, dir, version)
+}
+
+// newTreeImporter runs the import task with the `submit` function, the nodes are buffered until the task starts.
+func newTreeImporter(submit func(func()), dir string, version int64) *TreeImporter {
 	nodesChan := make(chan *ExportNode, NodeChannelBuffer)
-	quitChan := make(chan error)
-	go func() {
+	quitChan := make(chan error, 1)
+	submit(func() {
 		defer close(quitChan)
-		quitChan <- doImport(dir, version, nodesChan)
-	}()
+		err := doImport(dir, version, nodesChan)
+		// don't block the producer if the import failed in the middle
+		for range nodesChan {
+		}
+		quitChan <- err
+	})
 	return &TreeImporter{nodesChan, quitChan}
 }
 
@@ -116,14 +175,21 @@ func (ai *TreeImporter) Add(node *ExportNode) {
 	ai.nodesChan <- node
 }
 
+// closeInput signals the end of the nodes without waiting for the import to finish.
+func (ai *TreeImporter) closeInput() {
+	if ai.nodesChan != nil {
+		close(ai.nodesChan)
+		ai.nodesChan = nil
+	}
+}
+
 func (ai *TreeImporter) Close() error {
 	var err error
 	// tolerate double close
-	if ai.nodesChan != nil {
-		close(ai.nodesChan)
+	ai.closeInput()
+	if ai.quitChan != nil {
 		err = <-ai.quitChan
 	}
-	ai.nodesChan = nil
 	ai.quitChan = nil
 	return err
 }
@@ -0,0 +1,217 @@
+package memiavl
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/tidwall/wal"
+)
+
+// IncrementalExporter exports the WAL entries between the base snapshot and the target version as deltas, the base
+// snapshot is the one at the last snapshot interval at or below the target version, a peer which has restored the
+// full state at the base version can catch up by replaying the deltas, which is much cheaper than the full export.
+//
+// The base snapshot and the WAL entries after it are pinned in the db until the exporter is closed, so they are not
+// pruned while exporting.
+type IncrementalExporter struct {
+	wal            *wal.Log
+	release        func()
+	initialVersion uint32
+	baseVersion    int64
+	targetVersion  int64
+	// the version of the next delta to export
+	nextVersion int64
+}
+
+// NewIncrementalExporter creates the exporter of the deltas up to the target version, there's no delta if the target
+// version is a snapshot version itself, the caller should export the full snapshot instead.
+func (db *DB) NewIncrementalExporter(version uint32) (exporter *IncrementalExporter, returnErr error) {
+	baseVersion := int64(version) - int64(version%db.snapshotInterval)
+
+	// pin the base version while the snapshots pruning is not running, the pruning checks the pinned versions
+	// with the lock held.
+	db.pruneSnapshotLock.Lock()
+	release := db.pinVersion(baseVersion)
+	db.pruneSnapshotLock.Unlock()
+	defer func() {
+		if returnErr != nil {
+			release()
+		}
+	}()
+
+	if _, err := os.Stat(filepath.Join(db.dir, snapshotName(baseVersion))); err != nil {
+		return nil, fmt.Errorf("base snapshot is not available: height: %d, %w", baseVersion, err)
+	}
+
+	wal, err := OpenWAL(walPath(db.dir), &wal.Options{NoCopy: true, NoSync: true})
+	if err != nil {
+		return nil, err
+	}
+
+	initialVersion := db.initialVersion
+	if baseVersion < int64(version) {
+		if err := checkWALRange(wal, walIndex(baseVersion+1, initialVersion), walIndex(int64(version), initialVersion)); err != nil {
+			return nil, errors.Join(err, wal.Close())
+		}
+	}
+
+	return &IncrementalExporter{
+		wal:            wal,
+		release:        release,
+		initialVersion: initialVersion,
+		baseVersion:    baseVersion,
+		targetVersion:  int64(version),
+		nextVersion:    nextVersion(baseVersion, initialVersion),
+	}, nil
+}
+
+// checkWALRange checks the WAL contains the entries in the range `[first, last]`.
+func checkWALRange(log *wal.Log, first, last uint64) error {
+	lastIndex, err := log.LastIndex()
+	if err != nil {
+		return fmt.Errorf("read wal last index failed, %w", err)
+	}
+	if last > lastIndex {
+		return fmt.Errorf("wal entry is not written yet: index: %d, last index: %d", last, lastIndex)
+	}
+	firstIndex, err := log.FirstIndex()
+	if err != nil {
+		return fmt.Errorf("read wal first index failed, %w", err)
+	}
+	if first < firstIndex {
+		return fmt.Errorf("wal entry is truncated: index: %d, first index: %d", first, firstIndex)
+	}
+	return nil
+}
+
+// BaseVersion returns the version of the base snapshot which the deltas apply to.
+func (e *IncrementalExporter) BaseVersion() int64 {
+	return e.baseVersion
+}
+
+// NextDelta returns the version and the WAL entry of the next delta after the base snapshot, it returns
+// `ErrorExportDone` after the target version is exported.
+func (e *IncrementalExporter) NextDelta() (int64, *WALEntry, error) {
+	if e.nextVersion > e.targetVersion {
+		return 0, nil, ErrorExportDone
+	}
+
+	version := e.nextVersion
+	bz, err := e.wal.Read(walIndex(version, e.initialVersion))
+	if err != nil {
+		return 0, nil, fmt.Errorf("read wal log failed, version: %d, %w", version, err)
+	}
+	var entry WALEntry
+	if err := entry.Unmarshal(bz); err != nil {
+		return 0, nil, fmt.Errorf("unmarshal wal log failed, version: %d, %w", version, err)
+	}
+	e.nextVersion = version + 1
+	return version, &entry, nil
+}
+
+// Close closes the WAL and unpins the base version.
+func (e *IncrementalExporter) Close() error {
+	err := e.wal.Close()
+	e.release()
+	return err
+}
+
+// pinVersion prevents the snapshot at the version and the WAL entries after it from being pruned until the returned
+// function is called, the caller should hold `pruneSnapshotLock`.
+func (db *DB) pinVersion(version int64) func() {
+	db.pinsMtx.Lock()
+	defer db.pinsMtx.Unlock()
+	if db.pins == nil {
+		db.pins = make(map[int64]int)
+	}
+	db.pins[version]++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			db.pinsMtx.Lock()
+			defer db.pinsMtx.Unlock()
+			if db.pins[version]--; db.pins[version] == 0 {
+				delete(db.pins, version)
+			}
+		})
+	}
+}
+
+// minPinnedVersion returns the smallest pinned version, or -1 if there's none.
+func (db *DB) minPinnedVersion() int64 {
+	db.pinsMtx.Lock()
+	defer db.pinsMtx.Unlock()
+	result := int64(-1)
+	for version := range db.pins {
+		if result < 0 || version < result {
+			result = version
+		}
+	}
+	return result
+}
+
+// EncodeDelta encodes a delta of the incremental snapshot, the version is prefixed to the WAL entry.
+func EncodeDelta(version int64, entry *WALEntry) ([]byte, error) {
+	bz := make([]byte, 8, 8+entry.Size())
+	binary.BigEndian.PutUint64(bz, uint64(version))
+	n, err := entry.MarshalToSizedBuffer(bz[8:cap(bz)])
+	if err != nil {
+		return nil, err
+	}
+	return bz[:8+n], nil
+}
+
+// DecodeDelta is the reverse of `EncodeDelta`.
+func DecodeDelta(bz []byte) (int64, *WALEntry, error) {
+	if len(bz) < 8 {
+		return 0, nil, fmt.Errorf("invalid delta length: %d", len(bz))
+	}
+	var entry WALEntry
+	if err := entry.Unmarshal(bz[8:]); err != nil {
+		return 0, nil, err
+	}
+	return int64(binary.BigEndian.Uint64(bz)), &entry, nil
+}
+
+// DeltaImporter replays the deltas of an incremental snapshot on top of the imported base snapshot.
+type DeltaImporter struct {
+	db *DB
+}
+
+// NewDeltaImporter opens the db, the base snapshot must be imported with `MultiTreeImporter` already.
+func NewDeltaImporter(dir string, opts Options) (*DeltaImporter, error) {
+	db, err := Load(dir, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &DeltaImporter{db: db}, nil
+}
+
+// Add commits the delta as the next version of the db.
+func (di *DeltaImporter) Add(version int64, entry *WALEntry) error {
+	if expected := nextVersion(di.db.Version(), di.db.initialVersion); version != expected {
+		return fmt.Errorf("delta version mismatch, expected: %d, found: %d", expected, version)
+	}
+	if err := di.db.ApplyUpgrades(entry.Upgrades); err != nil {
+		return err
+	}
+	if err := di.db.ApplyChangeSets(entry.Changesets); err != nil {
+		return err
+	}
+	_, err := di.db.Commit()
+	return err
+}
+
+// Version returns the latest version replayed.
+func (di *DeltaImporter) Version() int64 {
+	return di.db.Version()
+}
+
+func (di *DeltaImporter) Close() error {
+	return di.db.Close()
+}
@@ -0,0 +1,119 @@
+package memiavl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncrementalExport(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Load(dir, Options{
+		CreateIfMissing:   true,
+		InitialStores:     []string{"test"},
+		SnapshotInterval:  2,
+		AsyncCommitBuffer: -1,
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	commit := func(i int) {
+		if i == 2 {
+			require.NoError(t, db.ApplyUpgrades([]*TreeNameUpgrade{{Name: "new"}}))
+			require.NoError(t, db.ApplyChangeSets(mockNameChangeSet("new", "hello", "world")))
+		}
+		require.NoError(t, db.ApplyChangeSets([]*NamedChangeSet{{Name: "test", Changeset: ChangeSets[i]}}))
+		_, err := db.Commit()
+		require.NoError(t, err)
+		for db.snapshotRewriteChan != nil {
+			require.NoError(t, db.checkAsyncTasks())
+		}
+		// wait for the snapshots pruning
+		db.pruneSnapshotLock.Lock()
+		db.pruneSnapshotLock.Unlock() //nolint:staticcheck
+	}
+
+	const target = 3
+	for i := 0; i < target; i++ {
+		commit(i)
+	}
+	expCommitInfo := *db.LastCommitInfo()
+
+	exporter, err := db.NewIncrementalExporter(target)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), exporter.BaseVersion())
+
+	// the base snapshot and the deltas are not pruned by the newer snapshots while exporting
+	for i := target; i < len(ChangeSets); i++ {
+		commit(i)
+	}
+	require.DirExists(t, filepath.Join(dir, snapshotName(exporter.BaseVersion())))
+
+	restoreDir := t.TempDir()
+	baseExporter, err := NewMultiTreeExporter(dir, uint32(exporter.BaseVersion()), false)
+	require.NoError(t, err)
+	importer, err := NewMultiTreeImporter(restoreDir, uint64(exporter.BaseVersion()), 0)
+	require.NoError(t, err)
+	for {
+		item, err := baseExporter.Next()
+		if err == ErrorExportDone {
+			break
+		}
+		require.NoError(t, err)
+		require.NoError(t, importer.Add(item))
+	}
+	require.NoError(t, importer.Finalize())
+	require.NoError(t, importer.Close())
+	require.NoError(t, baseExporter.Close())
+
+	deltaImporter, err := NewDeltaImporter(restoreDir, Options{})
+	require.NoError(t, err)
+	for {
+		version, entry, err := exporter.NextDelta()
+		if err == ErrorExportDone {
+			break
+		}
+		require.NoError(t, err)
+
+		// round trip the encoding
+		bz, err := EncodeDelta(version, entry)
+		require.NoError(t, err)
+		version, entry, err = DecodeDelta(bz)
+		require.NoError(t, err)
+		require.NoError(t, deltaImporter.Add(version, entry))
+	}
+	require.NoError(t, exporter.Close())
+	require.Equal(t, expCommitInfo.Version, deltaImporter.Version())
+
+	// the version can't be skipped
+	require.Error(t, deltaImporter.Add(expCommitInfo.Version+2, &WALEntry{}))
+	require.NoError(t, deltaImporter.Close())
+
+	restored, err := Load(restoreDir, Options{ReadOnly: true})
+	require.NoError(t, err)
+	require.Equal(t, expCommitInfo, *restored.LastCommitInfo())
+	require.NoError(t, restored.Close())
+
+	// the base snapshot is pruned after the exporter is closed
+	db.pruneSnapshots()
+	db.pruneSnapshotLock.Lock()
+	db.pruneSnapshotLock.Unlock() //nolint:staticcheck
+	_, err = os.Stat(filepath.Join(dir, snapshotName(2)))
+	require.True(t, os.IsNotExist(err))
+	_, err = db.NewIncrementalExporter(target)
+	require.Error(t, err)
+
+	// no delta if the target version is a snapshot version
+	exporter, err = db.NewIncrementalExporter(uint32(db.SnapshotVersion()))
+	require.NoError(t, err)
+	require.Equal(t, db.SnapshotVersion(), exporter.BaseVersion())
+	_, _, err = exporter.NextDelta()
+	require.Equal(t, ErrorExportDone, err)
+	require.NoError(t, exporter.Close())
+
+	// the target version is not committed yet
+	_, err = db.NewIncrementalExporter(uint32(db.Version()) + 1)
+	require.Error(t, err)
+}
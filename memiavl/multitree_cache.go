@@ -0,0 +1,303 @@
+package memiavl
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/tidwall/wal"
+)
+
+const DefaultMultiTreeCacheSize = 8
+
+var errMultiTreeCacheClosed = errors.New("multi tree cache is closed")
+
+// MultiTreeCache is a bounded cache of read-only `MultiTree`s at historical versions, it's used to serve the
+// queries at non-latest versions without loading the db from disk for each request.
+//
+// The trees loaded from the same snapshot share the mmap-ed snapshot files, a cache miss is served by copying
+// the closest cached tree below the target version and replaying the remaining changes on it (the WAL entries by
+// default), so a burst of queries at nearby versions only pays for a single replay.
+//
+// The returned trees are shared by concurrent readers, they must not be modified.
+type MultiTreeCache struct {
+	dir      string
+	zeroCopy bool
+	capacity int
+	catchup  CatchupFunc
+
+	mtx sync.Mutex
+	// the trees loaded directly from the snapshots, indexed by snapshot version,
+	// the mmap-ed files are closed when no cached version references it anymore.
+	bases map[int64]*cachedSnapshot
+	// the cached versions, indexed by version
+	entries map[int64]*cachedMultiTree
+	// the ongoing loads, indexed by version
+	loading map[int64]*loadCall
+	// logical clock for LRU eviction
+	clock  uint64
+	closed bool
+}
+
+type loadCall struct {
+	done chan struct{}
+	err  error
+}
+
+type cachedSnapshot struct {
+	mtree *MultiTree
+	refs  int
+}
+
+type cachedMultiTree struct {
+	mtree    *MultiTree
+	base     *cachedSnapshot
+	refs     int
+	lastUsed uint64
+}
+
+// CatchupFunc replays the changes on the tree until the target version.
+type CatchupFunc func(mtree *MultiTree, version int64) error
+
+// NewMultiTreeCache creates a cache for the db in `dir`, it keeps at most `capacity` unreferenced versions.
+func NewMultiTreeCache(dir string, zeroCopy bool, capacity int) *MultiTreeCache {
+	return NewMultiTreeCacheWithCatchup(dir, zeroCopy, capacity, func(mtree *MultiTree, version int64) error {
+		return catchupWALDir(mtree, dir, version)
+	})
+}
+
+// NewMultiTreeCacheWithCatchup is like `NewMultiTreeCache`, but replays the changes after the snapshots with a
+// custom function rather than the WAL, `dir` only need to contain the `snapshot-N` directories.
+func NewMultiTreeCacheWithCatchup(dir string, zeroCopy bool, capacity int, catchup CatchupFunc) *MultiTreeCache {
+	if capacity <= 0 {
+		capacity = DefaultMultiTreeCacheSize
+	}
+	return &MultiTreeCache{
+		dir:      dir,
+		zeroCopy: zeroCopy,
+		capacity: capacity,
+		catchup:  catchup,
+		bases:    make(map[int64]*cachedSnapshot),
+		entries:  make(map[int64]*cachedMultiTree),
+		loading:  make(map[int64]*loadCall),
+	}
+}
+
+// Get returns the read-only tree at the target version, the caller must call the returned release function
+// after finishing using the tree.
+//
+// The loading of a missed version is done without holding the lock, so the hits of the other versions are not
+// blocked by it, the concurrent requests of the same version wait for the single ongoing load.
+func (c *MultiTreeCache) Get(version int64) (*MultiTree, func(), error) {
+	if version <= 0 || version >= int64(^uint32(0)) {
+		return nil, nil, fmt.Errorf("invalid version: %d", version)
+	}
+
+	c.mtx.Lock()
+	for {
+		if c.closed {
+			c.mtx.Unlock()
+			return nil, nil, errMultiTreeCacheClosed
+		}
+		if entry, ok := c.entries[version]; ok {
+			release := c.acquire(entry)
+			c.mtx.Unlock()
+			return entry.mtree, release, nil
+		}
+		call, ok := c.loading[version]
+		if !ok {
+			break
+		}
+		c.mtx.Unlock()
+		<-call.done
+		if call.err != nil {
+			return nil, nil, call.err
+		}
+		// the loaded entry could be evicted again before we get the lock, retry in that case.
+		c.mtx.Lock()
+	}
+	call := &loadCall{done: make(chan struct{})}
+	c.loading[version] = call
+	c.mtx.Unlock()
+
+	entry, err := c.load(version)
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if err == nil && c.closed {
+		// don't cache it, the base snapshot is closed if not referenced by the others
+		c.unrefBase(entry.base)
+		err = errMultiTreeCacheClosed
+	}
+	delete(c.loading, version)
+	call.err = err
+	close(call.done)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.entries[version] = entry
+	return entry.mtree, c.acquire(entry), nil
+}
+
+// acquire references the entry and returns the release function, it must be called with the lock held.
+func (c *MultiTreeCache) acquire(entry *cachedMultiTree) func() {
+	entry.refs++
+	c.clock++
+	entry.lastUsed = c.clock
+	c.evict()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.mtx.Lock()
+			defer c.mtx.Unlock()
+
+			entry.refs--
+			c.evict()
+		})
+	}
+}
+
+// load builds the tree at the target version, it must be called without the lock held, the base snapshot is
+// referenced by the returned entry.
+func (c *MultiTreeCache) load(version int64) (*cachedMultiTree, error) {
+	snapshotVersion, err := seekSnapshot(c.dir, uint32(version))
+	if err != nil {
+		return nil, err
+	}
+
+	base, mtree, err := c.acquireBase(snapshotVersion, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if mtree.Version() < version {
+		err = c.catchup(mtree, version)
+	}
+	if err == nil && mtree.Version() != version {
+		err = fmt.Errorf("target version %d is not committed, latest: %d", version, mtree.Version())
+	}
+	if err != nil {
+		c.mtx.Lock()
+		defer c.mtx.Unlock()
+		c.unrefBase(base)
+		return nil, err
+	}
+	return &cachedMultiTree{mtree: mtree, base: base}, nil
+}
+
+// acquireBase references the snapshot, loads it if not cached yet, and returns a copy of the closest tree below the
+// target version derived from it to start the catchup from.
+func (c *MultiTreeCache) acquireBase(snapshotVersion, version int64) (*cachedSnapshot, *MultiTree, error) {
+	c.mtx.Lock()
+	base, ok := c.bases[snapshotVersion]
+	if !ok {
+		c.mtx.Unlock()
+		mtree, err := LoadMultiTree(filepath.Join(c.dir, snapshotName(snapshotVersion)), c.zeroCopy, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		c.mtx.Lock()
+		if c.closed {
+			c.mtx.Unlock()
+			return nil, nil, errors.Join(errMultiTreeCacheClosed, mtree.Close())
+		}
+		if base, ok = c.bases[snapshotVersion]; ok {
+			// loaded concurrently by the other versions
+			defer mtree.Close()
+		} else {
+			base = &cachedSnapshot{mtree: mtree}
+			c.bases[snapshotVersion] = base
+		}
+	}
+	defer c.mtx.Unlock()
+	base.refs++
+
+	// start from the closest cached version derived from the same snapshot, it's copied with the lock held, because
+	// `Copy` marks the copy-on-write version on the source tree, which is shared by the concurrent loads.
+	start := base.mtree
+	for _, entry := range c.entries {
+		if entry.base == base && entry.mtree.Version() <= version && entry.mtree.Version() > start.Version() {
+			start = entry.mtree
+		}
+	}
+	return base, start.Copy(0), nil
+}
+
+// evict removes the least recently used unreferenced versions if the cache is over capacity,
+// it must be called with the lock held.
+func (c *MultiTreeCache) evict() {
+	for len(c.entries) > c.capacity {
+		var (
+			victim  *cachedMultiTree
+			version int64
+		)
+		for v, entry := range c.entries {
+			if entry.refs > 0 {
+				continue
+			}
+			if victim == nil || entry.lastUsed < victim.lastUsed {
+				victim, version = entry, v
+			}
+		}
+		if victim == nil {
+			// all the entries are in use
+			return
+		}
+
+		delete(c.entries, version)
+		c.unrefBase(victim.base)
+	}
+}
+
+func (c *MultiTreeCache) unrefBase(base *cachedSnapshot) {
+	base.refs--
+	if base.refs > 0 {
+		return
+	}
+	for v, b := range c.bases {
+		if b == base {
+			delete(c.bases, v)
+			break
+		}
+	}
+	// the derived trees share the snapshot, only the base owns the mmap-ed files.
+	_ = base.mtree.Close()
+}
+
+// Close releases all the cached trees, the trees still in use are invalidated, it waits for the ongoing loads, which
+// are not cached after it's closed.
+func (c *MultiTreeCache) Close() error {
+	c.mtx.Lock()
+	c.closed = true
+	calls := make([]*loadCall, 0, len(c.loading))
+	for _, call := range c.loading {
+		calls = append(calls, call)
+	}
+	c.mtx.Unlock()
+	for _, call := range calls {
+		<-call.done
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	errs := make([]error, 0, len(c.bases))
+	for _, base := range c.bases {
+		errs = append(errs, base.mtree.Close())
+	}
+	c.bases = make(map[int64]*cachedSnapshot)
+	c.entries = make(map[int64]*cachedMultiTree)
+	return errors.Join(errs...)
+}
+
+// catchupWALDir opens the WAL of the db in `dir` and replays it on the tree until the target version.
+func catchupWALDir(mtree *MultiTree, dir string, version int64) error {
+	log, err := OpenWAL(walPath(dir), &wal.Options{NoCopy: true, NoSync: true})
+	if err != nil {
+		return err
+	}
+	return errors.Join(mtree.CatchupWAL(log, version), log.Close())
+}
@@ -0,0 +1,210 @@
+package memiavl
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiTreeCache(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Load(dir, Options{
+		CreateIfMissing:   true,
+		InitialStores:     []string{"test"},
+		AsyncCommitBuffer: -1,
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	for i, changes := range ChangeSets {
+		require.NoError(t, db.ApplyChangeSets([]*NamedChangeSet{{Name: "test", Changeset: changes}}))
+		_, err := db.Commit()
+		require.NoError(t, err)
+
+		if i == len(ChangeSets)/2 {
+			require.NoError(t, db.RewriteSnapshot())
+			require.NoError(t, db.Reload())
+		}
+	}
+
+	cache := NewMultiTreeCache(dir, false, 2)
+	defer cache.Close()
+
+	// query in an unordered way to exercise both the snapshot loading and the forward extension
+	versions := []int64{3, 1, 2, int64(len(ChangeSets)), int64(len(ChangeSets)/2 + 1), 3}
+	for _, v := range versions {
+		mtree, release, err := cache.Get(v)
+		require.NoError(t, err)
+		require.Equal(t, v, mtree.Version())
+		require.Equal(t, RefHashes[v-1], mtree.TreeByName("test").RootHash())
+		require.Equal(t, ExpectItems[v], collectIter(mtree.TreeByName("test").Iterator(nil, nil, true)))
+		release()
+	}
+	require.Len(t, cache.entries, 2)
+
+	// referenced entries are not evicted
+	var releases []func()
+	for v := int64(1); v <= 4; v++ {
+		mtree, release, err := cache.Get(v)
+		require.NoError(t, err)
+		require.Equal(t, RefHashes[v-1], mtree.TreeByName("test").RootHash())
+		releases = append(releases, release)
+	}
+	require.Len(t, cache.entries, 4)
+	for _, release := range releases {
+		release()
+	}
+	require.Len(t, cache.entries, 2)
+
+	_, _, err = cache.Get(int64(len(ChangeSets)) + 1)
+	require.Error(t, err)
+}
+
+func TestMultiTreeCacheConcurrentLoad(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Load(dir, Options{
+		CreateIfMissing:   true,
+		InitialStores:     []string{"test"},
+		AsyncCommitBuffer: -1,
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	for _, changes := range ChangeSets {
+		require.NoError(t, db.ApplyChangeSets([]*NamedChangeSet{{Name: "test", Changeset: changes}}))
+		_, err := db.Commit()
+		require.NoError(t, err)
+	}
+
+	const slowVersion = 5
+	var loads atomic.Int32
+	started, unblock := make(chan struct{}), make(chan struct{})
+	cache := NewMultiTreeCacheWithCatchup(dir, false, 4, func(mtree *MultiTree, version int64) error {
+		if version == slowVersion {
+			loads.Add(1)
+			close(started)
+			<-unblock
+		}
+		return catchupWALDir(mtree, dir, version)
+	})
+	defer cache.Close()
+
+	_, release, err := cache.Get(2)
+	require.NoError(t, err)
+	release()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mtree, release, err := cache.Get(slowVersion)
+			require.NoError(t, err)
+			require.Equal(t, RefHashes[slowVersion-1], mtree.TreeByName("test").RootHash())
+			release()
+		}()
+	}
+	<-started
+
+	// the cache hit is not blocked by the ongoing load
+	mtree, release, err := cache.Get(2)
+	require.NoError(t, err)
+	require.Equal(t, RefHashes[1], mtree.TreeByName("test").RootHash())
+	release()
+
+	close(unblock)
+	wg.Wait()
+	require.Equal(t, int32(1), loads.Load())
+}
+
+func TestMultiTreeCacheCloseDuringLoad(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Load(dir, Options{
+		CreateIfMissing:   true,
+		InitialStores:     []string{"test"},
+		AsyncCommitBuffer: -1,
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	for _, changes := range ChangeSets {
+		require.NoError(t, db.ApplyChangeSets([]*NamedChangeSet{{Name: "test", Changeset: changes}}))
+		_, err := db.Commit()
+		require.NoError(t, err)
+	}
+
+	started, unblock := make(chan struct{}), make(chan struct{})
+	cache := NewMultiTreeCacheWithCatchup(dir, false, 4, func(mtree *MultiTree, version int64) error {
+		close(started)
+		<-unblock
+		return catchupWALDir(mtree, dir, version)
+	})
+
+	result := make(chan error)
+	go func() {
+		_, _, err := cache.Get(3)
+		result <- err
+	}()
+	<-started
+
+	closed := make(chan error)
+	go func() {
+		closed <- cache.Close()
+	}()
+	// the close waits for the ongoing load
+	require.Eventually(t, func() bool {
+		cache.mtx.Lock()
+		defer cache.mtx.Unlock()
+		return cache.closed
+	}, time.Second, time.Millisecond)
+	close(unblock)
+
+	require.ErrorIs(t, <-result, errMultiTreeCacheClosed)
+	require.NoError(t, <-closed)
+	require.Empty(t, cache.entries)
+	require.Empty(t, cache.bases)
+
+	_, _, err = cache.Get(3)
+	require.ErrorIs(t, err, errMultiTreeCacheClosed)
+}
+
+func TestMultiTreeCacheConcurrentCopy(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Load(dir, Options{
+		CreateIfMissing:   true,
+		InitialStores:     []string{"test"},
+		AsyncCommitBuffer: -1,
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	for _, changes := range ChangeSets {
+		require.NoError(t, db.ApplyChangeSets([]*NamedChangeSet{{Name: "test", Changeset: changes}}))
+		_, err := db.Commit()
+		require.NoError(t, err)
+	}
+
+	cache := NewMultiTreeCache(dir, false, len(ChangeSets))
+	defer cache.Close()
+
+	// the version replayed in memory, which is the start of the following loads
+	_, release, err := cache.Get(2)
+	require.NoError(t, err)
+	release()
+
+	var wg sync.WaitGroup
+	for v := int64(3); v <= int64(len(ChangeSets)); v++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mtree, release, err := cache.Get(v)
+			require.NoError(t, err)
+			require.Equal(t, RefHashes[v-1], mtree.TreeByName("test").RootHash())
+			release()
+		}()
+	}
+	wg.Wait()
+}
@@ -51,11 +51,11 @@ type PersistedNode struct {
 var _ Node = PersistedNode{}
 
 func (node PersistedNode) branchNode() NodeLayout {
-	return node.snapshot.nodesLayout.Node(node.index)
+	return node.snapshot.branchNode(node.index)
 }
 
 func (node PersistedNode) leafNode() LeafLayout {
-	return node.snapshot.leavesLayout.Leaf(node.index)
+	return node.snapshot.leafNode(node.index)
 }
 
 func (node PersistedNode) Height() uint8 {
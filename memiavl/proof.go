@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/cosmos/iavl"
 	ics23 "github.com/cosmos/ics23/go"
@@ -81,6 +82,192 @@ func (t *Tree) VerifyNonMembership(proof *ics23.CommitmentProof, key []byte) boo
 	return ics23.VerifyNonMembership(ics23.IavlSpec, root, proof, key)
 }
 
+// GetBatchProof produces a compressed batch proof for the keys, an existence proof for each existing key and a
+// non-existence proof for each missing one, the inner nodes shared by the paths are only included once.
+func (t *Tree) GetBatchProof(keys [][]byte) (*ics23.CommitmentProof, error) {
+	proofs := make([]*ics23.CommitmentProof, 0, len(keys))
+	for _, key := range keys {
+		var (
+			proof *ics23.CommitmentProof
+			err   error
+		)
+		if t.Get(key) != nil {
+			proof, err = t.GetMembershipProof(key)
+		} else {
+			proof, err = t.GetNonMembershipProof(key)
+		}
+		if err != nil {
+			return nil, err
+		}
+		proofs = append(proofs, proof)
+	}
+	return ics23.CombineProofs(proofs)
+}
+
+// VerifyBatchProof returns true iff proof is a batch proof for the existence or non-existence of the keys.
+func (t *Tree) VerifyBatchProof(proof *ics23.CommitmentProof, keys [][]byte) bool {
+	root := t.RootHash()
+	items := make(map[string][]byte)
+	var absents [][]byte
+	for _, key := range keys {
+		if value := t.Get(key); value != nil {
+			items[string(key)] = value
+		} else {
+			absents = append(absents, key)
+		}
+	}
+	return ics23.BatchVerifyMembership(ics23.IavlSpec, root, proof, items) &&
+		ics23.BatchVerifyNonMembership(ics23.IavlSpec, root, proof, absents)
+}
+
+// GetRangeProof returns the key-value pairs in the range `[start, end)` in ascending order, nil means unbounded,
+// together with a compressed batch of existence proofs for them and the closest keys out of both ends of the range,
+// the adjacency of the proven keys proves that no key in the range is omitted, see `VerifyRangeProof`.
+func (t *Tree) GetRangeProof(start, end []byte) ([]Pair, *ics23.CommitmentProof, error) {
+	if start != nil && end != nil && bytes.Compare(start, end) > 0 {
+		return nil, nil, fmt.Errorf("invalid range [%X, %X)", start, end)
+	}
+
+	var size int64
+	if t.root != nil {
+		size = t.root.Size()
+	}
+	// the leaf indexes of the range
+	first, last := int64(0), size
+	if start != nil {
+		first, _ = t.GetWithIndex(start)
+	}
+	if end != nil {
+		last, _ = t.GetWithIndex(end)
+	}
+
+	pairs := make([]Pair, 0, last-first)
+	var entries []*ics23.BatchEntry
+	// include the left neighbor of the range and the first key not less than the end
+	for i := max(first-1, 0); i <= min(last, size-1); i++ {
+		key, value := t.GetByIndex(i)
+		exist, err := t.createExistenceProof(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		entries = append(entries, &ics23.BatchEntry{
+			Proof: &ics23.BatchEntry_Exist{Exist: exist},
+		})
+		if i >= first && i < last {
+			pairs = append(pairs, Pair{Key: key, Value: value})
+		}
+	}
+
+	proof := ics23.Compress(&ics23.CommitmentProof{
+		Proof: &ics23.CommitmentProof_Batch{
+			Batch: &ics23.BatchProof{Entries: entries},
+		},
+	})
+	return pairs, proof, nil
+}
+
+// GetPrefixProof is a shortcut of `GetRangeProof` for all the keys with the prefix.
+func (t *Tree) GetPrefixProof(prefix []byte) ([]Pair, *ics23.CommitmentProof, error) {
+	return t.GetRangeProof(prefix, prefixEnd(prefix))
+}
+
+// VerifyRangeProof verifies the proof generated by `GetRangeProof`, the pairs must be exactly the ones in the range
+// `[start, end)` in ascending order, returns the root hash committed by the proof, which the caller should compare
+// with the trusted one.
+func VerifyRangeProof(proof *ics23.CommitmentProof, start, end []byte, pairs []Pair) ([]byte, error) {
+	batch := ics23.Decompress(proof).GetBatch()
+	if batch == nil {
+		return nil, errors.New("range proof must be a batch proof")
+	}
+
+	exists := make([]*ics23.ExistenceProof, 0, len(batch.Entries))
+	for _, entry := range batch.Entries {
+		exist := entry.GetExist()
+		if exist == nil {
+			return nil, errors.New("range proof must only contain existence proofs")
+		}
+		if len(exists) > 0 && bytes.Compare(exists[len(exists)-1].Key, exist.Key) >= 0 {
+			return nil, errors.New("keys in range proof are not in ascending order")
+		}
+		exists = append(exists, exist)
+	}
+
+	if len(exists) == 0 {
+		// only an empty tree has no keys to prove
+		if len(pairs) > 0 {
+			return nil, errors.New("range proof is empty")
+		}
+		return bytes.Clone(emptyHash), nil
+	}
+
+	spec := ics23.IavlSpec
+	root, err := exists[0].Calculate()
+	if err != nil {
+		return nil, err
+	}
+	for _, exist := range exists {
+		if err := exist.Verify(spec, root, exist.Key, exist.Value); err != nil {
+			return nil, err
+		}
+		if len(exists) > 1 && len(exist.Path) == 0 {
+			return nil, errors.New("range proof contains an invalid path")
+		}
+	}
+
+	// the proven keys in the range
+	lo := sort.Search(len(exists), func(i int) bool {
+		return bytes.Compare(exists[i].Key, start) >= 0
+	})
+	hi := len(exists)
+	if end != nil {
+		hi = sort.Search(len(exists), func(i int) bool {
+			return bytes.Compare(exists[i].Key, end) >= 0
+		})
+	}
+	if hi < lo {
+		return nil, fmt.Errorf("invalid range [%X, %X)", start, end)
+	}
+
+	// no keys between the start and the first proven key in the range
+	if lo == 0 && !bytes.Equal(exists[0].Key, start) && !ics23.IsLeftMost(spec.InnerSpec, exists[0].Path) {
+		return nil, errors.New("range proof is missing the left boundary")
+	}
+	// the proven keys from the left neighbor of the range to the first key not less than the end must be adjacent
+	for i := max(lo-1, 0); i < min(hi, len(exists)-1); i++ {
+		if !ics23.IsLeftNeighbor(spec.InnerSpec, exists[i].Path, exists[i+1].Path) {
+			return nil, fmt.Errorf("keys %X and %X are not adjacent in range proof", exists[i].Key, exists[i+1].Key)
+		}
+	}
+	// no keys between the last proven key and the end
+	if hi == len(exists) && !ics23.IsRightMost(spec.InnerSpec, exists[len(exists)-1].Path) {
+		return nil, errors.New("range proof is missing the right boundary")
+	}
+
+	if len(pairs) != hi-lo {
+		return nil, fmt.Errorf("expect %d pairs in the range, got %d", hi-lo, len(pairs))
+	}
+	for i, pair := range pairs {
+		exist := exists[lo+i]
+		if !bytes.Equal(pair.Key, exist.Key) || !bytes.Equal(pair.Value, exist.Value) {
+			return nil, fmt.Errorf("pair %X doesn't match the range proof", pair.Key)
+		}
+	}
+	return root, nil
+}
+
+// prefixEnd returns the exclusive end of the keys with the prefix, nil if unbounded.
+func prefixEnd(prefix []byte) []byte {
+	end := bytes.Clone(prefix)
+	for len(end) > 0 {
+		if end[len(end)-1] != 0xff {
+			end[len(end)-1]++
+			return end
+		}
+		end = end[:len(end)-1]
+	}
+	return nil
+}
+
 // createExistenceProof will get the proof from the tree and convert the proof into a valid
 // existence proof, if that's what it is.
 func (t *Tree) createExistenceProof(key []byte) (*ics23.ExistenceProof, error) {
@@ -1,9 +1,11 @@
 package memiavl
 
 import (
+	"fmt"
 	"strconv"
 	"testing"
 
+	ics23 "github.com/cosmos/ics23/go"
 	"github.com/stretchr/testify/require"
 )
 
@@ -57,3 +59,136 @@ func TestProofs(t *testing.T) {
 		})
 	}
 }
+
+func TestBatchProof(t *testing.T) {
+	tree := New(0)
+	var cs ChangeSet
+	for i := 0; i < 100; i++ {
+		cs.Pairs = append(cs.Pairs, &KVPair{Key: []byte(fmt.Sprintf("key-%03d", i*2)), Value: []byte("value")})
+	}
+	tree.ApplyChangeSet(cs)
+	_, _, err := tree.SaveVersion(true)
+	require.NoError(t, err)
+
+	keys := [][]byte{[]byte("key-000"), []byte("key-001"), []byte("key-100"), []byte("key-199"), []byte("key-999")}
+	proof, err := tree.GetBatchProof(keys)
+	require.NoError(t, err)
+	require.True(t, tree.VerifyBatchProof(proof, keys))
+
+	// the shared inner nodes are deduped
+	var size int
+	for _, key := range keys {
+		single, err := tree.GetNonMembershipProof(key)
+		if err != nil {
+			single, err = tree.GetMembershipProof(key)
+		}
+		require.NoError(t, err)
+		size += single.Size()
+	}
+	require.Less(t, proof.Size(), size)
+
+	// can't prove a different value
+	require.False(t, ics23.BatchVerifyMembership(ics23.IavlSpec, tree.RootHash(), proof, map[string][]byte{
+		"key-000": []byte("value1"),
+	}))
+}
+
+func TestRangeProof(t *testing.T) {
+	tree := New(0)
+	var cs ChangeSet
+	for i := 0; i < 100; i++ {
+		cs.Pairs = append(cs.Pairs, &KVPair{Key: []byte(fmt.Sprintf("key-%03d", i*2)), Value: []byte(fmt.Sprintf("value-%d", i))})
+	}
+	cs.Pairs = append(cs.Pairs, &KVPair{Key: []byte("prefix/1"), Value: []byte("1")}, &KVPair{Key: []byte("prefix/2"), Value: []byte("2")})
+	tree.ApplyChangeSet(cs)
+	_, _, err := tree.SaveVersion(true)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		start, end []byte
+		expLen     int
+	}{
+		{nil, nil, 102},
+		{[]byte("key-000"), []byte("key-010"), 5},
+		{[]byte("key-001"), []byte("key-011"), 5},
+		{nil, []byte("key-010"), 5},
+		{[]byte("key-190"), nil, 7},
+		{[]byte("key-191"), []byte("key-192"), 0},
+		{[]byte("a"), []byte("b"), 0},
+		{[]byte("z"), nil, 0},
+		{[]byte("key-010"), []byte("key-010"), 0},
+	}
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			pairs, proof, err := tree.GetRangeProof(tc.start, tc.end)
+			require.NoError(t, err)
+			require.Len(t, pairs, tc.expLen)
+			require.Equal(t, collectIter(tree.Iterator(tc.start, tc.end, true)), toTestPairs(pairs))
+
+			root, err := VerifyRangeProof(proof, tc.start, tc.end, pairs)
+			require.NoError(t, err)
+			require.Equal(t, tree.RootHash(), root)
+
+			// round trip the encoding
+			bz, err := proof.Marshal()
+			require.NoError(t, err)
+			var decoded ics23.CommitmentProof
+			require.NoError(t, decoded.Unmarshal(bz))
+			_, err = VerifyRangeProof(&decoded, tc.start, tc.end, pairs)
+			require.NoError(t, err)
+
+			if len(pairs) > 0 {
+				// omit a pair
+				_, err = VerifyRangeProof(proof, tc.start, tc.end, pairs[1:])
+				require.Error(t, err)
+
+				// tamper the value
+				tampered := append([]Pair{}, pairs...)
+				tampered[0].Value = []byte("tampered")
+				_, err = VerifyRangeProof(proof, tc.start, tc.end, tampered)
+				require.Error(t, err)
+			}
+		})
+	}
+
+	pairs, proof, err := tree.GetPrefixProof([]byte("prefix/"))
+	require.NoError(t, err)
+	require.Equal(t, []Pair{{Key: []byte("prefix/1"), Value: []byte("1")}, {Key: []byte("prefix/2"), Value: []byte("2")}}, pairs)
+	_, err = VerifyRangeProof(proof, []byte("prefix/"), []byte("prefix0"), pairs)
+	require.NoError(t, err)
+	// the proof doesn't cover a wider range
+	_, err = VerifyRangeProof(proof, []byte("key-"), []byte("prefix0"), pairs)
+	require.Error(t, err)
+
+	// a proof with a gap in the middle is rejected
+	gapped, err := tree.GetBatchProof([][]byte{[]byte("key-000"), []byte("key-004")})
+	require.NoError(t, err)
+	_, err = VerifyRangeProof(gapped, []byte("key-000"), []byte("key-004"), []Pair{{Key: []byte("key-000"), Value: []byte("value-0")}})
+	require.Error(t, err)
+
+	// empty tree
+	pairs, proof, err = New(0).GetRangeProof(nil, nil)
+	require.NoError(t, err)
+	require.Empty(t, pairs)
+	root, err := VerifyRangeProof(proof, nil, nil, pairs)
+	require.NoError(t, err)
+	require.Equal(t, emptyHash, root)
+
+	_, _, err = tree.GetRangeProof([]byte("b"), []byte("a"))
+	require.Error(t, err)
+}
+
+func toTestPairs(pairs []Pair) []pair {
+	result := make([]pair, len(pairs))
+	for i, p := range pairs {
+		result[i] = pair{key: p.Key, value: p.Value}
+	}
+	return result
+}
+
+func TestPrefixEnd(t *testing.T) {
+	require.Equal(t, []byte("b"), prefixEnd([]byte("a")))
+	require.Equal(t, []byte{0x01}, prefixEnd([]byte{0x00, 0xff}))
+	require.Nil(t, prefixEnd([]byte{0xff, 0xff}))
+	require.Nil(t, prefixEnd(nil))
+}
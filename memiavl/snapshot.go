@@ -16,7 +16,11 @@ const (
 	SnapshotFileMagic = 1280721225
 
 	// the initial snapshot format
-	SnapshotFormat = 0
+	SnapshotFormatV0 = 0
+	// adds the block checksums and the optional compressed kvs file
+	SnapshotFormatV1 = 1
+	// the format of the newly written snapshots
+	SnapshotFormat = SnapshotFormatV1
 
 	// magic: uint32, format: uint32, version: uint32
 	SizeMetadata = 12
@@ -44,6 +48,13 @@ type Snapshot struct {
 	// parsed from metadata file
 	version uint32
 
+	// verify the block checksums lazily, nil for format 0 snapshots
+	nodesChecksum  *checksumVerifier
+	leavesChecksum *checksumVerifier
+	kvsChecksum    *checksumVerifier
+	// not nil if the kvs are compressed, `kvs` is empty in that case
+	compressedKVs *compressedKVs
+
 	// wrapping the raw nodes buffer
 	nodesLayout  Nodes
 	leavesLayout Leaves
@@ -59,26 +70,17 @@ func NewEmptySnapshot(version uint32) *Snapshot {
 }
 
 // OpenSnapshot parse the version number and the root node index from metadata file,
-// and mmap the other files.
+// and mmap the other files, it supports all the snapshot formats.
 func OpenSnapshot(snapshotDir string) (snapshot *Snapshot, err error) {
+	// the files must match the metadata, in case the snapshot is compressed concurrently
+	defer rlockSnapshot(snapshotDir)()
+
 	// read metadata file
-	bz, err := os.ReadFile(filepath.Join(snapshotDir, FileNameMetadata))
+	metadata, err := readSnapshotMetadata(snapshotDir)
 	if err != nil {
 		return nil, err
 	}
-	if len(bz) != SizeMetadata {
-		return nil, fmt.Errorf("wrong metadata file size, expcted: %d, found: %d", SizeMetadata, len(bz))
-	}
-
-	magic := binary.LittleEndian.Uint32(bz)
-	if magic != SnapshotFileMagic {
-		return nil, fmt.Errorf("invalid metadata file magic: %d", magic)
-	}
-	format := binary.LittleEndian.Uint32(bz[4:])
-	if format != SnapshotFormat {
-		return nil, fmt.Errorf("unknown snapshot format: %d", format)
-	}
-	version := binary.LittleEndian.Uint32(bz[8:])
+	version := metadata.version
 
 	var nodesMap, leavesMap, kvsMap *MmapFile
 	defer func() {
@@ -103,7 +105,11 @@ func OpenSnapshot(snapshotDir string) (snapshot *Snapshot, err error) {
 	if leavesMap, err = NewMmap(filepath.Join(snapshotDir, FileNameLeaves)); err != nil {
 		return nil, err
 	}
-	if kvsMap, err = NewMmap(filepath.Join(snapshotDir, FileNameKVs)); err != nil {
+	kvsFile := FileNameKVs
+	if metadata.compressedKVs() {
+		kvsFile = FileNameCompressedKVs
+	}
+	if kvsMap, err = NewMmap(filepath.Join(snapshotDir, kvsFile)); err != nil {
 		return nil, err
 	}
 
@@ -135,6 +141,33 @@ func OpenSnapshot(snapshotDir string) (snapshot *Snapshot, err error) {
 		return nil, err
 	}
 
+	var nodesChecksum, leavesChecksum, kvsChecksum *checksumVerifier
+	if metadata.format != SnapshotFormatV0 {
+		if nodesChecksum, err = newChecksumVerifier(
+			filepath.Join(snapshotDir, FileNameNodes), nodes, metadata.blockSize, metadata.nodes,
+		); err != nil {
+			return nil, err
+		}
+		if leavesChecksum, err = newChecksumVerifier(
+			filepath.Join(snapshotDir, FileNameLeaves), leaves, metadata.blockSize, metadata.leaves,
+		); err != nil {
+			return nil, err
+		}
+		if kvsChecksum, err = newChecksumVerifier(
+			filepath.Join(snapshotDir, kvsFile), kvs, metadata.blockSize, metadata.kvs,
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	var compressed *compressedKVs
+	if metadata.compressedKVs() {
+		if compressed, err = newCompressedKVs(kvs, kvsChecksum); err != nil {
+			return nil, err
+		}
+		kvs = nil
+	}
+
 	snapshot = &Snapshot{
 		nodesMap:  nodesMap,
 		leavesMap: leavesMap,
@@ -147,6 +180,11 @@ func OpenSnapshot(snapshotDir string) (snapshot *Snapshot, err error) {
 
 		version: version,
 
+		nodesChecksum:  nodesChecksum,
+		leavesChecksum: leavesChecksum,
+		kvsChecksum:    kvsChecksum,
+		compressedKVs:  compressed,
+
 		nodesLayout:  nodesData,
 		leavesLayout: leavesData,
 	}
@@ -255,40 +293,83 @@ func (snapshot *Snapshot) ScanNodes(callback func(node PersistedNode) error) err
 	return nil
 }
 
+// branchNode returns the layout of the branch node by index, verifies the checksum on first access.
+func (snapshot *Snapshot) branchNode(index uint32) NodeLayout {
+	snapshot.nodesChecksum.ensure(uint64(index)*SizeNode, SizeNode)
+	return snapshot.nodesLayout.Node(index)
+}
+
+// leafNode returns the layout of the leaf node by index, verifies the checksum on first access.
+func (snapshot *Snapshot) leafNode(index uint32) LeafLayout {
+	snapshot.leavesChecksum.ensure(uint64(index)*SizeLeaf, SizeLeaf)
+	return snapshot.leavesLayout.Leaf(index)
+}
+
+// kvsRange returns the slice of kvs in range, it's zero-copy unless the kvs are compressed.
+func (snapshot *Snapshot) kvsRange(offset, length uint64) []byte {
+	if snapshot.compressedKVs != nil {
+		return snapshot.compressedKVs.slice(offset, length)
+	}
+	snapshot.kvsChecksum.ensure(offset, length)
+	return snapshot.kvs[offset : offset+length]
+}
+
 // Key returns a zero-copy slice of key by offset
 func (snapshot *Snapshot) Key(offset uint64) []byte {
-	keyLen := binary.LittleEndian.Uint32(snapshot.kvs[offset:])
+	keyLen := binary.LittleEndian.Uint32(snapshot.kvsRange(offset, 4))
 	offset += 4
-	return snapshot.kvs[offset : offset+uint64(keyLen)]
+	return snapshot.kvsRange(offset, uint64(keyLen))
 }
 
 // KeyValue returns a zero-copy slice of key/value pair by offset
 func (snapshot *Snapshot) KeyValue(offset uint64) ([]byte, []byte) {
-	len := uint64(binary.LittleEndian.Uint32(snapshot.kvs[offset:]))
+	len := uint64(binary.LittleEndian.Uint32(snapshot.kvsRange(offset, 4)))
 	offset += 4
-	key := snapshot.kvs[offset : offset+len]
+	key := snapshot.kvsRange(offset, len)
 	offset += len
-	len = uint64(binary.LittleEndian.Uint32(snapshot.kvs[offset:]))
+	len = uint64(binary.LittleEndian.Uint32(snapshot.kvsRange(offset, 4)))
 	offset += 4
-	value := snapshot.kvs[offset : offset+len]
+	value := snapshot.kvsRange(offset, len)
 	return key, value
 }
 
 func (snapshot *Snapshot) LeafKey(index uint32) []byte {
-	leaf := snapshot.leavesLayout.Leaf(index)
+	leaf := snapshot.leafNode(index)
 	offset := leaf.KeyOffset() + 4
-	return snapshot.kvs[offset : offset+uint64(leaf.KeyLength())]
+	return snapshot.kvsRange(offset, uint64(leaf.KeyLength()))
 }
 
 func (snapshot *Snapshot) LeafKeyValue(index uint32) ([]byte, []byte) {
-	leaf := snapshot.leavesLayout.Leaf(index)
+	leaf := snapshot.leafNode(index)
 	offset := leaf.KeyOffset() + 4
 	length := uint64(leaf.KeyLength())
-	key := snapshot.kvs[offset : offset+length]
+	key := snapshot.kvsRange(offset, length)
 	offset += length
-	length = uint64(binary.LittleEndian.Uint32(snapshot.kvs[offset:]))
+	length = uint64(binary.LittleEndian.Uint32(snapshot.kvsRange(offset, 4)))
 	offset += 4
-	return key, snapshot.kvs[offset : offset+length]
+	return key, snapshot.kvsRange(offset, length)
+}
+
+// Verify verifies the checksums of all the files eagerly, it returns an error for format 0 snapshots which don't
+// have checksums.
+func (snapshot *Snapshot) Verify() error {
+	if snapshot.IsEmpty() {
+		return nil
+	}
+	if snapshot.nodesChecksum == nil {
+		return errors.New("snapshot format 0 don't have checksums")
+	}
+	if err := errors.Join(
+		snapshot.nodesChecksum.verifyAll(),
+		snapshot.leavesChecksum.verifyAll(),
+		snapshot.kvsChecksum.verifyAll(),
+	); err != nil {
+		return err
+	}
+	if snapshot.compressedKVs != nil {
+		return snapshot.compressedKVs.verifyAll()
+	}
+	return nil
 }
 
 // Export exports the nodes from snapshot file sequentially, more efficient than a post-order traversal.
@@ -316,10 +397,10 @@ func (snapshot *Snapshot) export(callback func(*ExportNode) bool) {
 	var i, j uint32
 	for ; i < uint32(snapshot.nodesLen()); i++ {
 		// pending branch node
-		node := snapshot.nodesLayout.Node(i)
+		node := snapshot.branchNode(i)
 		for pendingTrees < int(node.PreTrees())+2 {
 			// add more leaf nodes
-			leaf := snapshot.leavesLayout.Leaf(j)
+			leaf := snapshot.leafNode(j)
 			key, value := snapshot.KeyValue(leaf.KeyOffset())
 			enode := &ExportNode{
 				Height:  0,
@@ -408,9 +489,14 @@ func writeSnapshot(
 		}
 	}()
 
-	nodesWriter := bufio.NewWriter(fpNodes)
-	leavesWriter := bufio.NewWriter(fpLeaves)
-	kvsWriter := bufio.NewWriter(fpKVs)
+	// compute the block checksums on the fly
+	nodesChecksum := newChecksumWriter(fpNodes, SnapshotChecksumBlockSize)
+	leavesChecksum := newChecksumWriter(fpLeaves, SnapshotChecksumBlockSize)
+	kvsChecksum := newChecksumWriter(fpKVs, SnapshotChecksumBlockSize)
+
+	nodesWriter := bufio.NewWriter(nodesChecksum)
+	leavesWriter := bufio.NewWriter(leavesChecksum)
+	kvsWriter := bufio.NewWriter(kvsChecksum)
 
 	w := newSnapshotWriter(ctx, nodesWriter, leavesWriter, kvsWriter)
 	leaves, err := doWrite(w)
@@ -441,27 +527,15 @@ func writeSnapshot(
 	}
 
 	// write metadata
-	var metadataBuf [SizeMetadata]byte
-	binary.LittleEndian.PutUint32(metadataBuf[:], SnapshotFileMagic)
-	binary.LittleEndian.PutUint32(metadataBuf[4:], SnapshotFormat)
-	binary.LittleEndian.PutUint32(metadataBuf[8:], version)
-
-	metadataFile := filepath.Join(dir, FileNameMetadata)
-	fpMetadata, err := createFile(metadataFile)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		if err := fpMetadata.Close(); returnErr == nil {
-			returnErr = err
-		}
-	}()
-
-	if _, err := fpMetadata.Write(metadataBuf[:]); err != nil {
-		return err
-	}
-
-	return fpMetadata.Sync()
+	metadata := snapshotMetadata{
+		format:    SnapshotFormat,
+		version:   version,
+		blockSize: SnapshotChecksumBlockSize,
+		nodes:     nodesChecksum.Checksums(),
+		leaves:    leavesChecksum.Checksums(),
+		kvs:       kvsChecksum.Checksums(),
+	}
+	return writeFileSync(filepath.Join(dir, FileNameMetadata), metadata.encode())
 }
 
 type snapshotWriter struct {
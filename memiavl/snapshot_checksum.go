@@ -0,0 +1,327 @@
+package memiavl
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+)
+
+const (
+	// SnapshotChecksumBlockSize is the granularity of the checksums of the snapshot files, the blocks are verified
+	// when they are accessed for the first time.
+	SnapshotChecksumBlockSize = 64 * 1024
+
+	// SnapshotFlagCompressedKVs indicates the kvs are stored block-compressed in the file `kvs.zst`.
+	SnapshotFlagCompressedKVs = 1 << 0
+
+	// magic: uint32, format: uint32, version: uint32, flags: uint32, checksum block size: uint32
+	sizeMetadataV1Header = 20
+)
+
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+// snapshotMetadata is the content of the metadata file, the format 0 only contains the version.
+//
+// Format 1:
+// ```
+// magic: uint32
+// format: uint32
+// version: uint32
+// flags: uint32
+// checksum block size: uint32
+// repeated for nodes, leaves and kvs files:
+//
+//	count: uint32
+//	crc32c of each block: uint32 * count
+//
+// crc32c of the content above: uint32
+// ```
+type snapshotMetadata struct {
+	format    uint32
+	version   uint32
+	flags     uint32
+	blockSize uint32
+	// the block checksums of the nodes, leaves and kvs files
+	nodes, leaves, kvs []uint32
+}
+
+func (m *snapshotMetadata) compressedKVs() bool {
+	return m.flags&SnapshotFlagCompressedKVs != 0
+}
+
+func (m *snapshotMetadata) encode() []byte {
+	if m.format == SnapshotFormatV0 {
+		var buf [SizeMetadata]byte
+		binary.LittleEndian.PutUint32(buf[:], SnapshotFileMagic)
+		binary.LittleEndian.PutUint32(buf[4:], m.format)
+		binary.LittleEndian.PutUint32(buf[8:], m.version)
+		return buf[:]
+	}
+
+	buf := make([]byte, 0, sizeMetadataV1Header+4*(3+len(m.nodes)+len(m.leaves)+len(m.kvs)+1))
+	buf = binary.LittleEndian.AppendUint32(buf, SnapshotFileMagic)
+	buf = binary.LittleEndian.AppendUint32(buf, m.format)
+	buf = binary.LittleEndian.AppendUint32(buf, m.version)
+	buf = binary.LittleEndian.AppendUint32(buf, m.flags)
+	buf = binary.LittleEndian.AppendUint32(buf, m.blockSize)
+	for _, checksums := range [][]uint32{m.nodes, m.leaves, m.kvs} {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(checksums)))
+		for _, checksum := range checksums {
+			buf = binary.LittleEndian.AppendUint32(buf, checksum)
+		}
+	}
+	return binary.LittleEndian.AppendUint32(buf, crc32.Checksum(buf, crc32c))
+}
+
+func decodeSnapshotMetadata(bz []byte) (*snapshotMetadata, error) {
+	if len(bz) < SizeMetadata {
+		return nil, fmt.Errorf("wrong metadata file size, expcted: %d, found: %d", SizeMetadata, len(bz))
+	}
+
+	magic := binary.LittleEndian.Uint32(bz)
+	if magic != SnapshotFileMagic {
+		return nil, fmt.Errorf("invalid metadata file magic: %d", magic)
+	}
+
+	m := &snapshotMetadata{
+		format:  binary.LittleEndian.Uint32(bz[4:]),
+		version: binary.LittleEndian.Uint32(bz[8:]),
+	}
+	switch m.format {
+	case SnapshotFormatV0:
+		if len(bz) != SizeMetadata {
+			return nil, fmt.Errorf("wrong metadata file size, expcted: %d, found: %d", SizeMetadata, len(bz))
+		}
+		return m, nil
+	case SnapshotFormatV1:
+	default:
+		return nil, fmt.Errorf("unknown snapshot format: %d", m.format)
+	}
+
+	if len(bz) < sizeMetadataV1Header+4*3+4 {
+		return nil, fmt.Errorf("metadata file too short: %d", len(bz))
+	}
+	content, checksum := bz[:len(bz)-4], binary.LittleEndian.Uint32(bz[len(bz)-4:])
+	if crc32.Checksum(content, crc32c) != checksum {
+		return nil, errors.New("metadata file checksum mismatch")
+	}
+
+	m.flags = binary.LittleEndian.Uint32(content[12:])
+	m.blockSize = binary.LittleEndian.Uint32(content[16:])
+	if m.blockSize == 0 {
+		return nil, errors.New("invalid checksum block size: 0")
+	}
+
+	content = content[sizeMetadataV1Header:]
+	for _, checksums := range []*[]uint32{&m.nodes, &m.leaves, &m.kvs} {
+		if len(content) < 4 {
+			return nil, errors.New("metadata file truncated")
+		}
+		count := uint64(binary.LittleEndian.Uint32(content))
+		content = content[4:]
+		if uint64(len(content)) < count*4 {
+			return nil, errors.New("metadata file truncated")
+		}
+		*checksums = make([]uint32, count)
+		for i := range *checksums {
+			(*checksums)[i] = binary.LittleEndian.Uint32(content[i*4:])
+		}
+		content = content[count*4:]
+	}
+	if len(content) != 0 {
+		return nil, fmt.Errorf("metadata file has %d trailing bytes", len(content))
+	}
+
+	return m, nil
+}
+
+func readSnapshotMetadata(snapshotDir string) (*snapshotMetadata, error) {
+	bz, err := os.ReadFile(filepath.Join(snapshotDir, FileNameMetadata))
+	if err != nil {
+		return nil, err
+	}
+	return decodeSnapshotMetadata(bz)
+}
+
+// writeSnapshotMetadata replaces the metadata file atomically.
+func writeSnapshotMetadata(snapshotDir string, m *snapshotMetadata) error {
+	tmpPath := filepath.Join(snapshotDir, FileNameMetadata+TmpSuffix)
+	if err := writeFileSync(tmpPath, m.encode()); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, filepath.Join(snapshotDir, FileNameMetadata))
+}
+
+func writeFileSync(name string, data []byte) (returnErr error) {
+	fp, err := createFile(name)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := fp.Close(); returnErr == nil {
+			returnErr = err
+		}
+	}()
+
+	if _, err := fp.Write(data); err != nil {
+		return err
+	}
+	return fp.Sync()
+}
+
+// checksumWriter computes the block checksums of the content written through it.
+type checksumWriter struct {
+	w         io.Writer
+	blockSize int
+
+	checksums []uint32
+	// checksum and length of the pending block
+	current uint32
+	pending int
+}
+
+func newChecksumWriter(w io.Writer, blockSize int) *checksumWriter {
+	return &checksumWriter{w: w, blockSize: blockSize}
+}
+
+func (w *checksumWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	data := p[:n]
+	for len(data) > 0 {
+		size := min(w.blockSize-w.pending, len(data))
+		w.current = crc32.Update(w.current, crc32c, data[:size])
+		w.pending += size
+		data = data[size:]
+		if w.pending == w.blockSize {
+			w.checksums = append(w.checksums, w.current)
+			w.current, w.pending = 0, 0
+		}
+	}
+	return n, err
+}
+
+// Checksums returns the checksums of all the blocks written, including the last partial block.
+func (w *checksumWriter) Checksums() []uint32 {
+	if w.pending == 0 {
+		return w.checksums
+	}
+	return append(w.checksums, w.current)
+}
+
+// computeChecksums computes the block checksums of an existing buffer.
+func computeChecksums(data []byte, blockSize int) []uint32 {
+	checksums := make([]uint32, 0, (len(data)+blockSize-1)/blockSize)
+	for offset := 0; offset < len(data); offset += blockSize {
+		checksums = append(checksums, crc32.Checksum(data[offset:min(offset+blockSize, len(data))], crc32c))
+	}
+	return checksums
+}
+
+// checksumVerifier verifies the blocks of a mmap-ed file lazily, each block is verified when it's accessed for the
+// first time, it's a nop if nil, which is the case for format 0 snapshots.
+//
+// The accessors of the snapshot don't return errors, so a corrupted block panics rather than serving the corrupted
+// data silently.
+type checksumVerifier struct {
+	name      string
+	data      []byte
+	blockSize uint64
+	checksums []uint32
+	// bitmap of the verified blocks
+	verified []atomic.Uint64
+}
+
+func newChecksumVerifier(name string, data []byte, blockSize uint32, checksums []uint32) (*checksumVerifier, error) {
+	expected := (len(data) + int(blockSize) - 1) / int(blockSize)
+	if len(checksums) != expected {
+		return nil, fmt.Errorf("corrupted snapshot, file %s expects %d block checksums, found: %d", name, expected, len(checksums))
+	}
+	return &checksumVerifier{
+		name:      name,
+		data:      data,
+		blockSize: uint64(blockSize),
+		checksums: checksums,
+		verified:  make([]atomic.Uint64, (len(checksums)+63)/64),
+	}, nil
+}
+
+// ensure verifies the blocks overlapping with the range if not verified yet.
+func (v *checksumVerifier) ensure(offset, length uint64) {
+	if v == nil || length == 0 {
+		return
+	}
+	last := (offset + length - 1) / v.blockSize
+	for block := offset / v.blockSize; block <= last; block++ {
+		word, bit := &v.verified[block/64], uint64(1)<<(block%64)
+		if word.Load()&bit != 0 {
+			continue
+		}
+		if err := v.verifyBlock(block); err != nil {
+			panic(err)
+		}
+		for {
+			old := word.Load()
+			if word.CompareAndSwap(old, old|bit) {
+				break
+			}
+		}
+	}
+}
+
+func (v *checksumVerifier) verifyBlock(block uint64) error {
+	start := block * v.blockSize
+	end := min(start+v.blockSize, uint64(len(v.data)))
+	if crc32.Checksum(v.data[start:end], crc32c) != v.checksums[block] {
+		return fmt.Errorf("corrupted snapshot, checksum mismatch in file %s, block %d", v.name, block)
+	}
+	return nil
+}
+
+// verifyAll verifies all the blocks eagerly, returns the first mismatch.
+func (v *checksumVerifier) verifyAll() error {
+	if v == nil {
+		return nil
+	}
+	for block := range v.checksums {
+		if err := v.verifyBlock(uint64(block)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VerifySnapshot verifies the checksums of all the files of a tree snapshot eagerly.
+func VerifySnapshot(snapshotDir string) error {
+	snapshot, err := OpenSnapshot(snapshotDir)
+	if err != nil {
+		return err
+	}
+	return errors.Join(snapshot.Verify(), snapshot.Close())
+}
+
+// VerifyMultiTreeSnapshot verifies the snapshots of all the trees in a multi tree snapshot directory.
+func VerifyMultiTreeSnapshot(dir string) error {
+	return forEachTreeSnapshot(dir, VerifySnapshot)
+}
+
+// forEachTreeSnapshot calls the callback on the snapshot directory of each tree in the multi tree snapshot.
+func forEachTreeSnapshot(dir string, fn func(snapshotDir string) error) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		if err := fn(filepath.Join(dir, e.Name())); err != nil {
+			return fmt.Errorf("tree %s: %w", e.Name(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,340 @@
+package memiavl
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// FileNameCompressedKVs is the block-compressed version of the kvs file.
+	FileNameCompressedKVs = "kvs.zst"
+
+	// KVsCompressionBlockSize is the size of the uncompressed blocks in the compressed kvs file.
+	KVsCompressionBlockSize = 64 * 1024
+
+	// the number of decompressed blocks cached in each snapshot
+	kvsBlockCacheSize = 64
+
+	// uncompressed size: uint64, block size: uint32, blocks: uint32
+	sizeCompressedKVsFooter = 16
+)
+
+var kvsDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+
+// compressedKVs provides random access to the block-compressed kvs file.
+//
+// File format:
+// ```
+// zstd frame of each block
+// offsets of the frames, the last one is the end of the frames: uint64 * (blocks + 1)
+// uncompressed size: uint64
+// block size: uint32
+// blocks: uint32
+// ```
+type compressedKVs struct {
+	data      []byte
+	checksum  *checksumVerifier
+	size      uint64
+	blockSize uint64
+	offsets   []uint64
+
+	mtx   sync.Mutex
+	cache map[uint64][]byte
+	// cached blocks in insertion order, for FIFO eviction
+	order []uint64
+}
+
+func newCompressedKVs(data []byte, checksum *checksumVerifier) (*compressedKVs, error) {
+	if len(data) < sizeCompressedKVsFooter {
+		return nil, fmt.Errorf("corrupted snapshot, compressed kvs file too short: %d", len(data))
+	}
+	// the footer and index are verified eagerly, because they are needed to locate the blocks
+	checksum.ensure(uint64(len(data))-sizeCompressedKVsFooter, sizeCompressedKVsFooter)
+
+	footer := data[len(data)-sizeCompressedKVsFooter:]
+	size := binary.LittleEndian.Uint64(footer)
+	blockSize := uint64(binary.LittleEndian.Uint32(footer[8:]))
+	blocks := uint64(binary.LittleEndian.Uint32(footer[12:]))
+	if blockSize == 0 || (size+blockSize-1)/blockSize != blocks {
+		return nil, fmt.Errorf("corrupted snapshot, invalid compressed kvs footer, size: %d, block size: %d, blocks: %d", size, blockSize, blocks)
+	}
+
+	indexSize := (blocks + 1) * 8
+	if uint64(len(data)) < indexSize+sizeCompressedKVsFooter {
+		return nil, errors.New("corrupted snapshot, compressed kvs file truncated")
+	}
+	checksum.ensure(uint64(len(data))-sizeCompressedKVsFooter-indexSize, indexSize)
+	index := data[uint64(len(data))-sizeCompressedKVsFooter-indexSize : len(data)-sizeCompressedKVsFooter]
+	offsets := make([]uint64, blocks+1)
+	for i := range offsets {
+		offsets[i] = binary.LittleEndian.Uint64(index[i*8:])
+		if (i > 0 && offsets[i] < offsets[i-1]) || offsets[i] > uint64(len(data))-sizeCompressedKVsFooter-indexSize {
+			return nil, errors.New("corrupted snapshot, invalid compressed kvs block offsets")
+		}
+	}
+
+	return &compressedKVs{
+		data:      data,
+		checksum:  checksum,
+		size:      size,
+		blockSize: blockSize,
+		offsets:   offsets,
+		cache:     make(map[uint64][]byte, kvsBlockCacheSize),
+	}, nil
+}
+
+// slice returns the uncompressed content in range, it only copies if the range cross block boundaries.
+func (c *compressedKVs) slice(offset, length uint64) []byte {
+	if offset+length > c.size {
+		panic(fmt.Sprintf("kvs range out of bound: %d + %d > %d", offset, length, c.size))
+	}
+
+	block := offset / c.blockSize
+	start := offset - block*c.blockSize
+	buf := c.block(block)
+	if start+length <= uint64(len(buf)) {
+		return buf[start : start+length]
+	}
+
+	result := make([]byte, 0, length)
+	for uint64(len(result)) < length {
+		n := min(length-uint64(len(result)), uint64(len(buf))-start)
+		result = append(result, buf[start:start+n]...)
+		block++
+		start = 0
+		if uint64(len(result)) < length {
+			buf = c.block(block)
+		}
+	}
+	return result
+}
+
+// block returns the uncompressed content of the block, the result must not be modified.
+func (c *compressedKVs) block(i uint64) []byte {
+	c.mtx.Lock()
+	buf, ok := c.cache[i]
+	c.mtx.Unlock()
+	if ok {
+		return buf
+	}
+
+	buf, err := c.decodeBlock(i)
+	if err != nil {
+		panic(err)
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if _, ok := c.cache[i]; !ok {
+		if len(c.order) >= kvsBlockCacheSize {
+			delete(c.cache, c.order[0])
+			c.order = c.order[1:]
+		}
+		c.cache[i] = buf
+		c.order = append(c.order, i)
+	}
+	return buf
+}
+
+func (c *compressedKVs) decodeBlock(i uint64) ([]byte, error) {
+	start, end := c.offsets[i], c.offsets[i+1]
+	c.checksum.ensure(start, end-start)
+
+	expected := min(c.blockSize, c.size-i*c.blockSize)
+	buf, err := kvsDecoder.DecodeAll(c.data[start:end], make([]byte, 0, expected))
+	if err != nil {
+		return nil, fmt.Errorf("corrupted snapshot, fail to decode kvs block %d: %w", i, err)
+	}
+	if uint64(len(buf)) != expected {
+		return nil, fmt.Errorf("corrupted snapshot, kvs block %d size mismatch, expected: %d, found: %d", i, expected, len(buf))
+	}
+	return buf, nil
+}
+
+// verifyAll decodes all the blocks to verify the integrity.
+func (c *compressedKVs) verifyAll() error {
+	for i := 0; i < len(c.offsets)-1; i++ {
+		if _, err := c.decodeBlock(uint64(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CompressSnapshot converts the kvs file of the snapshot into the block-compressed encoding, it's meant for the cold
+// snapshots, which are accessed much less frequently than the current one. The snapshot is upgraded to the latest
+// format if it's in format 0, it's a nop if it's already compressed.
+//
+// The metadata file is replaced atomically, so the snapshot is always valid on disk, the snapshots already opened
+// are not affected.
+func CompressSnapshot(snapshotDir string) error {
+	metadata, err := readSnapshotMetadata(snapshotDir)
+	if err != nil {
+		return err
+	}
+	if metadata.compressedKVs() {
+		return nil
+	}
+
+	snapshot, err := OpenSnapshot(snapshotDir)
+	if err != nil {
+		return err
+	}
+	defer snapshot.Close()
+
+	if metadata.format == SnapshotFormatV0 {
+		// add the checksums for the other files
+		metadata.format = SnapshotFormatV1
+		metadata.blockSize = SnapshotChecksumBlockSize
+		metadata.nodes = computeChecksums(snapshot.nodes, SnapshotChecksumBlockSize)
+		metadata.leaves = computeChecksums(snapshot.leaves, SnapshotChecksumBlockSize)
+	} else if err := snapshot.kvsChecksum.verifyAll(); err != nil {
+		// don't propagate the corruption into the compressed file
+		return err
+	}
+
+	kvsFile := filepath.Join(snapshotDir, FileNameCompressedKVs)
+	checksums, err := writeCompressedKVs(kvsFile+TmpSuffix, snapshot.kvs, int(metadata.blockSize))
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(kvsFile+TmpSuffix, kvsFile); err != nil {
+		return err
+	}
+
+	metadata.flags |= SnapshotFlagCompressedKVs
+	metadata.kvs = checksums
+
+	// the opening of the snapshot reads the metadata and the kvs file under the read lock, so it don't see the new
+	// metadata with the removed kvs file, the snapshots already opened keep the mmap of the removed file.
+	unlock := lockSnapshot(snapshotDir)
+	defer unlock()
+	if err := writeSnapshotMetadata(snapshotDir, metadata); err != nil {
+		return err
+	}
+
+	return os.Remove(filepath.Join(snapshotDir, FileNameKVs))
+}
+
+// snapshotLocks serializes the file replacements of the snapshots with the opening of them in the process,
+// the locks are indexed by the snapshot directory, and removed when they are not used.
+var snapshotLocks = struct {
+	mtx   sync.Mutex
+	locks map[string]*snapshotLock
+}{locks: make(map[string]*snapshotLock)}
+
+type snapshotLock struct {
+	sync.RWMutex
+	refs int
+}
+
+func acquireSnapshotLock(snapshotDir string) (string, *snapshotLock) {
+	key := filepath.Clean(snapshotDir)
+	snapshotLocks.mtx.Lock()
+	defer snapshotLocks.mtx.Unlock()
+	lock, ok := snapshotLocks.locks[key]
+	if !ok {
+		lock = &snapshotLock{}
+		snapshotLocks.locks[key] = lock
+	}
+	lock.refs++
+	return key, lock
+}
+
+func releaseSnapshotLock(key string, lock *snapshotLock) {
+	snapshotLocks.mtx.Lock()
+	defer snapshotLocks.mtx.Unlock()
+	lock.refs--
+	if lock.refs == 0 {
+		delete(snapshotLocks.locks, key)
+	}
+}
+
+// lockSnapshot locks the snapshot exclusively for replacing the files, returns the unlock function.
+func lockSnapshot(snapshotDir string) func() {
+	key, lock := acquireSnapshotLock(snapshotDir)
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		releaseSnapshotLock(key, lock)
+	}
+}
+
+// rlockSnapshot locks the snapshot for opening the files, returns the unlock function.
+func rlockSnapshot(snapshotDir string) func() {
+	key, lock := acquireSnapshotLock(snapshotDir)
+	lock.RLock()
+	return func() {
+		lock.RUnlock()
+		releaseSnapshotLock(key, lock)
+	}
+}
+
+// CompressMultiTreeSnapshot compresses the snapshots of all the trees in a multi tree snapshot directory.
+func CompressMultiTreeSnapshot(dir string) error {
+	return forEachTreeSnapshot(dir, CompressSnapshot)
+}
+
+// writeCompressedKVs writes the compressed kvs file, returns the block checksums of the file.
+func writeCompressedKVs(fileName string, kvs []byte, checksumBlockSize int) (checksums []uint32, returnErr error) {
+	fp, err := createFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := fp.Close(); returnErr == nil {
+			returnErr = err
+		}
+	}()
+
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBetterCompression))
+	if err != nil {
+		return nil, err
+	}
+	defer encoder.Close()
+
+	cw := newChecksumWriter(fp, checksumBlockSize)
+	writer := bufio.NewWriter(cw)
+
+	blocks := (len(kvs) + KVsCompressionBlockSize - 1) / KVsCompressionBlockSize
+	offsets := make([]uint64, 0, blocks+1)
+	var (
+		offset uint64
+		buf    []byte
+	)
+	for i := 0; i < blocks; i++ {
+		buf = encoder.EncodeAll(kvs[i*KVsCompressionBlockSize:min((i+1)*KVsCompressionBlockSize, len(kvs))], buf[:0])
+		if _, err := writer.Write(buf); err != nil {
+			return nil, err
+		}
+		offsets = append(offsets, offset)
+		offset += uint64(len(buf))
+	}
+	offsets = append(offsets, offset)
+
+	footer := make([]byte, 0, len(offsets)*8+sizeCompressedKVsFooter)
+	for _, offset := range offsets {
+		footer = binary.LittleEndian.AppendUint64(footer, offset)
+	}
+	footer = binary.LittleEndian.AppendUint64(footer, uint64(len(kvs)))
+	footer = binary.LittleEndian.AppendUint32(footer, KVsCompressionBlockSize)
+	footer = binary.LittleEndian.AppendUint32(footer, uint32(blocks))
+	if _, err := writer.Write(footer); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Flush(); err != nil {
+		return nil, err
+	}
+	if err := fp.Sync(); err != nil {
+		return nil, err
+	}
+	return cw.Checksums(), nil
+}
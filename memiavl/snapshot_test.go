@@ -1,6 +1,11 @@
 package memiavl
 
 import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -167,7 +172,7 @@ func testSnapshotRoundTrip(t *testing.T, db *DB) {
 	require.NoError(t, err)
 
 	restoreDir := t.TempDir()
-	importer, err := NewMultiTreeImporter(restoreDir, uint64(db.Version()))
+	importer, err := NewMultiTreeImporter(restoreDir, uint64(db.Version()), 0)
 	require.NoError(t, err)
 
 	for {
@@ -191,3 +196,182 @@ func testSnapshotRoundTrip(t *testing.T, db *DB) {
 	_, err = db2.Commit()
 	require.NoError(t, err)
 }
+
+func TestExportImportDir(t *testing.T) {
+	db, err := Load(t.TempDir(), Options{
+		CreateIfMissing:   true,
+		InitialStores:     []string{"test", "test2", "test3"},
+		AsyncCommitBuffer: -1,
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	for _, changes := range ChangeSets {
+		require.NoError(t, db.ApplyChangeSets([]*NamedChangeSet{
+			{Name: "test", Changeset: changes},
+			{Name: "test2", Changeset: changes},
+		}))
+		_, err := db.Commit()
+		require.NoError(t, err)
+	}
+	require.NoError(t, db.RewriteSnapshot())
+
+	exporter, err := NewMultiTreeExporter(db.dir, uint32(db.Version()), false)
+	require.NoError(t, err)
+	exportDir := t.TempDir()
+	require.NoError(t, exporter.ExportToDir(exportDir, 2))
+	require.NoError(t, exporter.Close())
+
+	for _, name := range []string{"test", "test2", "test3"} {
+		require.FileExists(t, filepath.Join(exportDir, name+ExportFileSuffix))
+	}
+
+	restoreDir := t.TempDir()
+	importer, err := NewMultiTreeImporter(restoreDir, uint64(db.Version()), 2)
+	require.NoError(t, err)
+	require.NoError(t, importer.ImportDir(exportDir))
+	require.NoError(t, importer.Finalize())
+	require.NoError(t, importer.Close())
+
+	db2, err := Load(restoreDir, Options{})
+	require.NoError(t, err)
+	require.Equal(t, db.LastCommitInfo(), db2.LastCommitInfo())
+	require.NoError(t, db2.Close())
+
+	// truncated export file
+	path := filepath.Join(exportDir, "test"+ExportFileSuffix)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(path, info.Size()-1))
+
+	importer, err = NewMultiTreeImporter(t.TempDir(), uint64(db.Version()), 2)
+	require.NoError(t, err)
+	require.ErrorIs(t, importer.ImportDir(exportDir), io.ErrUnexpectedEOF)
+	require.NoError(t, importer.Close())
+}
+
+func writeLargeSnapshot(t *testing.T) (*Tree, string) {
+	tree := New(0)
+	var changeSet ChangeSet
+	for i := 0; i < 5000; i++ {
+		changeSet.Pairs = append(changeSet.Pairs, &KVPair{
+			Key:   []byte(fmt.Sprintf("key-%08d", i)),
+			Value: []byte(fmt.Sprintf("value-%0100d", i)),
+		})
+	}
+	tree.ApplyChangeSet(changeSet)
+	_, _, err := tree.SaveVersion(true)
+	require.NoError(t, err)
+
+	snapshotDir := t.TempDir()
+	require.NoError(t, tree.WriteSnapshot(snapshotDir))
+	return tree, snapshotDir
+}
+
+func TestSnapshotChecksum(t *testing.T) {
+	_, snapshotDir := writeLargeSnapshot(t)
+	require.NoError(t, VerifySnapshot(snapshotDir))
+
+	// flip a bit in the second block of kvs file
+	kvsFile := filepath.Join(snapshotDir, FileNameKVs)
+	bz, err := os.ReadFile(kvsFile)
+	require.NoError(t, err)
+	require.Greater(t, len(bz), SnapshotChecksumBlockSize*2)
+	bz[SnapshotChecksumBlockSize+1] ^= 1
+	require.NoError(t, os.WriteFile(kvsFile, bz, 0o600))
+
+	require.ErrorContains(t, VerifySnapshot(snapshotDir), "checksum mismatch")
+
+	snapshot, err := OpenSnapshot(snapshotDir)
+	require.NoError(t, err)
+	defer snapshot.Close()
+
+	// the first block is still accessible
+	key, _ := snapshot.LeafKeyValue(0)
+	require.Equal(t, []byte("key-00000000"), key)
+	// the corrupted block is detected on first access
+	require.Panics(t, func() {
+		for i := 0; i < snapshot.leavesLen(); i++ {
+			snapshot.LeafKeyValue(uint32(i))
+		}
+	})
+}
+
+func TestCompressSnapshot(t *testing.T) {
+	tree, snapshotDir := writeLargeSnapshot(t)
+
+	require.NoError(t, CompressSnapshot(snapshotDir))
+	// idempotent
+	require.NoError(t, CompressSnapshot(snapshotDir))
+	require.NoError(t, VerifySnapshot(snapshotDir))
+
+	_, err := os.Stat(filepath.Join(snapshotDir, FileNameKVs))
+	require.True(t, os.IsNotExist(err))
+
+	snapshot, err := OpenSnapshot(snapshotDir)
+	require.NoError(t, err)
+	defer snapshot.Close()
+	require.NotNil(t, snapshot.compressedKVs)
+
+	tree2 := NewFromSnapshot(snapshot, true, 0)
+	require.Equal(t, tree.RootHash(), tree2.RootHash())
+	require.Equal(t,
+		collectIter(tree.Iterator(nil, nil, true)),
+		collectIter(tree2.Iterator(nil, nil, true)),
+	)
+	for i := 0; i < snapshot.nodesLen(); i++ {
+		node := snapshot.Node(uint32(i))
+		require.Equal(t, node.Hash(), HashNode(node))
+	}
+}
+
+func TestCompressSnapshotConcurrentOpen(t *testing.T) {
+	tree, snapshotDir := writeLargeSnapshot(t)
+
+	done := make(chan error)
+	go func() {
+		done <- CompressSnapshot(snapshotDir)
+	}()
+
+	// the snapshot is always opened consistently while it's being compressed
+	for compressed := false; !compressed; {
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+			compressed = true
+		default:
+		}
+
+		snapshot, err := OpenSnapshot(snapshotDir)
+		require.NoError(t, err)
+		require.Equal(t, tree.RootHash(), NewFromSnapshot(snapshot, true, 0).RootHash())
+		require.NoError(t, snapshot.Close())
+	}
+	require.Empty(t, snapshotLocks.locks)
+}
+
+func TestSnapshotFormatV0(t *testing.T) {
+	tree, snapshotDir := writeLargeSnapshot(t)
+
+	// downgrade the metadata file to format 0
+	var metadata [SizeMetadata]byte
+	binary.LittleEndian.PutUint32(metadata[:], SnapshotFileMagic)
+	binary.LittleEndian.PutUint32(metadata[4:], SnapshotFormatV0)
+	binary.LittleEndian.PutUint32(metadata[8:], uint32(tree.Version()))
+	require.NoError(t, os.WriteFile(filepath.Join(snapshotDir, FileNameMetadata), metadata[:], 0o600))
+
+	snapshot, err := OpenSnapshot(snapshotDir)
+	require.NoError(t, err)
+	require.Equal(t, tree.RootHash(), NewFromSnapshot(snapshot, true, 0).RootHash())
+	require.Error(t, snapshot.Verify())
+	require.NoError(t, snapshot.Close())
+
+	// compression upgrades the snapshot to the latest format
+	require.NoError(t, CompressSnapshot(snapshotDir))
+	require.NoError(t, VerifySnapshot(snapshotDir))
+
+	snapshot, err = OpenSnapshot(snapshotDir)
+	require.NoError(t, err)
+	defer snapshot.Close()
+	require.Equal(t, tree.RootHash(), NewFromSnapshot(snapshot, true, 0).RootHash())
+}
@@ -11,11 +11,12 @@ import (
 
 var emptyHash = sha256.New().Sum(nil)
 
+// NewCache creates a concurrency-safe lru cache for the tree, returns nil if `cacheSize` is 0.
 func NewCache(cacheSize int) cache.Cache {
 	if cacheSize == 0 {
 		return nil
 	}
-	return cache.New(cacheSize)
+	return newShardedCache(cacheSize)
 }
 
 // verify change sets by replay them to rebuild iavl tree and verify the root hashes
@@ -25,7 +26,7 @@ type Tree struct {
 	root     Node
 	snapshot *Snapshot
 
-	// simple lru cache provided by iavl library
+	// sharded lru cache, safe for concurrent readers
 	cache cache.Cache
 
 	// when true, the get and iterator methods could return a slice pointing to mmaped blob files.
@@ -122,7 +123,7 @@ func (t *Tree) Copy(cacheSize int) *Tree {
 		t.cowVersion = t.version
 	}
 	newTree := *t
-	// cache is not copied along because it's not thread-safe to access
+	// cache is not shared, because it'll be updated by the further modifications on the main tree
 	newTree.cache = NewCache(cacheSize)
 	return &newTree
 }
@@ -0,0 +1,168 @@
+package memiavl
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrViewNotAvailable is returned by `DB.ViewAt` if the version can't be served from the in-memory trees.
+var ErrViewNotAvailable = errors.New("view not available")
+
+// View is an immutable snapshot of the db at a committed version, it shares the nodes with the db through
+// copy-on-write, so it's cheap to create and safe to read concurrently with the commits.
+//
+// The views are reference-counted, the caller must call `Release` after finishing using it, the mmap-ed snapshot
+// files are only closed after all the views referencing them are released.
+type View struct {
+	*MultiTree
+
+	db   *DB
+	base *viewBase
+	// protected by `db.viewsMtx`
+	refs int
+}
+
+// viewBase tracks the views derived from the trees loaded from the same snapshot, the trees are closed when
+// they are switched out and no view references them anymore.
+type viewBase struct {
+	// the retired multi tree to close, nil if it's still in use by the db
+	retired *MultiTree
+	refs    int
+}
+
+// Release decrements the reference count of the view, the view must not be used after the last release.
+func (v *View) Release() error {
+	v.db.viewsMtx.Lock()
+	defer v.db.viewsMtx.Unlock()
+
+	return v.db.unrefView(v)
+}
+
+// ViewAt returns a read-only view of the db at the version, 0 means the latest version.
+//
+// The latest version is always available if there are no pending changes, the older versions are only available
+// if they were viewed before or `ViewsKeepRecent` is set, and are not evicted yet. It returns `ErrViewNotAvailable`
+// otherwise, the caller could fallback to load the version from disk.
+func (db *DB) ViewAt(version int64) (*View, error) {
+	// fast path, don't contend with the commits
+	db.viewsMtx.Lock()
+	view, ok := db.views[version]
+	if version == 0 {
+		view, ok = db.latestView, db.latestView != nil
+	}
+	if ok {
+		view.refs++
+	}
+	db.viewsMtx.Unlock()
+	if ok {
+		return view, nil
+	}
+
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
+	latest := db.MultiTree.Version()
+	if version == 0 {
+		version = latest
+	}
+
+	db.viewsMtx.Lock()
+	defer db.viewsMtx.Unlock()
+
+	if view, ok := db.views[version]; ok {
+		view.refs++
+		return view, nil
+	}
+
+	if version != latest {
+		return nil, fmt.Errorf("%w: version %d, latest: %d", ErrViewNotAvailable, version, latest)
+	}
+	if len(db.pendingLog.Changesets) > 0 || len(db.pendingLog.Upgrades) > 0 {
+		// the trees are modified in-place by the pending changes
+		return nil, fmt.Errorf("%w: version %d has pending changes", ErrViewNotAvailable, version)
+	}
+
+	view = db.newView()
+	view.refs++
+	return view, nil
+}
+
+// newView creates a view of current version and caches it, it must be called with both locks held.
+func (db *DB) newView() *View {
+	version := db.MultiTree.Version()
+	view := &View{
+		MultiTree: db.MultiTree.Copy(0),
+		db:        db,
+		base:      db.viewBase,
+		// referenced by the cache
+		refs: 1,
+	}
+	view.base.refs++
+	db.views[version] = view
+	db.latestView = view
+
+	// evict the old versions
+	keepRecent := max(int64(db.viewsKeepRecent), 1)
+	for v, old := range db.views {
+		if v <= version-keepRecent {
+			delete(db.views, v)
+			if err := db.unrefView(old); err != nil {
+				db.logger.Error("failed to close retired trees", "err", err)
+			}
+		}
+	}
+	return view
+}
+
+// unrefView must be called with `db.viewsMtx` held.
+func (db *DB) unrefView(v *View) error {
+	if v.refs <= 0 {
+		return errors.New("view released too many times")
+	}
+	v.refs--
+	if v.refs > 0 {
+		return nil
+	}
+
+	v.base.refs--
+	if v.base.refs > 0 || v.base.retired == nil {
+		return nil
+	}
+	delete(db.retiredViews, v.base)
+	return v.base.retired.Close()
+}
+
+// retireViews is called when the trees are switched out, the old trees are closed immediately if no view
+// references them, it must be called with `db.mtx` held.
+func (db *DB) retireViews() error {
+	db.viewsMtx.Lock()
+	defer db.viewsMtx.Unlock()
+
+	old := db.MultiTree
+	base := db.viewBase
+	db.viewBase = &viewBase{}
+	db.latestView = nil
+	if base.refs == 0 {
+		return old.Close()
+	}
+	base.retired = &old
+	db.retiredViews[base] = struct{}{}
+	return nil
+}
+
+// closeViews releases the cached views and closes all the retired trees, the views still in use are invalidated,
+// it must be called with `db.mtx` held.
+func (db *DB) closeViews() error {
+	db.viewsMtx.Lock()
+	defer db.viewsMtx.Unlock()
+
+	var errs []error
+	for base := range db.retiredViews {
+		errs = append(errs, base.retired.Close())
+		base.retired = nil
+	}
+	db.views = make(map[int64]*View)
+	db.latestView = nil
+	db.retiredViews = make(map[*viewBase]struct{})
+	return errors.Join(errs...)
+}
@@ -0,0 +1,117 @@
+package memiavl
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewAt(t *testing.T) {
+	db, err := Load(t.TempDir(), Options{
+		CreateIfMissing:   true,
+		InitialStores:     []string{"test"},
+		AsyncCommitBuffer: -1,
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.ApplyChangeSets([]*NamedChangeSet{{Name: "test", Changeset: ChangeSets[0]}}))
+	_, err = db.Commit()
+	require.NoError(t, err)
+
+	view, err := db.ViewAt(0)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), view.Version())
+
+	// the working tree is not observable through the views
+	require.NoError(t, db.ApplyChangeSets([]*NamedChangeSet{{Name: "test", Changeset: ChangeSets[1]}}))
+	// the latest view is served without contending with the commits
+	db.mtx.Lock()
+	view2, err := db.ViewAt(0)
+	db.mtx.Unlock()
+	require.NoError(t, err)
+	require.Equal(t, view, view2)
+	require.NoError(t, view2.Release())
+	_, err = db.Commit()
+	require.NoError(t, err)
+
+	// pending changes
+	require.NoError(t, db.ApplyChangeSets([]*NamedChangeSet{{Name: "test", Changeset: ChangeSets[2]}}))
+	_, err = db.ViewAt(2)
+	require.ErrorIs(t, err, ErrViewNotAvailable)
+	_, err = db.Commit()
+	require.NoError(t, err)
+
+	// switch out the trees while the view is still in use
+	require.NoError(t, db.RewriteSnapshot())
+	require.NoError(t, db.Reload())
+
+	require.Equal(t, RefHashes[0], view.TreeByName("test").RootHash())
+	require.Equal(t, ExpectItems[1], collectIter(view.TreeByName("test").Iterator(nil, nil, true)))
+	require.NoError(t, view.Release())
+	require.Len(t, db.retiredViews, 1)
+
+	// evicted by the newer view
+	view, err = db.ViewAt(3)
+	require.NoError(t, err)
+	require.Equal(t, RefHashes[2], view.TreeByName("test").RootHash())
+	require.NoError(t, view.Release())
+	require.Empty(t, db.retiredViews)
+
+	_, err = db.ViewAt(1)
+	require.ErrorIs(t, err, ErrViewNotAvailable)
+
+	// the latest view is reset by the commit
+	require.NoError(t, db.ApplyChangeSets([]*NamedChangeSet{{Name: "test", Changeset: ChangeSets[3]}}))
+	_, err = db.Commit()
+	require.NoError(t, err)
+	view, err = db.ViewAt(0)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), view.Version())
+	require.NoError(t, view.Release())
+}
+
+func TestViewsKeepRecent(t *testing.T) {
+	db, err := Load(t.TempDir(), Options{
+		CreateIfMissing:   true,
+		InitialStores:     []string{"test"},
+		AsyncCommitBuffer: -1,
+		ViewsKeepRecent:   2,
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	var wg sync.WaitGroup
+	for i, changes := range ChangeSets {
+		require.NoError(t, db.ApplyChangeSets([]*NamedChangeSet{{Name: "test", Changeset: changes}}))
+		v, err := db.Commit()
+		require.NoError(t, err)
+
+		if i%3 == 0 {
+			require.NoError(t, db.RewriteSnapshot())
+			require.NoError(t, db.Reload())
+		}
+
+		// read the views concurrently with the next commits
+		for _, version := range []int64{v - 1, v} {
+			if version == 0 {
+				continue
+			}
+			view, err := db.ViewAt(version)
+			require.NoError(t, err)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer view.Release()
+				require.Equal(t, RefHashes[version-1], view.TreeByName("test").RootHash())
+				require.Equal(t, ExpectItems[version], collectIter(view.TreeByName("test").Iterator(nil, nil, true)))
+			}()
+		}
+		if v > 2 {
+			_, err = db.ViewAt(v - 2)
+			require.ErrorIs(t, err, ErrViewNotAvailable)
+		}
+	}
+	wg.Wait()
+}
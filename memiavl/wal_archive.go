@@ -0,0 +1,538 @@
+package memiavl
+
+import (
+	"bufio"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/tidwall/wal"
+)
+
+const (
+	WALArchiveManifestFileName = "manifest.json"
+
+	// the compression algorithms of the archived change set files
+	WALArchiveCompressionNone = "none"
+	WALArchiveCompressionZlib = "zlib"
+	WALArchiveCompressionZstd = "zstd"
+
+	ZlibFileSuffix = ".zz"
+	ZstdFileSuffix = ".zst"
+
+	// DefaultWALArchiveChunkSize is the same as the default chunk size of the `changeset dump` command.
+	DefaultWALArchiveChunkSize = 1000000
+)
+
+// WALArchiveManifest records the change set files and the store upgrades archived from the WAL,
+// the store upgrades can't be represented in the change set files.
+type WALArchiveManifest struct {
+	Files    []WALArchiveFile    `json:"files"`
+	Upgrades []WALArchiveUpgrade `json:"upgrades"`
+}
+
+// WALArchiveFile describes an archived change set file, it contains the change sets of a store in the version
+// range `[StartVersion, EndVersion]`, the path is relative to the archive directory.
+type WALArchiveFile struct {
+	Store        string `json:"store"`
+	StartVersion int64  `json:"start_version"`
+	EndVersion   int64  `json:"end_version"`
+	File         string `json:"file"`
+}
+
+// WALArchiveUpgrade is a store upgrade applied at the version.
+type WALArchiveUpgrade struct {
+	Version int64 `json:"version"`
+	TreeNameUpgrade
+}
+
+func validateWALArchiveCompression(compression string) error {
+	switch compression {
+	case "", WALArchiveCompressionNone, WALArchiveCompressionZlib, WALArchiveCompressionZstd:
+		return nil
+	default:
+		return fmt.Errorf("unknown wal archive compression: %s", compression)
+	}
+}
+
+// archiveWAL converts the WAL entries up to the end version into change set files in the archive directory,
+// it's called before the entries are truncated, the end version must have a snapshot in the db.
+func (db *DB) archiveWAL(initialVersion uint32, endVersion int64) error {
+	firstIndex, err := db.wal.FirstIndex()
+	if err != nil {
+		return err
+	}
+	if firstIndex == 0 {
+		// empty wal
+		return nil
+	}
+	startVersion := walVersion(firstIndex, initialVersion)
+	if startVersion > endVersion {
+		return nil
+	}
+
+	stores, err := snapshotStores(filepath.Join(db.dir, snapshotName(endVersion)))
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(db.walArchiveDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	files, upgrades, err := archiveWAL(
+		db.wal, initialVersion, startVersion, endVersion, stores, db.walArchiveDir, db.walArchiveCompression, db.walArchiveChunkSize,
+	)
+	if err != nil {
+		return err
+	}
+
+	db.logger.Info("archived wal", "start", startVersion, "end", endVersion, "dir", db.walArchiveDir)
+	return updateWALArchiveManifest(db.walArchiveDir, startVersion, endVersion, files, upgrades)
+}
+
+// DumpWAL writes the change sets of the versions `[startVersion, endVersion]` in the WAL of the db into per-store
+// change set files in the output directory, together with a manifest, in the same layout as the WAL archive.
+// 0 start or end version means the first or last version in the WAL, 0 chunk size means no limit of the blocks in
+// each file. The db must not be pruned concurrently.
+func DumpWAL(dir string, startVersion, endVersion int64, outDir, compression string, chunkSize int64) (_ *WALArchiveManifest, returnErr error) {
+	if err := validateWALArchiveCompression(compression); err != nil {
+		return nil, err
+	}
+
+	metadata, err := readMetadata(currentPath(dir))
+	if err != nil {
+		return nil, err
+	}
+	initialVersion := uint32(metadata.InitialVersion)
+
+	log, err := OpenWAL(walPath(dir), &wal.Options{NoCopy: true, NoSync: true})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		returnErr = errors.Join(returnErr, log.Close())
+	}()
+
+	firstIndex, err := log.FirstIndex()
+	if err != nil {
+		return nil, err
+	}
+	lastIndex, err := log.LastIndex()
+	if err != nil {
+		return nil, err
+	}
+	if firstIndex == 0 {
+		return nil, errors.New("empty wal")
+	}
+	firstVersion, lastVersion := walVersion(firstIndex, initialVersion), walVersion(lastIndex, initialVersion)
+	if startVersion == 0 {
+		startVersion = firstVersion
+	}
+	if endVersion == 0 {
+		endVersion = lastVersion
+	}
+	if startVersion < firstVersion || endVersion > lastVersion || startVersion > endVersion {
+		return nil, fmt.Errorf("invalid version range [%d, %d], the wal contains [%d, %d]", startVersion, endVersion, firstVersion, lastVersion)
+	}
+
+	stores, err := walStores(dir, log, initialVersion, endVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(outDir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	files, upgrades, err := archiveWAL(log, initialVersion, startVersion, endVersion, stores, outDir, compression, chunkSize)
+	if err != nil {
+		return nil, err
+	}
+	if err := updateWALArchiveManifest(outDir, startVersion, endVersion, files, upgrades); err != nil {
+		return nil, err
+	}
+	return ReadWALArchiveManifest(outDir)
+}
+
+// walStores returns the stores existing at the version, by applying the store upgrades in the WAL on top of the
+// closest snapshot.
+func walStores(dir string, log *wal.Log, initialVersion uint32, version int64) ([]string, error) {
+	snapshotVersion, err := seekSnapshot(dir, uint32(version))
+	if err != nil {
+		return nil, err
+	}
+	names, err := snapshotStores(filepath.Join(dir, snapshotName(snapshotVersion)))
+	if err != nil {
+		return nil, err
+	}
+
+	stores := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		stores[name] = struct{}{}
+	}
+	for v := nextVersion(snapshotVersion, initialVersion); v <= version; v++ {
+		bz, err := log.Read(walIndex(v, initialVersion))
+		if err != nil {
+			return nil, fmt.Errorf("read wal log failed, %w", err)
+		}
+		var entry WALEntry
+		if err := entry.Unmarshal(bz); err != nil {
+			return nil, fmt.Errorf("unmarshal wal log failed, %w", err)
+		}
+		for _, upgrade := range entry.Upgrades {
+			switch {
+			case upgrade.Delete:
+				delete(stores, upgrade.Name)
+			case upgrade.RenameFrom != "":
+				delete(stores, upgrade.RenameFrom)
+				stores[upgrade.Name] = struct{}{}
+			default:
+				stores[upgrade.Name] = struct{}{}
+			}
+		}
+	}
+
+	names = make([]string, 0, len(stores))
+	for name := range stores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// archiveWAL writes the change sets of the versions `[startVersion, endVersion]` in the WAL into per-store change
+// set files, named by the first version, the same as the ones dumped from the iavl db. `stores` is the stores
+// existing at the end version, every store has a change set for each version, even if it's empty. A store is split
+// into multiple files if the versions exceed the chunk size, 0 means no limit.
+func archiveWAL(
+	log *wal.Log, initialVersion uint32, startVersion, endVersion int64, stores []string, archiveDir, compression string,
+	chunkSize int64,
+) (files []WALArchiveFile, upgrades []WALArchiveUpgrade, returnErr error) {
+	readEntry := func(version int64) (*WALEntry, error) {
+		bz, err := log.Read(walIndex(version, initialVersion))
+		if err != nil {
+			return nil, fmt.Errorf("read wal log failed, %w", err)
+		}
+		var entry WALEntry
+		if err := entry.Unmarshal(bz); err != nil {
+			return nil, fmt.Errorf("unmarshal wal log failed, %w", err)
+		}
+		return &entry, nil
+	}
+
+	// collect the upgrades first, to figure out the stores at the start version
+	for version := startVersion; version <= endVersion; version++ {
+		entry, err := readEntry(version)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, upgrade := range entry.Upgrades {
+			upgrades = append(upgrades, WALArchiveUpgrade{Version: version, TreeNameUpgrade: *upgrade})
+		}
+	}
+
+	current := make(map[string]struct{}, len(stores))
+	for _, name := range stores {
+		current[name] = struct{}{}
+	}
+	for i := len(upgrades) - 1; i >= 0; i-- {
+		upgrade := upgrades[i]
+		switch {
+		case upgrade.Delete:
+			current[upgrade.Name] = struct{}{}
+		case upgrade.RenameFrom != "":
+			delete(current, upgrade.Name)
+			current[upgrade.RenameFrom] = struct{}{}
+		default:
+			delete(current, upgrade.Name)
+		}
+	}
+
+	writers := make(map[string]*changeSetFileWriter, len(current))
+	closeWriter := func(name string) error {
+		w, ok := writers[name]
+		if !ok {
+			return nil
+		}
+		delete(writers, name)
+		if err := w.Close(); err != nil {
+			return err
+		}
+		files = append(files, w.file)
+		return nil
+	}
+	defer func() {
+		names := make([]string, 0, len(writers))
+		for name := range writers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			returnErr = errors.Join(returnErr, closeWriter(name))
+		}
+	}()
+
+	for version := startVersion; version <= endVersion; version++ {
+		entry, err := readEntry(version)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, upgrade := range entry.Upgrades {
+			switch {
+			case upgrade.Delete:
+				delete(current, upgrade.Name)
+				err = closeWriter(upgrade.Name)
+			case upgrade.RenameFrom != "":
+				delete(current, upgrade.RenameFrom)
+				current[upgrade.Name] = struct{}{}
+				err = closeWriter(upgrade.RenameFrom)
+			default:
+				current[upgrade.Name] = struct{}{}
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		changeSets := make(map[string]*ChangeSet, len(entry.Changesets))
+		for _, cs := range entry.Changesets {
+			changeSets[cs.Name] = &cs.Changeset
+		}
+
+		for name := range current {
+			w, ok := writers[name]
+			if ok && chunkSize > 0 && version-w.file.StartVersion >= chunkSize {
+				// start a new chunk
+				if err := closeWriter(name); err != nil {
+					return nil, nil, err
+				}
+				ok = false
+			}
+			if !ok {
+				w, err = newChangeSetFileWriter(archiveDir, name, version, compression)
+				if err != nil {
+					return nil, nil, err
+				}
+				writers[name] = w
+			}
+
+			var pairs []*KVPair
+			if cs, ok := changeSets[name]; ok {
+				pairs = cs.Pairs
+			}
+			if err := w.Write(version, pairs); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	return files, upgrades, nil
+}
+
+// snapshotStores returns the store names in the snapshot directory.
+func snapshotStores(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var stores []string
+	for _, e := range entries {
+		if e.IsDir() {
+			stores = append(stores, e.Name())
+		}
+	}
+	return stores, nil
+}
+
+// updateWALArchiveManifest adds the new files and upgrades into the manifest, replacing the existing records of the
+// same version range, in case the previous archive was interrupted before the WAL is truncated.
+func updateWALArchiveManifest(dir string, startVersion, endVersion int64, files []WALArchiveFile, upgrades []WALArchiveUpgrade) error {
+	manifest, err := ReadWALArchiveManifest(dir)
+	if err != nil {
+		return err
+	}
+
+	newFiles := make(map[string]struct{}, len(files))
+	for _, file := range files {
+		newFiles[file.File] = struct{}{}
+	}
+	oldFiles := manifest.Files
+	manifest.Files = make([]WALArchiveFile, 0, len(oldFiles)+len(files))
+	for _, file := range oldFiles {
+		if _, ok := newFiles[file.File]; !ok {
+			manifest.Files = append(manifest.Files, file)
+		}
+	}
+	manifest.Files = append(manifest.Files, files...)
+	sort.SliceStable(manifest.Files, func(i, j int) bool {
+		if manifest.Files[i].StartVersion != manifest.Files[j].StartVersion {
+			return manifest.Files[i].StartVersion < manifest.Files[j].StartVersion
+		}
+		return manifest.Files[i].Store < manifest.Files[j].Store
+	})
+
+	oldUpgrades := manifest.Upgrades
+	manifest.Upgrades = make([]WALArchiveUpgrade, 0, len(oldUpgrades)+len(upgrades))
+	for _, upgrade := range oldUpgrades {
+		if upgrade.Version < startVersion || upgrade.Version > endVersion {
+			manifest.Upgrades = append(manifest.Upgrades, upgrade)
+		}
+	}
+	manifest.Upgrades = append(manifest.Upgrades, upgrades...)
+	sort.SliceStable(manifest.Upgrades, func(i, j int) bool {
+		return manifest.Upgrades[i].Version < manifest.Upgrades[j].Version
+	})
+
+	bz, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// replace the manifest file atomically
+	tmpPath := filepath.Join(dir, WALArchiveManifestFileName+TmpSuffix)
+	if err := WriteFileSync(tmpPath, bz); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, filepath.Join(dir, WALArchiveManifestFileName))
+}
+
+// ReadWALArchiveManifest reads the manifest in the wal archive directory, returns empty manifest if not exists.
+func ReadWALArchiveManifest(dir string) (*WALArchiveManifest, error) {
+	var manifest WALArchiveManifest
+	bz, err := os.ReadFile(filepath.Join(dir, WALArchiveManifestFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &manifest, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(bz, &manifest); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// changeSetFileWriter writes the change sets of a store into a file, in the change set file format.
+type changeSetFileWriter struct {
+	fp         *os.File
+	buf        *bufio.Writer
+	compressor io.WriteCloser
+	writer     io.Writer
+
+	file WALArchiveFile
+}
+
+func newChangeSetFileWriter(dir, store string, startVersion int64, compression string) (*changeSetFileWriter, error) {
+	if err := os.MkdirAll(filepath.Join(dir, store), os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("block-%d", startVersion)
+	switch compression {
+	case "", WALArchiveCompressionZlib:
+		name += ZlibFileSuffix
+	case WALArchiveCompressionZstd:
+		name += ZstdFileSuffix
+	}
+	file := filepath.Join(store, name)
+
+	fp, err := os.OpenFile(filepath.Join(dir, file), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &changeSetFileWriter{
+		fp:  fp,
+		buf: bufio.NewWriter(fp),
+		file: WALArchiveFile{
+			Store:        store,
+			StartVersion: startVersion,
+			EndVersion:   startVersion - 1,
+			File:         file,
+		},
+	}
+	w.writer = w.buf
+
+	switch compression {
+	case "", WALArchiveCompressionZlib:
+		w.compressor = zlib.NewWriter(w.buf)
+	case WALArchiveCompressionZstd:
+		w.compressor, err = zstd.NewWriter(w.buf)
+		if err != nil {
+			return nil, errors.Join(err, fp.Close())
+		}
+	}
+	if w.compressor != nil {
+		w.writer = w.compressor
+	}
+
+	return w, nil
+}
+
+// Write writes a version of change set.
+//
+// see memiavl/README.md for the format
+func (w *changeSetFileWriter) Write(version int64, pairs []*KVPair) error {
+	var size int
+	for _, pair := range pairs {
+		size += encodedSizeOfKVPair(pair)
+	}
+
+	buf := make([]byte, 16, 16+size)
+	binary.LittleEndian.PutUint64(buf, uint64(version))
+	binary.LittleEndian.PutUint64(buf[8:], uint64(size))
+	for _, pair := range pairs {
+		buf = appendKVPair(buf, pair)
+	}
+
+	if _, err := w.writer.Write(buf); err != nil {
+		return err
+	}
+	w.file.EndVersion = version
+	return nil
+}
+
+func (w *changeSetFileWriter) Close() error {
+	var errs []error
+	if w.compressor != nil {
+		errs = append(errs, w.compressor.Close())
+	}
+	errs = append(errs, w.buf.Flush(), w.fp.Sync(), w.fp.Close())
+	return errors.Join(errs...)
+}
+
+// encodedSizeOfKVPair returns the encoded length of a key-value pair in change set file
+//
+// layout: deletion(1) + keyLen(varint) + key + [ valueLen(varint) + value ]
+func encodedSizeOfKVPair(pair *KVPair) int {
+	var tmp [binary.MaxVarintLen64]byte
+	size := 1 + binary.PutUvarint(tmp[:], uint64(len(pair.Key))) + len(pair.Key)
+	if pair.Delete {
+		return size
+	}
+	return size + binary.PutUvarint(tmp[:], uint64(len(pair.Value))) + len(pair.Value)
+}
+
+// appendKVPair encodes the key-value pair in change set file format and append to buffer.
+func appendKVPair(buf []byte, pair *KVPair) []byte {
+	var deletion byte
+	if pair.Delete {
+		deletion = 1
+	}
+	buf = append(buf, deletion)
+	buf = binary.AppendUvarint(buf, uint64(len(pair.Key)))
+	buf = append(buf, pair.Key...)
+	if pair.Delete {
+		return buf
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(pair.Value)))
+	return append(buf, pair.Value...)
+}
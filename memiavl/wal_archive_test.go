@@ -0,0 +1,206 @@
+package memiavl
+
+import (
+	"bufio"
+	"compress/zlib"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWALArchive(t *testing.T) {
+	for _, compression := range []string{WALArchiveCompressionZlib, WALArchiveCompressionZstd, WALArchiveCompressionNone} {
+		t.Run(compression, func(t *testing.T) {
+			dir := t.TempDir()
+			archiveDir := t.TempDir()
+			db, err := Load(dir, Options{
+				CreateIfMissing:       true,
+				InitialStores:         []string{"test"},
+				AsyncCommitBuffer:     -1,
+				WALArchiveDir:         archiveDir,
+				WALArchiveCompression: compression,
+			})
+			require.NoError(t, err)
+			defer db.Close()
+
+			for i, changes := range ChangeSets {
+				if i == 2 {
+					require.NoError(t, db.ApplyUpgrades([]*TreeNameUpgrade{{Name: "new"}}))
+				}
+				cs := []*NamedChangeSet{{Name: "test", Changeset: changes}}
+				if i >= 2 {
+					cs = append(cs, &NamedChangeSet{Name: "new", Changeset: changes})
+				}
+				require.NoError(t, db.ApplyChangeSets(cs))
+				_, err := db.Commit()
+				require.NoError(t, err)
+			}
+			require.NoError(t, db.RewriteSnapshot())
+
+			require.NoError(t, db.archiveWAL(db.initialVersion, db.Version()))
+
+			manifest, err := ReadWALArchiveManifest(archiveDir)
+			require.NoError(t, err)
+			// the initial stores are recorded as upgrades in the first WAL entry
+			require.Equal(t, []WALArchiveUpgrade{
+				{Version: 1, TreeNameUpgrade: TreeNameUpgrade{Name: "test"}},
+				{Version: 3, TreeNameUpgrade: TreeNameUpgrade{Name: "new"}},
+			}, manifest.Upgrades)
+			require.Len(t, manifest.Files, 2)
+			require.Equal(t, WALArchiveFile{Store: "test", StartVersion: 1, EndVersion: int64(len(ChangeSets)), File: manifest.Files[0].File}, manifest.Files[0])
+			require.Equal(t, WALArchiveFile{Store: "new", StartVersion: 3, EndVersion: int64(len(ChangeSets)), File: manifest.Files[1].File}, manifest.Files[1])
+
+			for _, file := range manifest.Files {
+				versions, changeSets := readChangeSetFile(t, filepath.Join(archiveDir, file.File), compression)
+				for i, version := range versions {
+					require.Equal(t, file.StartVersion+int64(i), version)
+					require.Equal(t, len(ChangeSets[version-1].Pairs), len(changeSets[i].Pairs))
+					for j, pair := range changeSets[i].Pairs {
+						expected := ChangeSets[version-1].Pairs[j]
+						require.Equal(t, expected.Delete, pair.Delete)
+						require.Equal(t, expected.Key, pair.Key)
+						if !expected.Delete {
+							require.Equal(t, expected.Value, pair.Value)
+						}
+					}
+				}
+			}
+
+			// archive again is idempotent
+			require.NoError(t, db.archiveWAL(db.initialVersion, db.Version()))
+			manifest2, err := ReadWALArchiveManifest(archiveDir)
+			require.NoError(t, err)
+			require.Equal(t, manifest, manifest2)
+		})
+	}
+}
+
+func TestDumpWAL(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Load(dir, Options{
+		CreateIfMissing:   true,
+		InitialStores:     []string{"test"},
+		AsyncCommitBuffer: -1,
+	})
+	require.NoError(t, err)
+	for i, changes := range ChangeSets {
+		if i == 2 {
+			require.NoError(t, db.ApplyUpgrades([]*TreeNameUpgrade{{Name: "new"}}))
+		}
+		cs := []*NamedChangeSet{{Name: "test", Changeset: changes}}
+		if i >= 2 {
+			cs = append(cs, &NamedChangeSet{Name: "new", Changeset: changes})
+		}
+		require.NoError(t, db.ApplyChangeSets(cs))
+		_, err := db.Commit()
+		require.NoError(t, err)
+	}
+	require.NoError(t, db.Close())
+
+	outDir := t.TempDir()
+	manifest, err := DumpWAL(dir, 2, 4, outDir, WALArchiveCompressionZstd, 0)
+	require.NoError(t, err)
+	require.Equal(t, []WALArchiveUpgrade{
+		{Version: 3, TreeNameUpgrade: TreeNameUpgrade{Name: "new"}},
+	}, manifest.Upgrades)
+	require.Equal(t, []WALArchiveFile{
+		{Store: "test", StartVersion: 2, EndVersion: 4, File: filepath.Join("test", "block-2"+ZstdFileSuffix)},
+		{Store: "new", StartVersion: 3, EndVersion: 4, File: filepath.Join("new", "block-3"+ZstdFileSuffix)},
+	}, manifest.Files)
+
+	versions, changeSets := readChangeSetFile(t, filepath.Join(outDir, manifest.Files[1].File), WALArchiveCompressionZstd)
+	require.Equal(t, []int64{3, 4}, versions)
+	for i, version := range versions {
+		require.Equal(t, len(ChangeSets[version-1].Pairs), len(changeSets[i].Pairs))
+	}
+
+	// default to the whole wal
+	manifest, err = DumpWAL(dir, 0, 0, t.TempDir(), "", 0)
+	require.NoError(t, err)
+	require.Len(t, manifest.Files, 2)
+	require.Equal(t, int64(1), manifest.Files[0].StartVersion)
+	require.Equal(t, int64(len(ChangeSets)), manifest.Files[0].EndVersion)
+
+	// split by the chunk size
+	outDir = t.TempDir()
+	manifest, err = DumpWAL(dir, 2, 6, outDir, "", 2)
+	require.NoError(t, err)
+	require.Equal(t, []WALArchiveFile{
+		{Store: "test", StartVersion: 2, EndVersion: 3, File: filepath.Join("test", "block-2"+ZlibFileSuffix)},
+		{Store: "new", StartVersion: 3, EndVersion: 4, File: filepath.Join("new", "block-3"+ZlibFileSuffix)},
+		{Store: "test", StartVersion: 4, EndVersion: 5, File: filepath.Join("test", "block-4"+ZlibFileSuffix)},
+		{Store: "new", StartVersion: 5, EndVersion: 6, File: filepath.Join("new", "block-5"+ZlibFileSuffix)},
+		{Store: "test", StartVersion: 6, EndVersion: 6, File: filepath.Join("test", "block-6"+ZlibFileSuffix)},
+	}, manifest.Files)
+	versions, _ = readChangeSetFile(t, filepath.Join(outDir, manifest.Files[2].File), WALArchiveCompressionZlib)
+	require.Equal(t, []int64{4, 5}, versions)
+
+	_, err = DumpWAL(dir, 2, int64(len(ChangeSets))+1, t.TempDir(), "", 0)
+	require.Error(t, err)
+	_, err = DumpWAL(dir, 0, 0, t.TempDir(), "snappy", 0)
+	require.Error(t, err)
+}
+
+func readChangeSetFile(t *testing.T, fileName, compression string) ([]int64, []ChangeSet) {
+	fp, err := os.Open(fileName)
+	require.NoError(t, err)
+	defer fp.Close()
+
+	var reader io.Reader = fp
+	switch compression {
+	case WALArchiveCompressionZlib:
+		reader, err = zlib.NewReader(fp)
+		require.NoError(t, err)
+	case WALArchiveCompressionZstd:
+		dec, err := zstd.NewReader(fp)
+		require.NoError(t, err)
+		defer dec.Close()
+		reader = dec
+	}
+	buf := bufio.NewReader(reader)
+
+	var (
+		versions   []int64
+		changeSets []ChangeSet
+	)
+	for {
+		var header [16]byte
+		if _, err := io.ReadFull(buf, header[:]); err == io.EOF {
+			break
+		} else {
+			require.NoError(t, err)
+		}
+		size := int(binary.LittleEndian.Uint64(header[8:]))
+
+		var cs ChangeSet
+		for size > 0 {
+			deletion, err := buf.ReadByte()
+			require.NoError(t, err)
+			pair := &KVPair{Delete: deletion == 1}
+			keyLen, err := binary.ReadUvarint(buf)
+			require.NoError(t, err)
+			pair.Key = make([]byte, keyLen)
+			_, err = io.ReadFull(buf, pair.Key)
+			require.NoError(t, err)
+			if !pair.Delete {
+				valueLen, err := binary.ReadUvarint(buf)
+				require.NoError(t, err)
+				pair.Value = make([]byte, valueLen)
+				_, err = io.ReadFull(buf, pair.Value)
+				require.NoError(t, err)
+			}
+			size -= encodedSizeOfKVPair(pair)
+			cs.Pairs = append(cs.Pairs, pair)
+		}
+		require.Zero(t, size)
+
+		versions = append(versions, int64(binary.LittleEndian.Uint64(header[:8])))
+		changeSets = append(changeSets, cs)
+	}
+	return versions, changeSets
+}
@@ -18,6 +18,7 @@ cd ..
 # move proto files to the right places
 cp -r github.com/crypto-org-chain/cronos/v2/* ./
 cp -r github.com/crypto-org-chain/cronos/memiavl/* ./memiavl/
+cp -r github.com/crypto-org-chain/cronos/versiondb/* ./versiondb/
 rm -rf github.com
 
 # TODO uncomment go mod tidy after upgrading to ghcr.io/cosmos/proto-builder v0.12.0
@@ -21,12 +21,39 @@ type MemIAVLConfig struct {
 	SnapshotInterval uint32 `mapstructure:"snapshot-interval"`
 	// CacheSize defines the size of the cache for each memiavl store.
 	CacheSize int `mapstructure:"cache-size"`
+	// HistoricalCacheSize defines the max number of historical versions cached in memory to serve the queries,
+	// default to 8.
+	HistoricalCacheSize int `mapstructure:"historical-cache-size"`
+	// WALArchiveDir defines the directory to archive the WAL entries as change set files before they are truncated,
+	// the WAL entries are simply deleted if it's empty.
+	WALArchiveDir string `mapstructure:"wal-archive-dir"`
+	// WALArchiveCompression defines the compression algorithm of the archived change set files: zlib, zstd or none,
+	// default to zlib.
+	WALArchiveCompression string `mapstructure:"wal-archive-compression"`
+	// WALArchiveChunkSize defines the max number of blocks in each archived change set file, the same as the
+	// `--chunk-size` of the `changeset dump` command, 0 means no limit.
+	WALArchiveChunkSize int64 `mapstructure:"wal-archive-chunk-size"`
+	// CompressColdSnapshots defines if the kvs files of the old snapshots kept by `snapshot-keep-recent` are
+	// block-compressed to save disk space, the current snapshot is not affected.
+	CompressColdSnapshots bool `mapstructure:"compress-cold-snapshots"`
+	// ViewsKeepRecent defines how many recent versions are kept in memory as read-only views to serve the queries
+	// without contending with the state machine, 0 means only the latest version is served on demand.
+	ViewsKeepRecent uint32 `mapstructure:"views-keep-recent"`
+	// IncrementalSnapshot defines if the state-sync snapshots are exported as the change sets in the WAL since the
+	// last memiavl snapshot height only, which is much cheaper than the full export of every tree, the snapshots at
+	// the memiavl snapshot heights are still exported in full as the bases. An incremental snapshot can only be
+	// restored on top of the full snapshot at its base height restored locally, a fresh node can't state-sync from it
+	// alone, the state-sync snapshot-interval should divide the memiavl snapshot-interval.
+	IncrementalSnapshot bool `mapstructure:"incremental-snapshot"`
 }
 
 func DefaultMemIAVLConfig() MemIAVLConfig {
 	return MemIAVLConfig{
-		CacheSize:          DefaultCacheSize,
-		SnapshotInterval:   memiavl.DefaultSnapshotInterval,
-		SnapshotKeepRecent: 1,
+		CacheSize:             DefaultCacheSize,
+		SnapshotInterval:      memiavl.DefaultSnapshotInterval,
+		SnapshotKeepRecent:    1,
+		HistoricalCacheSize:   memiavl.DefaultMultiTreeCacheSize,
+		WALArchiveCompression: memiavl.WALArchiveCompressionZlib,
+		WALArchiveChunkSize:   memiavl.DefaultWALArchiveChunkSize,
 	}
 }
@@ -29,4 +29,35 @@ snapshot-interval = {{ .MemIAVL.SnapshotInterval }}
 
 # CacheSize defines the size of the cache for each memiavl store, default to 1000.
 cache-size = {{ .MemIAVL.CacheSize }}
+
+# HistoricalCacheSize defines the max number of historical versions cached in memory to serve the queries,
+# default to 8.
+historical-cache-size = {{ .MemIAVL.HistoricalCacheSize }}
+
+# WALArchiveDir defines the directory to archive the WAL entries as change set files before they are truncated,
+# the WAL entries are simply deleted if it's empty.
+wal-archive-dir = "{{ .MemIAVL.WALArchiveDir }}"
+
+# WALArchiveCompression defines the compression algorithm of the archived change set files: zlib, zstd or none,
+# default to zlib.
+wal-archive-compression = "{{ .MemIAVL.WALArchiveCompression }}"
+
+# WALArchiveChunkSize defines the max number of blocks in each archived change set file, the same as the
+# --chunk-size of the "changeset dump" command, 0 means no limit.
+wal-archive-chunk-size = {{ .MemIAVL.WALArchiveChunkSize }}
+
+# CompressColdSnapshots defines if the kvs files of the old snapshots kept by snapshot-keep-recent are
+# block-compressed to save disk space, the current snapshot is not affected.
+compress-cold-snapshots = {{ .MemIAVL.CompressColdSnapshots }}
+
+# ViewsKeepRecent defines how many recent versions are kept in memory as read-only views to serve the queries
+# without contending with the state machine, 0 means only the latest version is served on demand.
+views-keep-recent = {{ .MemIAVL.ViewsKeepRecent }}
+
+# IncrementalSnapshot defines if the state-sync snapshots are exported as the change sets in the WAL since the
+# last memiavl snapshot height only, which is much cheaper than the full export of every tree, the snapshots at
+# the memiavl snapshot heights are still exported in full as the bases. An incremental snapshot can only be
+# restored on top of the full snapshot at its base height restored locally, a fresh node can't state-sync from it
+# alone, the state-sync snapshot-interval should divide the memiavl snapshot-interval.
+incremental-snapshot = {{ .MemIAVL.IncrementalSnapshot }}
 `
@@ -0,0 +1,81 @@
+package memiavlstore
+
+import (
+	"cosmossdk.io/errors"
+	"cosmossdk.io/store/types"
+	"github.com/cometbft/cometbft/crypto/merkle"
+	cmtprotocrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
+	ics23 "github.com/cosmos/ics23/go"
+	"github.com/crypto-org-chain/cronos/memiavl"
+)
+
+// ProofOpMemIAVLRange is the proof op type of the range proofs returned by subspace queries.
+const ProofOpMemIAVLRange = "memiavl:range"
+
+var _ merkle.ProofOperator = RangeCommitmentOp{}
+
+// RangeCommitmentOp implements merkle.ProofOperator, it proves the complete set of key-value pairs with a prefix,
+// by wrapping a compressed batch of ics23 existence proofs, see `memiavl.VerifyRangeProof`.
+//
+// The light clients need to register `RangeCommitmentOpDecoder` to the proof runtime to verify it.
+type RangeCommitmentOp struct {
+	Prefix []byte
+	Proof  *ics23.CommitmentProof
+}
+
+func NewRangeCommitmentOp(prefix []byte, proof *ics23.CommitmentProof) RangeCommitmentOp {
+	return RangeCommitmentOp{
+		Prefix: prefix,
+		Proof:  proof,
+	}
+}
+
+// RangeCommitmentOpDecoder decodes a merkle.ProofOp of type `ProofOpMemIAVLRange` into a RangeCommitmentOp.
+func RangeCommitmentOpDecoder(pop cmtprotocrypto.ProofOp) (merkle.ProofOperator, error) {
+	if pop.Type != ProofOpMemIAVLRange {
+		return nil, errors.Wrapf(types.ErrInvalidProof, "unexpected ProofOp.Type; got %s, want %s", pop.Type, ProofOpMemIAVLRange)
+	}
+
+	proof := &ics23.CommitmentProof{}
+	if err := proof.Unmarshal(pop.Data); err != nil {
+		return nil, err
+	}
+	return NewRangeCommitmentOp(pop.Key, proof), nil
+}
+
+func (op RangeCommitmentOp) GetKey() []byte {
+	return op.Prefix
+}
+
+// Run takes the marshaled `memiavl.Pairs` returned by the subspace query as the only argument, verifies it's the
+// complete set of pairs with the prefix, and returns the root of the proof.
+func (op RangeCommitmentOp) Run(args [][]byte) ([][]byte, error) {
+	if len(args) != 1 {
+		return nil, errors.Wrapf(types.ErrInvalidProof, "args must be length 1, got: %d", len(args))
+	}
+
+	var pairs memiavl.Pairs
+	if err := pairs.Unmarshal(args[0]); err != nil {
+		return nil, errors.Wrapf(types.ErrInvalidProof, "could not unmarshal KV pairs: %v", err)
+	}
+
+	root, err := memiavl.VerifyRangeProof(op.Proof, op.Prefix, types.PrefixEndBytes(op.Prefix), pairs.Pairs)
+	if err != nil {
+		return nil, errors.Wrapf(types.ErrInvalidProof, "proof did not verify the pairs with prefix %X: %v", op.Prefix, err)
+	}
+	return [][]byte{root}, nil
+}
+
+// ProofOp implements ProofOperator interface and converts a RangeCommitmentOp
+// into a merkle.ProofOp format that can later be decoded by RangeCommitmentOpDecoder.
+func (op RangeCommitmentOp) ProofOp() cmtprotocrypto.ProofOp {
+	bz, err := op.Proof.Marshal()
+	if err != nil {
+		panic(err.Error())
+	}
+	return cmtprotocrypto.ProofOp{
+		Type: ProofOpMemIAVLRange,
+		Key:  op.Prefix,
+		Data: bz,
+	}
+}
@@ -160,11 +160,23 @@ func (st *Store) Query(req *types.RequestQuery) (res *types.ResponseQuery, err e
 		subspace := req.Data
 		res.Key = subspace
 
-		iterator := types.KVStorePrefixIterator(st, subspace)
-		for ; iterator.Valid(); iterator.Next() {
-			pairs.Pairs = append(pairs.Pairs, memiavl.Pair{Key: iterator.Key(), Value: iterator.Value()})
+		if req.Prove {
+			// prove the completeness of the pairs with a single range proof
+			var proof *ics23.CommitmentProof
+			var err error
+			pairs.Pairs, proof, err = st.tree.GetPrefixProof(subspace)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to create range proof")
+			}
+			op := NewRangeCommitmentOp(subspace, proof)
+			res.ProofOps = &cmtprotocrypto.ProofOps{Ops: []cmtprotocrypto.ProofOp{op.ProofOp()}}
+		} else {
+			iterator := types.KVStorePrefixIterator(st, subspace)
+			for ; iterator.Valid(); iterator.Next() {
+				pairs.Pairs = append(pairs.Pairs, memiavl.Pair{Key: iterator.Key(), Value: iterator.Value()})
+			}
+			iterator.Close()
 		}
-		iterator.Close()
 
 		bz, err := pairs.Marshal()
 		if err != nil {
@@ -1,6 +1,8 @@
 package rootmulti
 
 import (
+	"encoding/binary"
+	stderrors "errors"
 	"fmt"
 	"io"
 	"math"
@@ -34,12 +36,16 @@ func (rs *Store) Restore(
 
 func (rs *Store) restore(
 	height uint64, format uint32, protoReader protoio.Reader,
-) (types.SnapshotItem, error) {
-	importer, err := memiavl.NewMultiTreeImporter(rs.dir, height)
-	if err != nil {
-		return types.SnapshotItem{}, err
+) (_ types.SnapshotItem, returnErr error) {
+	restorer := &snapshotRestorer{
+		dir:        rs.dir,
+		opts:       rs.opts,
+		height:     height,
+		baseHeight: height,
 	}
-	defer importer.Close()
+	defer func() {
+		returnErr = stderrors.Join(returnErr, restorer.Close())
+	}()
 
 	var snapshotItem types.SnapshotItem
 loop:
@@ -53,7 +59,26 @@ loop:
 		}
 
 		switch item := snapshotItem.Item.(type) {
+		case *types.SnapshotItem_Extension:
+			if item.Extension.Name != IncrementalSnapshotExtension || restorer.started() {
+				// the extension snapshotters
+				break loop
+			}
+			if err := restorer.readHeader(item.Extension.Format, protoReader); err != nil {
+				return types.SnapshotItem{}, err
+			}
+		case *types.SnapshotItem_ExtensionPayload:
+			if !restorer.incremental {
+				break loop
+			}
+			if err := restorer.addDelta(item.ExtensionPayload.Payload); err != nil {
+				return types.SnapshotItem{}, err
+			}
 		case *types.SnapshotItem_Store:
+			importer, err := restorer.baseImporter()
+			if err != nil {
+				return types.SnapshotItem{}, err
+			}
 			if err := importer.AddTree(item.Store.Name); err != nil {
 				return types.SnapshotItem{}, err
 			}
@@ -76,6 +101,10 @@ loop:
 			if node.Height == 0 && node.Value == nil {
 				node.Value = []byte{}
 			}
+			importer, err := restorer.baseImporter()
+			if err != nil {
+				return types.SnapshotItem{}, err
+			}
 			importer.AddNode(node)
 		default:
 			// unknown element, could be an extension
@@ -83,9 +112,122 @@ loop:
 		}
 	}
 
-	if err := importer.Finalize(); err != nil {
+	if err := restorer.Finalize(); err != nil {
 		return types.SnapshotItem{}, err
 	}
 
 	return snapshotItem, nil
 }
+
+// snapshotRestorer imports the full snapshot, or replays the deltas of an incremental snapshot on top of the local
+// db, which must be restored from the full snapshot at the base height already.
+type snapshotRestorer struct {
+	dir    string
+	opts   memiavl.Options
+	height uint64
+	// the height of the base snapshot of an incremental snapshot, the same as `height` if it's a full snapshot
+	baseHeight  uint64
+	incremental bool
+
+	importer *memiavl.MultiTreeImporter
+	deltas   *memiavl.DeltaImporter
+}
+
+func (r *snapshotRestorer) started() bool {
+	return r.incremental || r.importer != nil || r.deltas != nil
+}
+
+// readHeader reads the base height following the incremental snapshot extension item.
+func (r *snapshotRestorer) readHeader(format uint32, protoReader protoio.Reader) error {
+	if format != IncrementalSnapshotFormat {
+		return fmt.Errorf("unknown incremental snapshot format: %d", format)
+	}
+
+	var item types.SnapshotItem
+	if err := protoReader.ReadMsg(&item); err != nil {
+		return errors.Wrap(err, "invalid protobuf message")
+	}
+	payload := item.GetExtensionPayload()
+	if payload == nil || len(payload.Payload) != 8 {
+		return stderrors.New("invalid incremental snapshot header")
+	}
+
+	r.baseHeight = binary.BigEndian.Uint64(payload.Payload)
+	if r.baseHeight >= r.height {
+		return fmt.Errorf("base height %d is not lower than the snapshot height %d", r.baseHeight, r.height)
+	}
+	r.incremental = true
+
+	// the snapshot only contains the deltas, fail before reading them if the base is not restored locally
+	opts := r.opts
+	// don't trigger state-sync snapshot creation while restoring
+	opts.TriggerStateSyncExport = nil
+	deltas, err := memiavl.NewDeltaImporter(r.dir, opts)
+	if err != nil {
+		return fmt.Errorf("the full snapshot at the base height %d must be restored first: %w", r.baseHeight, err)
+	}
+	r.deltas = deltas
+	if version := deltas.Version(); version != int64(r.baseHeight) {
+		return fmt.Errorf("the full snapshot at the base height %d must be restored first, local version: %d", r.baseHeight, version)
+	}
+	return nil
+}
+
+func (r *snapshotRestorer) baseImporter() (*memiavl.MultiTreeImporter, error) {
+	if r.incremental {
+		return nil, stderrors.New("unexpected store items in incremental snapshot")
+	}
+	if r.importer == nil {
+		importer, err := memiavl.NewMultiTreeImporter(r.dir, r.baseHeight, r.opts.SnapshotWriterLimit)
+		if err != nil {
+			return nil, err
+		}
+		r.importer = importer
+	}
+	return r.importer, nil
+}
+
+func (r *snapshotRestorer) finalizeBase() error {
+	importer, err := r.baseImporter()
+	if err != nil {
+		return err
+	}
+	r.importer = nil
+	return stderrors.Join(importer.Finalize(), importer.Close())
+}
+
+func (r *snapshotRestorer) addDelta(payload []byte) error {
+	version, entry, err := memiavl.DecodeDelta(payload)
+	if err != nil {
+		return err
+	}
+	return r.deltas.Add(version, entry)
+}
+
+// Finalize finishes the restoration, checks the restored db reaches the snapshot height.
+func (r *snapshotRestorer) Finalize() error {
+	if !r.incremental {
+		return r.finalizeBase()
+	}
+	version := r.deltas.Version()
+	err := r.deltas.Close()
+	r.deltas = nil
+	if err != nil {
+		return err
+	}
+	if version != int64(r.height) {
+		return fmt.Errorf("incremental snapshot ends at height %d, expected: %d", version, r.height)
+	}
+	return nil
+}
+
+func (r *snapshotRestorer) Close() error {
+	var errs []error
+	if r.importer != nil {
+		errs = append(errs, r.importer.Close())
+	}
+	if r.deltas != nil {
+		errs = append(errs, r.deltas.Close())
+	}
+	return stderrors.Join(errs...)
+}
@@ -1,6 +1,7 @@
 package rootmulti
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math"
@@ -11,13 +12,30 @@ import (
 	"github.com/crypto-org-chain/cronos/memiavl"
 )
 
+const (
+	// IncrementalSnapshotExtension is the name of the extension item which starts an incremental snapshot, it's
+	// followed by a payload of the base version, then a payload for each delta after the base version, the state at
+	// the base version is not included, it must be restored from the full snapshot at the base height first.
+	// It's consumed by the multistore itself rather than a registered extension snapshotter.
+	IncrementalSnapshotExtension = "memiavl_incremental"
+	IncrementalSnapshotFormat    = 1
+)
+
 // Implements interface Snapshotter
-func (rs *Store) Snapshot(height uint64, protoWriter protoio.Writer) (returnErr error) {
+func (rs *Store) Snapshot(height uint64, protoWriter protoio.Writer) error {
 	if height > math.MaxUint32 {
 		return fmt.Errorf("height overflows uint32: %d", height)
 	}
 	version := uint32(height)
 
+	if rs.incrementalSnapshot {
+		return rs.snapshotIncremental(version, protoWriter)
+	}
+
+	return rs.snapshotFull(version, protoWriter)
+}
+
+func (rs *Store) snapshotFull(version uint32, protoWriter protoio.Writer) (returnErr error) {
 	exporter, err := memiavl.NewMultiTreeExporter(rs.dir, version, rs.supportExportNonSnapshotVersion)
 	if err != nil {
 		return err
@@ -27,6 +45,73 @@ func (rs *Store) Snapshot(height uint64, protoWriter protoio.Writer) (returnErr
 		returnErr = errors.Join(returnErr, exporter.Close())
 	}()
 
+	return writeExportItems(exporter, protoWriter)
+}
+
+// snapshotIncremental exports the change sets in the WAL since the last memiavl snapshot at or below the height,
+// the snapshot at a memiavl snapshot height is exported in full, which is the base of the following incremental
+// snapshots.
+func (rs *Store) snapshotIncremental(version uint32, protoWriter protoio.Writer) (returnErr error) {
+	if rs.db == nil {
+		return errors.New("memiavl db is not loaded")
+	}
+	exporter, err := rs.db.NewIncrementalExporter(version)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		returnErr = errors.Join(returnErr, exporter.Close())
+	}()
+
+	if exporter.BaseVersion() == int64(version) {
+		// the base snapshot is pinned by the exporter while exporting
+		return rs.snapshotFull(version, protoWriter)
+	}
+
+	if err := protoWriter.WriteMsg(&types.SnapshotItem{
+		Item: &types.SnapshotItem_Extension{
+			Extension: &types.SnapshotExtensionMeta{
+				Name:   IncrementalSnapshotExtension,
+				Format: IncrementalSnapshotFormat,
+			},
+		},
+	}); err != nil {
+		return err
+	}
+	if err := writePayload(protoWriter, binary.BigEndian.AppendUint64(nil, uint64(exporter.BaseVersion()))); err != nil {
+		return err
+	}
+
+	for {
+		version, entry, err := exporter.NextDelta()
+		if err != nil {
+			if err == memiavl.ErrorExportDone {
+				return nil
+			}
+			return err
+		}
+		bz, err := memiavl.EncodeDelta(version, entry)
+		if err != nil {
+			return err
+		}
+		if err := writePayload(protoWriter, bz); err != nil {
+			return err
+		}
+	}
+}
+
+func writePayload(protoWriter protoio.Writer, payload []byte) error {
+	return protoWriter.WriteMsg(&types.SnapshotItem{
+		Item: &types.SnapshotItem_ExtensionPayload{
+			ExtensionPayload: &types.SnapshotExtensionPayload{
+				Payload: payload,
+			},
+		},
+	})
+}
+
+func writeExportItems(exporter interface{ Next() (interface{}, error) }, protoWriter protoio.Writer) error {
 	for {
 		item, err := exporter.Next()
 		if err != nil {
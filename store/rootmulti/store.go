@@ -1,6 +1,7 @@
 package rootmulti
 
 import (
+	stderrors "errors"
 	"fmt"
 	"io"
 	"math"
@@ -31,6 +32,13 @@ var (
 	_ types.Queryable        = (*Store)(nil)
 )
 
+// HistoricalTrees provides the read-only trees at historical versions,
+// the caller must call the returned release function after finishing using the tree.
+type HistoricalTrees interface {
+	Get(version int64) (*memiavl.MultiTree, func(), error)
+	Close() error
+}
+
 type Store struct {
 	dir    string
 	db     *memiavl.DB
@@ -46,10 +54,18 @@ type Store struct {
 
 	opts memiavl.Options
 
+	// cache the read-only trees to serve the queries at historical versions
+	historicalTrees     *memiavl.MultiTreeCache
+	historicalCacheSize int
+	// optional fallback to serve the queries at the versions pruned from memiavl db
+	archivedTrees HistoricalTrees
+
 	// sdk46Compact defines if the root hash is compatible with cosmos-sdk 0.46 and before.
 	sdk46Compact bool
 	// it's more efficient to export snapshot versions, we can filter out the non-snapshot versions
 	supportExportNonSnapshotVersion bool
+	// export the state-sync snapshots as a base memiavl snapshot plus the change sets in the WAL
+	incrementalSnapshot bool
 }
 
 func NewStore(dir string, logger log.Logger, sdk46Compact bool, supportExportNonSnapshotVersion bool) *Store {
@@ -136,7 +152,14 @@ func (rs *Store) Commit() types.CommitID {
 }
 
 func (rs *Store) Close() error {
-	return rs.db.Close()
+	var errs []error
+	if rs.historicalTrees != nil {
+		errs = append(errs, rs.historicalTrees.Close())
+	}
+	if rs.archivedTrees != nil {
+		errs = append(errs, rs.archivedTrees.Close())
+	}
+	return stderrors.Join(append(errs, rs.db.Close())...)
 }
 
 // Implements interface Committer
@@ -200,6 +223,15 @@ func (rs *Store) CacheMultiStore() types.CacheMultiStore {
 // Implements interface MultiStore
 // used to createQueryContext, abci_query or grpc query service.
 func (rs *Store) CacheMultiStoreWithVersion(version int64) (types.CacheMultiStore, error) {
+	// serve the recent versions from the in-memory views, which don't contend with the state machine.
+	view, err := rs.db.ViewAt(version)
+	if err == nil {
+		return rs.cacheMultiStoreWithView(view), nil
+	}
+	if !stderrors.Is(err, memiavl.ErrViewNotAvailable) {
+		return nil, err
+	}
+
 	if version == 0 || (rs.lastCommitInfo != nil && version == rs.lastCommitInfo.Version) {
 		return rs.CacheMultiStore(), nil
 	}
@@ -211,21 +243,28 @@ func (rs *Store) CacheMultiStoreWithVersion(version int64) (types.CacheMultiStor
 		return nil, err
 	}
 
-	stores := make(map[types.StoreKey]types.CacheWrapper)
+	return rs.cacheMultiStoreWithTrees(db.Trees(), db), nil
+}
 
-	// add the transient/mem stores registered in current app.
+// cacheMultiStoreWithTrees builds a cache multi store with the read-only trees, the closer is called when the
+// cache multi store is closed.
+func (rs *Store) cacheMultiStoreWithTrees(trees []memiavl.NamedTree, closer io.Closer) types.CacheMultiStore {
+	stores := make(map[types.StoreKey]types.CacheWrapper, len(trees))
+	for _, tree := range trees {
+		stores[rs.keysByName[tree.Name]] = memiavlstore.New(tree.Tree, rs.logger)
+	}
+	return rs.cacheMultiStoreWithStores(stores, closer)
+}
+
+// cacheMultiStoreWithStores builds a cache multi store with the iavl stores at the target version, plus the
+// transient/mem stores registered in current app.
+func (rs *Store) cacheMultiStoreWithStores(stores map[types.StoreKey]types.CacheWrapper, closer io.Closer) types.CacheMultiStore {
 	for k, store := range rs.stores {
 		if store.GetStoreType() != types.StoreTypeIAVL {
 			stores[k] = store
 		}
 	}
-
-	// add all the iavl stores at the target version.
-	for _, tree := range db.Trees() {
-		stores[rs.keysByName[tree.Name]] = memiavlstore.New(tree.Tree, rs.logger)
-	}
-
-	return cachemulti.NewStore(stores, nil, nil, nil), nil
+	return cachemulti.NewStore(stores, nil, nil, closer)
 }
 
 // Implements interface MultiStore
@@ -376,8 +415,15 @@ func (rs *Store) LoadVersionAndUpgrade(version int64, upgrades *types.StoreUpgra
 		}
 	}
 
+	if rs.historicalTrees != nil {
+		if err := rs.historicalTrees.Close(); err != nil {
+			return err
+		}
+	}
+
 	rs.db = db
 	rs.stores = newStores
+	rs.historicalTrees = memiavl.NewMultiTreeCache(rs.dir, false, rs.historicalCacheSize)
 	// to keep the root hash compatible with cosmos-sdk 0.46
 	if db.Version() != 0 {
 		rs.lastCommitInfo = convertCommitInfo(db.LastCommitInfo())
@@ -460,6 +506,24 @@ func (rs *Store) SetMemIAVLOptions(opts memiavl.Options) {
 	rs.opts = opts
 }
 
+// SetHistoricalCacheSize sets the max number of historical versions cached for queries,
+// it must be called before the store is loaded.
+func (rs *Store) SetHistoricalCacheSize(size int) {
+	rs.historicalCacheSize = size
+}
+
+// SetIncrementalSnapshot sets if the state-sync snapshots are exported incrementally, only the change sets in the
+// WAL since the last memiavl snapshot height are exported, except the ones at the memiavl snapshot heights.
+func (rs *Store) SetIncrementalSnapshot(incremental bool) {
+	rs.incrementalSnapshot = incremental
+}
+
+// SetArchivedTrees sets the fallback provider of the trees at the versions pruned from memiavl db,
+// so the queries at these versions can still be proved.
+func (rs *Store) SetArchivedTrees(trees HistoricalTrees) {
+	rs.archivedTrees = trees
+}
+
 // RollbackToVersion delete the versions after `target` and update the latest version.
 // it should only be called in standalone cli commands.
 func (rs *Store) RollbackToVersion(target int64) error {
@@ -543,26 +607,35 @@ func (rs *Store) Query(req *types.RequestQuery) (*types.ResponseQuery, error) {
 		version = rs.db.Version()
 	}
 
+	storeName, subpath, err := parsePath(req.Path)
+	if err != nil {
+		return nil, err
+	}
+
 	// If the request's height is the latest height we've committed, then utilize
 	// the store's lastCommitInfo as this commit info may not be flushed to disk.
-	// Otherwise, we query for the commit info from disk.
-	db := rs.db
-	if version != rs.lastCommitInfo.Version {
-		var err error
-		db, err = memiavl.Load(rs.dir, memiavl.Options{TargetVersion: uint32(version), ReadOnly: true})
+	// Otherwise, we query the read-only tree at the historical version.
+	var (
+		tree       *memiavl.Tree
+		commitInfo *memiavl.CommitInfo
+	)
+	if version == rs.lastCommitInfo.Version {
+		tree = rs.db.TreeByName(storeName)
+		commitInfo = rs.db.LastCommitInfo()
+	} else {
+		mtree, release, err := rs.historicalMultiTree(version)
 		if err != nil {
 			return nil, err
 		}
-		defer db.Close()
+		defer release()
+		tree = mtree.TreeByName(storeName)
+		commitInfo = mtree.LastCommitInfo()
 	}
-
-	path := req.Path
-	storeName, subpath, err := parsePath(path)
-	if err != nil {
-		return nil, err
+	if tree == nil {
+		return nil, errors.Wrapf(sdkerrors.ErrUnknownRequest, "no such store: %s", storeName)
 	}
 
-	store := types.Queryable(memiavlstore.New(db.TreeByName(storeName), rs.logger))
+	store := types.Queryable(memiavlstore.New(tree, rs.logger))
 
 	// trim the path and make the query
 	req.Path = subpath
@@ -571,7 +644,8 @@ func (rs *Store) Query(req *types.RequestQuery) (*types.ResponseQuery, error) {
 		return nil, err
 	}
 
-	if !req.Prove || !rootmulti.RequireProof(subpath) {
+	// the subspace queries are proven by a range proof of the store
+	if !req.Prove || !(rootmulti.RequireProof(subpath) || subpath == "/subspace") {
 		return res, nil
 	}
 
@@ -579,17 +653,32 @@ func (rs *Store) Query(req *types.RequestQuery) (*types.ResponseQuery, error) {
 		return nil, errors.Wrap(sdkerrors.ErrInvalidRequest, "proof is unexpectedly empty; ensure height has not been pruned")
 	}
 
-	commitInfo := convertCommitInfo(db.LastCommitInfo())
+	info := convertCommitInfo(commitInfo)
 	if rs.sdk46Compact {
-		commitInfo = amendCommitInfo(commitInfo, rs.storesParams)
+		info = amendCommitInfo(info, rs.storesParams)
 	}
 
 	// Restore origin path and append proof op.
-	res.ProofOps.Ops = append(res.ProofOps.Ops, commitInfo.ProofOp(storeName))
+	res.ProofOps.Ops = append(res.ProofOps.Ops, info.ProofOp(storeName))
 
 	return res, nil
 }
 
+// historicalMultiTree returns the read-only tree at a historical version,
+// fallback to the archived trees if the version is pruned from memiavl db.
+func (rs *Store) historicalMultiTree(version int64) (*memiavl.MultiTree, func(), error) {
+	mtree, release, err := rs.historicalTrees.Get(version)
+	if err == nil || rs.archivedTrees == nil {
+		return mtree, release, err
+	}
+
+	mtree, release, archiveErr := rs.archivedTrees.Get(version)
+	if archiveErr != nil {
+		return nil, nil, stderrors.Join(err, archiveErr)
+	}
+	return mtree, release, nil
+}
+
 // parsePath expects a format like /<storeName>[/<subpath>]
 // Must start with /, subpath may be empty
 // Returns error if it doesn't start with /
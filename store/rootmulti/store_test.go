@@ -1,14 +1,262 @@
 package rootmulti
 
 import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
 	"cosmossdk.io/log"
+	snapshottypes "cosmossdk.io/store/snapshots/types"
 	"cosmossdk.io/store/types"
+	"github.com/cometbft/cometbft/crypto/merkle"
+	protoio "github.com/cosmos/gogoproto/io"
 	"github.com/stretchr/testify/require"
+
+	"github.com/crypto-org-chain/cronos/memiavl"
+	"github.com/crypto-org-chain/cronos/store/cachemulti"
+	"github.com/crypto-org-chain/cronos/store/memiavlstore"
 )
 
 func TestLastCommitID(t *testing.T) {
 	store := NewStore(t.TempDir(), log.NewNopLogger(), false, false)
 	require.Equal(t, types.CommitID{}, store.LastCommitID())
 }
+
+func TestHistoricalQuery(t *testing.T) {
+	key := types.NewKVStoreKey("test")
+	store := NewStore(t.TempDir(), log.NewNopLogger(), false, false)
+	store.MountStoreWithDB(key, types.StoreTypeIAVL, nil)
+	require.NoError(t, store.LoadLatestVersion())
+	defer store.Close()
+
+	for i := 1; i <= 5; i++ {
+		store.GetKVStore(key).Set([]byte("hello"), []byte{byte(i)})
+		store.Commit()
+	}
+	require.NoError(t, store.db.WaitAsyncCommit())
+
+	for i := 1; i <= 5; i++ {
+		res, err := store.Query(&types.RequestQuery{
+			Path:   "/test/key",
+			Data:   []byte("hello"),
+			Height: int64(i),
+			Prove:  true,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []byte{byte(i)}, res.Value)
+		require.NotEmpty(t, res.ProofOps.Ops)
+	}
+}
+
+func TestSubspaceQueryProof(t *testing.T) {
+	key := types.NewKVStoreKey("test")
+	store := NewStore(t.TempDir(), log.NewNopLogger(), false, false)
+	store.MountStoreWithDB(key, types.StoreTypeIAVL, nil)
+	require.NoError(t, store.LoadLatestVersion())
+	defer store.Close()
+
+	kvstore := store.GetKVStore(key)
+	kvstore.Set([]byte("a"), []byte("a"))
+	kvstore.Set([]byte("prefix/1"), []byte("1"))
+	kvstore.Set([]byte("prefix/2"), []byte("2"))
+	kvstore.Set([]byte("z"), []byte("z"))
+	commitID := store.Commit()
+
+	prt := merkle.NewProofRuntime()
+	prt.RegisterOpDecoder(types.ProofOpSimpleMerkleCommitment, types.CommitmentOpDecoder)
+	prt.RegisterOpDecoder(memiavlstore.ProofOpMemIAVLRange, memiavlstore.RangeCommitmentOpDecoder)
+
+	for _, prefix := range []string{"prefix/", "b", "a"} {
+		res, err := store.Query(&types.RequestQuery{
+			Path:  "/test/subspace",
+			Data:  []byte(prefix),
+			Prove: true,
+		})
+		require.NoError(t, err)
+		require.Len(t, res.ProofOps.Ops, 2)
+
+		keypath := merkle.KeyPath{}.
+			AppendKey([]byte("test"), merkle.KeyEncodingURL).
+			AppendKey([]byte(prefix), merkle.KeyEncodingURL)
+		require.NoError(t, prt.VerifyValue(res.ProofOps, commitID.Hash, keypath.String(), res.Value))
+
+		// omitting a pair fails the verification
+		var pairs memiavl.Pairs
+		require.NoError(t, pairs.Unmarshal(res.Value))
+		if len(pairs.Pairs) > 0 {
+			pairs.Pairs = pairs.Pairs[1:]
+			bz, err := pairs.Marshal()
+			require.NoError(t, err)
+			require.Error(t, prt.VerifyValue(res.ProofOps, commitID.Hash, keypath.String(), bz))
+		}
+	}
+}
+
+func TestCacheMultiStoreWithVersion(t *testing.T) {
+	key := types.NewKVStoreKey("test")
+	store := NewStore(t.TempDir(), log.NewNopLogger(), false, false)
+	store.SetMemIAVLOptions(memiavl.Options{AsyncCommitBuffer: -1, ViewsKeepRecent: 2})
+	store.MountStoreWithDB(key, types.StoreTypeIAVL, nil)
+	require.NoError(t, store.LoadLatestVersion())
+	defer store.Close()
+
+	for i := 1; i <= 5; i++ {
+		store.GetKVStore(key).Set([]byte("hello"), []byte{byte(i)})
+		store.Commit()
+	}
+
+	// pending writes are not visible to the queries
+	store.GetKVStore(key).Set([]byte("hello"), []byte{6})
+	require.NoError(t, store.flush())
+
+	for _, version := range []int64{0, 5, 4, 3} {
+		cms, err := store.CacheMultiStoreWithVersion(version)
+		require.NoError(t, err)
+		expected := version
+		if version == 0 {
+			expected = 5
+		}
+		require.Equal(t, []byte{byte(expected)}, cms.GetKVStore(key).Get([]byte("hello")))
+		require.NoError(t, cms.(io.Closer).Close())
+	}
+
+	// the iterator keeps the view alive after the cache multi store is closed, across the commits
+	cms, err := store.CacheMultiStoreWithVersion(0)
+	require.NoError(t, err)
+	ref := cms.(cachemulti.Store).Closer.(*viewUser).ref
+	it := cms.GetKVStore(key).Iterator(nil, nil)
+	require.NoError(t, cms.(io.Closer).Close())
+	// closing twice is a no-op
+	require.NoError(t, cms.(io.Closer).Close())
+	store.Commit()
+	store.Commit()
+	require.Equal(t, int32(1), ref.refs.Load())
+	require.True(t, it.Valid())
+	require.Equal(t, []byte{5}, it.Value())
+	require.NoError(t, it.Close())
+	require.Zero(t, ref.refs.Load())
+
+	// iterating the closed cache multi store panics rather than reading the released view
+	require.Panics(t, func() { cms.GetKVStore(key).Iterator(nil, nil) })
+
+	// the view not closed by the caller is reported after it's garbage collected, but not released
+	logs := make(chan string, 16)
+	ref = func() *viewRef {
+		cms, err := store.CacheMultiStoreWithVersion(0)
+		require.NoError(t, err)
+		require.Equal(t, []byte{6}, cms.GetKVStore(key).Get([]byte("hello")))
+		ref := cms.(cachemulti.Store).Closer.(*viewUser).ref
+		ref.logger = log.NewLogger(logWriter(logs))
+		return ref
+	}()
+	require.Eventually(t, func() bool {
+		runtime.GC()
+		return len(logs) > 0
+	}, time.Second, 10*time.Millisecond)
+	require.Contains(t, <-logs, "memiavl view leaked")
+	require.Equal(t, int32(1), ref.refs.Load())
+}
+
+// logWriter sends the log lines to the channel, drops them if it's full.
+type logWriter chan string
+
+func (w logWriter) Write(p []byte) (int, error) {
+	select {
+	case w <- string(p):
+	default:
+	}
+	return len(p), nil
+}
+
+func TestIncrementalSnapshot(t *testing.T) {
+	key := types.NewKVStoreKey("test")
+	dir := t.TempDir()
+	store := NewStore(dir, log.NewNopLogger(), false, false)
+	store.SetMemIAVLOptions(memiavl.Options{AsyncCommitBuffer: -1, SnapshotInterval: 3})
+	store.SetIncrementalSnapshot(true)
+	store.MountStoreWithDB(key, types.StoreTypeIAVL, nil)
+	require.NoError(t, store.LoadLatestVersion())
+	defer store.Close()
+
+	for i := 1; i <= 5; i++ {
+		store.GetKVStore(key).Set([]byte("hello"), []byte{byte(i)})
+		store.GetKVStore(key).Set([]byte{byte(i)}, []byte("world"))
+		store.Commit()
+		if i == 3 {
+			// wait for the base snapshot rewritten in background
+			require.Eventually(t, func() bool {
+				current, err := os.Readlink(filepath.Join(dir, "current"))
+				return err == nil && current == "snapshot-00000000000000000003"
+			}, 10*time.Second, 10*time.Millisecond)
+		}
+	}
+
+	// the items of the extension snapshotters follow
+	extension := snapshottypes.SnapshotItem{
+		Item: &snapshottypes.SnapshotItem_Extension{
+			Extension: &snapshottypes.SnapshotExtensionMeta{Name: "test", Format: 1},
+		},
+	}
+	snapshot := func(height uint64) []snapshottypes.SnapshotItem {
+		var buf bytes.Buffer
+		writer := protoio.NewDelimitedWriter(&buf)
+		require.NoError(t, store.Snapshot(height, writer))
+		require.NoError(t, writer.WriteMsg(&extension))
+
+		var items []snapshottypes.SnapshotItem
+		reader := protoio.NewDelimitedReader(&buf, 1<<20)
+		for {
+			var item snapshottypes.SnapshotItem
+			if err := reader.ReadMsg(&item); err == io.EOF {
+				return items
+			} else {
+				require.NoError(t, err)
+			}
+			items = append(items, item)
+		}
+	}
+
+	// the snapshot at the memiavl snapshot height is exported in full, as the base of the incremental ones
+	base := snapshot(3)
+	require.NotNil(t, base[0].GetStore())
+	// the base version and two deltas
+	deltas := snapshot(5)
+	require.Len(t, deltas, 5)
+	require.Equal(t, IncrementalSnapshotExtension, deltas[0].GetExtension().Name)
+	for _, item := range deltas[1:4] {
+		require.NotNil(t, item.GetExtensionPayload())
+	}
+
+	restored := NewStore(t.TempDir(), log.NewNopLogger(), false, false)
+	restored.MountStoreWithDB(key, types.StoreTypeIAVL, nil)
+	require.NoError(t, restored.LoadLatestVersion())
+	defer restored.Close()
+
+	restore := func(height uint64, items []snapshottypes.SnapshotItem) (snapshottypes.SnapshotItem, error) {
+		var buf bytes.Buffer
+		writer := protoio.NewDelimitedWriter(&buf)
+		for i := range items {
+			require.NoError(t, writer.WriteMsg(&items[i]))
+		}
+		return restored.Restore(height, snapshottypes.CurrentFormat, protoio.NewDelimitedReader(&buf, 1<<20))
+	}
+
+	// the base snapshot must be restored first
+	_, err := restore(5, deltas)
+	require.ErrorContains(t, err, "the full snapshot at the base height 3 must be restored first")
+
+	next, err := restore(3, base)
+	require.NoError(t, err)
+	require.Equal(t, extension, next)
+	require.Equal(t, int64(3), restored.LastCommitID().Version)
+
+	next, err = restore(5, deltas)
+	require.NoError(t, err)
+	require.Equal(t, extension, next)
+	require.Equal(t, store.LastCommitID(), restored.LastCommitID())
+	require.Equal(t, []byte{5}, restored.GetKVStore(key).Get([]byte("hello")))
+}
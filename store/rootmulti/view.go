@@ -0,0 +1,126 @@
+package rootmulti
+
+import (
+	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/store/cachekv"
+	"cosmossdk.io/store/tracekv"
+	"cosmossdk.io/store/types"
+
+	"github.com/crypto-org-chain/cronos/memiavl"
+	"github.com/crypto-org-chain/cronos/store/memiavlstore"
+)
+
+// viewRef counts the users of a view, the cache multi store is one user until it's closed, and each iterator is
+// one until it's closed, the view is released after the last user is done, so the trees are never closed while
+// they are still being read.
+type viewRef struct {
+	view   *memiavl.View
+	refs   atomic.Int32
+	logger log.Logger
+}
+
+// acquire adds a user of the view, it panics if the view is released already, e.g. iterating a closed cache
+// multi store.
+func (r *viewRef) acquire() *viewUser {
+	for {
+		refs := r.refs.Load()
+		if refs <= 0 {
+			panic("memiavl view is already released")
+		}
+		if r.refs.CompareAndSwap(refs, refs+1) {
+			return newViewUser(r)
+		}
+	}
+}
+
+func (r *viewRef) unref() error {
+	if r.refs.Add(-1) == 0 {
+		return r.view.Release()
+	}
+	return nil
+}
+
+// viewUser holds one reference of the view until it's closed, the caller must close the cache multi store and the
+// iterators. The finalizer only reports the users garbage collected without being closed, it never releases the
+// view, because the view could be released while it's still read.
+type viewUser struct {
+	once sync.Once
+	ref  *viewRef
+}
+
+// newViewUser wraps a reference already counted in `ref`.
+func newViewUser(ref *viewRef) *viewUser {
+	user := &viewUser{ref: ref}
+	runtime.SetFinalizer(user, func(u *viewUser) {
+		u.ref.logger.Error("memiavl view leaked, the cache multi store or iterator is not closed", "version", u.ref.view.Version())
+	})
+	return user
+}
+
+func (u *viewUser) Close() error {
+	var err error
+	u.once.Do(func() {
+		runtime.SetFinalizer(u, nil)
+		err = u.ref.unref()
+	})
+	return err
+}
+
+// viewStore wraps the store of a tree in the view, the iterators hold their own references of the view.
+type viewStore struct {
+	*memiavlstore.Store
+	ref *viewRef
+}
+
+var _ types.KVStore = viewStore{}
+
+func (s viewStore) CacheWrap() types.CacheWrap {
+	return cachekv.NewStore(s)
+}
+
+func (s viewStore) CacheWrapWithTrace(w io.Writer, tc types.TraceContext) types.CacheWrap {
+	return cachekv.NewStore(tracekv.NewStore(s, w, tc))
+}
+
+func (s viewStore) Iterator(start, end []byte) types.Iterator {
+	user := s.ref.acquire()
+	return &viewIterator{Iterator: s.Store.Iterator(start, end), user: user}
+}
+
+func (s viewStore) ReverseIterator(start, end []byte) types.Iterator {
+	user := s.ref.acquire()
+	return &viewIterator{Iterator: s.Store.ReverseIterator(start, end), user: user}
+}
+
+// viewIterator holds a reference of the view until it's closed, so it outlives the cache multi store.
+type viewIterator struct {
+	types.Iterator
+	user *viewUser
+}
+
+func (it *viewIterator) Close() error {
+	err := it.Iterator.Close()
+	if uerr := it.user.Close(); err == nil {
+		err = uerr
+	}
+	return err
+}
+
+// cacheMultiStoreWithView builds a cache multi store with the trees in the view, which is released after the
+// cache multi store and the iterators created from it are all closed.
+func (rs *Store) cacheMultiStoreWithView(view *memiavl.View) types.CacheMultiStore {
+	ref := &viewRef{view: view, logger: rs.logger}
+	ref.refs.Store(1)
+	user := newViewUser(ref)
+	trees := view.Trees()
+	stores := make(map[types.StoreKey]types.CacheWrapper, len(trees))
+	for _, tree := range trees {
+		stores[rs.keysByName[tree.Name]] = viewStore{Store: memiavlstore.New(tree.Tree, rs.logger), ref: ref}
+	}
+	return rs.cacheMultiStoreWithStores(stores, user)
+}
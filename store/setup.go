@@ -15,13 +15,20 @@ import (
 )
 
 const (
-	FlagMemIAVL             = "memiavl.enable"
-	FlagAsyncCommitBuffer   = "memiavl.async-commit-buffer"
-	FlagZeroCopy            = "memiavl.zero-copy"
-	FlagSnapshotKeepRecent  = "memiavl.snapshot-keep-recent"
-	FlagSnapshotInterval    = "memiavl.snapshot-interval"
-	FlagCacheSize           = "memiavl.cache-size"
-	FlagSnapshotWriterLimit = "memiavl.snapshot-writer-limit"
+	FlagMemIAVL               = "memiavl.enable"
+	FlagAsyncCommitBuffer     = "memiavl.async-commit-buffer"
+	FlagZeroCopy              = "memiavl.zero-copy"
+	FlagSnapshotKeepRecent    = "memiavl.snapshot-keep-recent"
+	FlagSnapshotInterval      = "memiavl.snapshot-interval"
+	FlagCacheSize             = "memiavl.cache-size"
+	FlagSnapshotWriterLimit   = "memiavl.snapshot-writer-limit"
+	FlagHistoricalCacheSize   = "memiavl.historical-cache-size"
+	FlagWALArchiveDir         = "memiavl.wal-archive-dir"
+	FlagWALArchiveCompression = "memiavl.wal-archive-compression"
+	FlagWALArchiveChunkSize   = "memiavl.wal-archive-chunk-size"
+	FlagCompressColdSnapshots = "memiavl.compress-cold-snapshots"
+	FlagViewsKeepRecent       = "memiavl.views-keep-recent"
+	FlagIncrementalSnapshot   = "memiavl.incremental-snapshot"
 )
 
 // SetupMemIAVL insert the memiavl setter in front of baseapp options, so that
@@ -37,12 +44,17 @@ func SetupMemIAVL(
 ) []func(*baseapp.BaseApp) {
 	if cast.ToBool(appOpts.Get(FlagMemIAVL)) {
 		opts := memiavl.Options{
-			AsyncCommitBuffer:   cast.ToInt(appOpts.Get(FlagAsyncCommitBuffer)),
-			ZeroCopy:            cast.ToBool(appOpts.Get(FlagZeroCopy)),
-			SnapshotKeepRecent:  cast.ToUint32(appOpts.Get(FlagSnapshotKeepRecent)),
-			SnapshotInterval:    cast.ToUint32(appOpts.Get(FlagSnapshotInterval)),
-			CacheSize:           cacheSize,
-			SnapshotWriterLimit: cast.ToInt(appOpts.Get(FlagSnapshotWriterLimit)),
+			AsyncCommitBuffer:     cast.ToInt(appOpts.Get(FlagAsyncCommitBuffer)),
+			ZeroCopy:              cast.ToBool(appOpts.Get(FlagZeroCopy)),
+			SnapshotKeepRecent:    cast.ToUint32(appOpts.Get(FlagSnapshotKeepRecent)),
+			SnapshotInterval:      cast.ToUint32(appOpts.Get(FlagSnapshotInterval)),
+			CacheSize:             cacheSize,
+			SnapshotWriterLimit:   cast.ToInt(appOpts.Get(FlagSnapshotWriterLimit)),
+			WALArchiveDir:         cast.ToString(appOpts.Get(FlagWALArchiveDir)),
+			WALArchiveCompression: cast.ToString(appOpts.Get(FlagWALArchiveCompression)),
+			WALArchiveChunkSize:   cast.ToInt64(appOpts.Get(FlagWALArchiveChunkSize)),
+			CompressColdSnapshots: cast.ToBool(appOpts.Get(FlagCompressColdSnapshots)),
+			ViewsKeepRecent:       cast.ToUint32(appOpts.Get(FlagViewsKeepRecent)),
 		}
 
 		if opts.ZeroCopy {
@@ -52,13 +64,15 @@ func SetupMemIAVL(
 
 		// cms must be overridden before the other options, because they may use the cms,
 		// make sure the cms aren't be overridden by the other options later on.
-		baseAppOptions = append([]func(*baseapp.BaseApp){setMemIAVL(homePath, logger, opts, sdk46Compact, supportExportNonSnapshotVersion)}, baseAppOptions...)
+		historicalCacheSize := cast.ToInt(appOpts.Get(FlagHistoricalCacheSize))
+		incrementalSnapshot := cast.ToBool(appOpts.Get(FlagIncrementalSnapshot))
+		baseAppOptions = append([]func(*baseapp.BaseApp){setMemIAVL(homePath, logger, opts, sdk46Compact, supportExportNonSnapshotVersion, historicalCacheSize, incrementalSnapshot)}, baseAppOptions...)
 	}
 
 	return baseAppOptions
 }
 
-func setMemIAVL(homePath string, logger log.Logger, opts memiavl.Options, sdk46Compact bool, supportExportNonSnapshotVersion bool) func(*baseapp.BaseApp) {
+func setMemIAVL(homePath string, logger log.Logger, opts memiavl.Options, sdk46Compact bool, supportExportNonSnapshotVersion bool, historicalCacheSize int, incrementalSnapshot bool) func(*baseapp.BaseApp) {
 	return func(bapp *baseapp.BaseApp) {
 		// trigger state-sync snapshot creation by memiavl
 		opts.TriggerStateSyncExport = func(height int64) {
@@ -66,6 +80,8 @@ func setMemIAVL(homePath string, logger log.Logger, opts memiavl.Options, sdk46C
 		}
 		cms := rootmulti.NewStore(filepath.Join(homePath, "data", "memiavl.db"), logger, sdk46Compact, supportExportNonSnapshotVersion)
 		cms.SetMemIAVLOptions(opts)
+		cms.SetHistoricalCacheSize(historicalCacheSize)
+		cms.SetIncrementalSnapshot(incrementalSnapshot)
 		bapp.SetCMS(cms)
 	}
 }
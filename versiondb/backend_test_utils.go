@@ -54,6 +54,13 @@ func Run(t *testing.T, storeCreator func() VersionStore) {
 	testBasics(t, storeCreator())
 	testIterator(t, storeCreator())
 	testHeightInFuture(t, storeCreator())
+	testKeyHistory(t, storeCreator())
+	if store, ok := storeCreator().(HistoryPruner); ok {
+		testPruneBefore(t, store)
+	}
+	if store, ok := storeCreator().(HistoryPruner); ok {
+		testPruneStores(t, store)
+	}
 
 	// test delete in genesis, noop
 	store := storeCreator()
@@ -257,6 +264,223 @@ func testHeightInFuture(t *testing.T, store VersionStore) {
 	require.NoError(t, err)
 }
 
+func testKeyHistory(t *testing.T, store VersionStore) {
+	SetupTestDB(t, store)
+
+	changes, err := store.KeyHistory("evm", []byte("re-add-in-block3"), 0, 4, 0)
+	require.NoError(t, err)
+	require.Equal(t, []KeyChange{
+		{Version: 0, Value: []byte("1")},
+		{Version: 1, Deleted: true},
+		{Version: 3, Value: []byte("2")},
+		{Version: 4, Deleted: true},
+	}, changes)
+
+	// the range bounds are inclusive
+	changes, err = store.KeyHistory("evm", []byte("re-add-in-block3"), 1, 3, 0)
+	require.NoError(t, err)
+	require.Equal(t, []KeyChange{
+		{Version: 1, Deleted: true},
+		{Version: 3, Value: []byte("2")},
+	}, changes)
+
+	// the oldest changes are returned if limited
+	changes, err = store.KeyHistory("evm", []byte("re-add-in-block3"), 1, 4, 2)
+	require.NoError(t, err)
+	require.Equal(t, []KeyChange{
+		{Version: 1, Deleted: true},
+		{Version: 3, Value: []byte("2")},
+	}, changes)
+
+	// the keys sharing the prefix are not included
+	changes, err = store.KeyHistory("staking", key1, 0, 100, 0)
+	require.NoError(t, err)
+	require.Equal(t, []KeyChange{
+		{Version: 0, Value: value1},
+		{Version: 1, Deleted: true},
+		{Version: 2, Value: []byte("value2")},
+	}, changes)
+
+	changes, err = store.KeyHistory("evm", []byte("modify-in-block2"), 3, 4, 0)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	changes, err = store.KeyHistory("evm", []byte("non-exist"), 0, 4, 0)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	_, err = store.KeyHistory("evm", []byte("re-add-in-block3"), 3, 1, 0)
+	require.Error(t, err)
+}
+
+func testPruneBefore(t *testing.T, pruner HistoryPruner) {
+	store := pruner.(VersionStore)
+	SetupTestDB(t, store)
+
+	require.NoError(t, pruner.PruneBefore(2, nil, true))
+
+	// the queries before the version are rejected
+	v := int64(1)
+	_, err := store.GetAtVersion("evm", []byte("add-in-block1"), &v)
+	require.Error(t, err)
+
+	// the queries at or after the version are not affected
+	v = 2
+	it, err := store.IteratorAtVersion("evm", nil, nil, &v)
+	require.NoError(t, err)
+	require.Equal(t, []kvPair{
+		{[]byte("add-in-block1"), []byte("1")},
+		{[]byte("add-in-block2"), []byte("1")},
+		{[]byte("modify-in-block2"), []byte("2")},
+		{[]byte("z-genesis-only"), []byte("2")},
+	}, consumeIterator(it))
+
+	v = 3
+	value, err := store.GetAtVersion("evm", []byte("re-add-in-block3"), &v)
+	require.NoError(t, err)
+	require.Equal(t, []byte("2"), value)
+
+	value, err = store.GetAtVersion("staking", key1Subkey, nil)
+	require.NoError(t, err)
+	require.Equal(t, value1, value)
+
+	// the older version is a noop
+	require.NoError(t, pruner.PruneBefore(4, nil, true))
+	require.NoError(t, pruner.PruneBefore(3, nil, false))
+
+	v = 4
+	it, err = store.IteratorAtVersion("evm", nil, nil, &v)
+	require.NoError(t, err)
+	require.Equal(t, []kvPair{
+		{[]byte("add-in-block1"), []byte("1")},
+		{[]byte("add-in-block2"), []byte("1")},
+		{[]byte("modify-in-block2"), []byte("2")},
+		{[]byte("z-genesis-only"), []byte("2")},
+	}, consumeIterator(it))
+	value, err = store.GetAtVersion("staking", key1, &v)
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), value)
+}
+
+func testPruneStores(t *testing.T, pruner HistoryPruner) {
+	store := pruner.(VersionStore)
+	SetupTestDB(t, store)
+
+	require.NoError(t, pruner.PruneBefore(3, []string{"evm"}, true))
+
+	// the queries of the pruned store before the version are rejected
+	v := int64(2)
+	_, err := store.GetAtVersion("evm", []byte("modify-in-block2"), &v)
+	require.ErrorIs(t, err, ErrVersionPruned)
+	_, err = store.IteratorAtVersion("evm", nil, nil, &v)
+	require.ErrorIs(t, err, ErrVersionPruned)
+	_, err = store.KeyHistory("evm", []byte("modify-in-block2"), v, 4, 0)
+	require.ErrorIs(t, err, ErrVersionPruned)
+
+	// the other stores are not affected
+	v = 0
+	value, err := store.GetAtVersion("staking", []byte("key1"), &v)
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), value)
+
+	// the queries at or after the version are not affected
+	v = 3
+	value, err = store.GetAtVersion("evm", []byte("modify-in-block2"), &v)
+	require.NoError(t, err)
+	require.Equal(t, []byte("2"), value)
+	it, err := store.IteratorAtVersion("evm", nil, nil, &v)
+	require.NoError(t, err)
+	require.Equal(t, []kvPair{
+		{[]byte("add-in-block1"), []byte("1")},
+		{[]byte("add-in-block2"), []byte("1")},
+		{[]byte("modify-in-block2"), []byte("2")},
+		{[]byte("re-add-in-block3"), []byte("2")},
+		{[]byte("z-genesis-only"), []byte("2")},
+	}, consumeIterator(it))
+	changes, err := store.KeyHistory("evm", []byte("re-add-in-block3"), v, 4, 0)
+	require.NoError(t, err)
+	require.Equal(t, []KeyChange{
+		{Version: 3, Value: []byte("2")},
+		{Version: 4, Deleted: true},
+	}, changes)
+}
+
+// valueIndexes indexes the evm store by the values.
+var valueIndexes = Indexes{
+	{Name: "evm-value", Store: "evm", Extract: func(_, value []byte) [][]byte { return [][]byte{value} }},
+}
+
+// RunIndexes runs the tests of the secondary indexes, the stores created must implement `IndexedStore`.
+func RunIndexes(t *testing.T, storeCreator func(indexes Indexes) VersionStore) {
+	testIndexes(t, storeCreator(valueIndexes))
+	testImportIndexes(t, storeCreator(valueIndexes))
+}
+
+func testIndexes(t *testing.T, store VersionStore) {
+	SetupTestDB(t, store)
+	indexedStore := store.(IndexedStore)
+
+	expected := []struct {
+		version int64
+		prefix  string
+		entries []string
+	}{
+		{0, "1", []string{"delete-in-block2", "modify-in-block2", "re-add-in-block3"}},
+		{0, "2", []string{"z-genesis-only"}},
+		{1, "1", []string{"add-in-block1", "delete-in-block2", "modify-in-block2"}},
+		{2, "1", []string{"add-in-block1", "add-in-block2"}},
+		{2, "2", []string{"modify-in-block2", "z-genesis-only"}},
+		{3, "2", []string{"modify-in-block2", "re-add-in-block3", "z-genesis-only"}},
+		{4, "2", []string{"modify-in-block2", "z-genesis-only"}},
+	}
+	for _, tc := range expected {
+		prefix := IndexKeyPrefix([]byte(tc.prefix))
+		it, err := indexedStore.IndexIteratorAtVersion("evm-value", prefix, types.PrefixEndBytes(prefix), &tc.version)
+		require.NoError(t, err)
+		var entries []kvPair
+		for _, key := range tc.entries {
+			entries = append(entries, kvPair{indexEntryKey(tc.prefix, key), []byte(key)})
+		}
+		require.Equal(t, entries, consumeIterator(it), "version %d prefix %s", tc.version, tc.prefix)
+	}
+
+	// latest version
+	it, err := indexedStore.IndexIteratorAtVersion("evm-value", nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []kvPair{
+		{indexEntryKey("1", "add-in-block1"), []byte("add-in-block1")},
+		{indexEntryKey("1", "add-in-block2"), []byte("add-in-block2")},
+		{indexEntryKey("2", "modify-in-block2"), []byte("modify-in-block2")},
+		{indexEntryKey("2", "z-genesis-only"), []byte("z-genesis-only")},
+	}, consumeIterator(it))
+
+	_, err = indexedStore.IndexIteratorAtVersion("not-exists", nil, nil, nil)
+	require.Error(t, err)
+}
+
+func testImportIndexes(t *testing.T, store VersionStore) {
+	ch := make(chan ImportEntry)
+	go func() {
+		defer close(ch)
+		ch <- ImportEntry{StoreKey: "evm", Key: []byte("a"), Value: []byte("1")}
+		ch <- ImportEntry{StoreKey: "evm", Key: []byte("b"), Value: []byte("2")}
+		ch <- ImportEntry{StoreKey: "staking", Key: []byte("c"), Value: []byte("1")}
+	}()
+	require.NoError(t, store.Import(10, ch))
+
+	v := int64(10)
+	it, err := store.(IndexedStore).IndexIteratorAtVersion("evm-value", nil, nil, &v)
+	require.NoError(t, err)
+	require.Equal(t, []kvPair{
+		{indexEntryKey("1", "a"), []byte("a")},
+		{indexEntryKey("2", "b"), []byte("b")},
+	}, consumeIterator(it))
+}
+
+func indexEntryKey(indexKey, primaryKey string) []byte {
+	return append(IndexKeyPrefix([]byte(indexKey)), primaryKey...)
+}
+
 func consumeIterator(it dbm.Iterator) []kvPair {
 	var result []kvPair
 	for ; it.Valid(); it.Next() {
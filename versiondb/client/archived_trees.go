@@ -0,0 +1,174 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/cosmos/iavl"
+
+	"github.com/crypto-org-chain/cronos/memiavl"
+)
+
+// NewArchivedTrees returns a cache of the memiavl trees at archived versions, which is used to generate merkle
+// proofs for the versions pruned from memiavl db.
+//
+// A cache miss is served by loading the closest snapshot in `snapshotDir` and replaying the change set files in
+// `changeSetDir` on it, the same way as the `verify` command does. The snapshots are named `snapshot-<version>`
+// like the ones in memiavl db, they can be generated with `verify --save-snapshot`.
+func NewArchivedTrees(snapshotDir, changeSetDir string, stores []string, cacheSize int) *memiavl.MultiTreeCache {
+	return memiavl.NewMultiTreeCacheWithCatchup(snapshotDir, false, cacheSize, ChangeSetCatchup(changeSetDir, stores))
+}
+
+// ChangeSetCatchup returns a function which replays the change set files of the stores on the multi tree,
+// the stores missing in the tree are added at the first version of their change sets.
+func ChangeSetCatchup(changeSetDir string, stores []string) memiavl.CatchupFunc {
+	return func(mtree *memiavl.MultiTree, targetVersion int64) (err error) {
+		startVersion := mtree.Version() + 1
+
+		streams := make([]*changeSetStream, 0, len(stores))
+		defer func() {
+			for _, stream := range streams {
+				err = errors.Join(err, stream.Close())
+			}
+		}()
+		for _, store := range stores {
+			stream, err := openChangeSetStream(changeSetDir, store, startVersion)
+			if err != nil {
+				return err
+			}
+			streams = append(streams, stream)
+		}
+
+		for version := startVersion; version <= targetVersion; version++ {
+			var (
+				upgrades   []*memiavl.TreeNameUpgrade
+				changeSets []*memiavl.NamedChangeSet
+				exhausted  = true
+			)
+			for _, stream := range streams {
+				if stream.changeSet == nil {
+					continue
+				}
+				exhausted = false
+				if stream.version != version {
+					continue
+				}
+				if mtree.TreeByName(stream.store) == nil {
+					upgrades = append(upgrades, &memiavl.TreeNameUpgrade{Name: stream.store})
+				}
+				changeSets = append(changeSets, &memiavl.NamedChangeSet{
+					Name:      stream.store,
+					Changeset: convertChangeSet(stream.changeSet),
+				})
+				if err := stream.Next(); err != nil {
+					return err
+				}
+			}
+
+			if exhausted {
+				return fmt.Errorf("target version %d is beyond the change set files", targetVersion)
+			}
+
+			if err := mtree.ApplyUpgrades(upgrades); err != nil {
+				return err
+			}
+			if err := mtree.ApplyChangeSets(changeSets); err != nil {
+				return err
+			}
+			// no need to update hashes for intermediate versions.
+			if _, err := mtree.SaveVersion(false); err != nil {
+				return err
+			}
+		}
+
+		mtree.UpdateCommitInfo()
+		return nil
+	}
+}
+
+// changeSetStream reads the change sets of a store in version order across the change set files.
+type changeSetStream struct {
+	store  string
+	files  []FileWithVersion
+	reader ReadCloser
+	// the change sets before it are not needed
+	startVersion int64
+
+	// the current change set, nil if the stream is exhausted.
+	version   int64
+	changeSet *iavl.ChangeSet
+}
+
+// openChangeSetStream opens the change set files of the store, and seeks to the first change set whose
+// version is not smaller than `startVersion`.
+func openChangeSetStream(changeSetDir, store string, startVersion int64) (*changeSetStream, error) {
+	files, err := scanChangeSetFiles(changeSetDir, store)
+	if err != nil {
+		return nil, err
+	}
+
+	// skip the files which are entirely before the start version
+	i := sort.Search(len(files), func(i int) bool {
+		return files[i].Version > uint64(startVersion)
+	})
+	if i > 0 {
+		files = files[i-1:]
+	}
+
+	stream := &changeSetStream{store: store, files: files, startVersion: startVersion}
+	for {
+		if err := stream.Next(); err != nil {
+			return nil, errors.Join(err, stream.Close())
+		}
+		if stream.changeSet == nil || stream.version >= startVersion {
+			return stream, nil
+		}
+	}
+}
+
+// Next moves to the next change set, it opens the next file when the current one is exhausted.
+func (s *changeSetStream) Next() error {
+	for {
+		if s.reader == nil {
+			if len(s.files) == 0 {
+				s.changeSet = nil
+				return nil
+			}
+
+			// the v2 files are positioned with the index, the earlier versions are skipped below for the others
+			reader, err := openChangeSetFileAt(s.files[0].FileName, s.startVersion)
+			if err != nil {
+				return err
+			}
+			s.reader = reader
+			s.files = s.files[1:]
+		}
+
+		version, _, changeSet, err := ReadChangeSet(s.reader, true)
+		if err == io.EOF {
+			if err := s.reader.Close(); err != nil {
+				return err
+			}
+			s.reader = nil
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		s.version = version
+		s.changeSet = changeSet
+		return nil
+	}
+}
+
+func (s *changeSetStream) Close() error {
+	if s.reader == nil {
+		return nil
+	}
+	err := s.reader.Close()
+	s.reader = nil
+	return err
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/crypto-org-chain/cronos/memiavl"
+)
+
+func TestArchivedTrees(t *testing.T) {
+	changeSetDir := t.TempDir()
+	storeDir := filepath.Join(changeSetDir, "test")
+	require.NoError(t, os.MkdirAll(storeDir, os.ModePerm))
+
+	// split the change sets into two files
+	for i, chunk := range [][2]int{{0, 3}, {3, len(ChangeSets)}} {
+		fp, err := os.Create(filepath.Join(storeDir, []string{"block-1", "block-4"}[i]))
+		require.NoError(t, err)
+		for j := chunk[0]; j < chunk[1]; j++ {
+			require.NoError(t, WriteChangeSet(fp, int64(j+1), ChangeSets[j]))
+		}
+		require.NoError(t, fp.Close())
+	}
+
+	// the reference root hashes
+	tree := memiavl.New(0)
+	hashes := make([][]byte, len(ChangeSets))
+	for i, cs := range ChangeSets {
+		tree.ApplyChangeSet(convertChangeSet(cs))
+		hash, _, err := tree.SaveVersion(true)
+		require.NoError(t, err)
+		hashes[i] = hash
+	}
+
+	// an empty snapshot at version 0, the store is added at the first version of the change sets.
+	snapshotDir := t.TempDir()
+	db, err := memiavl.Load(snapshotDir, memiavl.Options{CreateIfMissing: true})
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	trees := NewArchivedTrees(snapshotDir, changeSetDir, []string{"test"}, 2)
+	defer trees.Close()
+
+	for _, version := range []int64{2, 1, 5, int64(len(ChangeSets)), 3} {
+		mtree, release, err := trees.Get(version)
+		require.NoError(t, err)
+		require.Equal(t, version, mtree.Version())
+		require.Equal(t, hashes[version-1], mtree.TreeByName("test").RootHash())
+		require.Equal(t, hashes[version-1], mtree.LastCommitInfo().StoreInfos[0].CommitId.Hash)
+		release()
+	}
+
+	_, _, err = trees.Get(int64(len(ChangeSets)) + 1)
+	require.Error(t, err)
+}
@@ -8,16 +8,19 @@ import (
 	"io"
 	"math/bits"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 
 	"github.com/cosmos/iavl"
 	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
 )
 
 const (
 	ZlibFileSuffix   = ".zz"
 	SnappyFileSuffix = ".snappy"
+	ZstdFileSuffix   = ".zst"
 )
 
 // WriteChangeSet writes a version of change sets to writer.
@@ -191,9 +194,15 @@ func readKVPair(reader Reader) (*iavl.KVPair, error) {
 }
 
 // openChangeSetFile opens change set file,
-// it handles compressed files automatically,
+// it handles compressed files and v2 files automatically,
 // also supports special name "-" to specify stdin.
 func openChangeSetFile(fileName string) (ReadCloser, error) {
+	return openChangeSetFileAt(fileName, 0)
+}
+
+// openChangeSetFileAt is like `openChangeSetFile`, but the v2 files are positioned at the first version not smaller
+// than `startVersion` with the index, the other files still start from the beginning.
+func openChangeSetFileAt(fileName string, startVersion int64) (ReadCloser, error) {
 	if fileName == "-" {
 		return WrapReader(bufio.NewReader(os.Stdin), nil), nil
 	}
@@ -213,16 +222,53 @@ func openChangeSetFile(fileName string) (ReadCloser, error) {
 		reader = bufio.NewReader(zreader)
 	case strings.HasSuffix(fileName, SnappyFileSuffix):
 		reader = snappy.NewReader(fp)
+	case strings.HasSuffix(fileName, ZstdFileSuffix):
+		zreader, err := zstd.NewReader(fp)
+		if err != nil {
+			_ = fp.Close()
+			return nil, err
+		}
+		// the decoder must be closed to release the background goroutines
+		return WrapReader(bufio.NewReader(zreader), zstdFileCloser{zreader, fp}), nil
 	default:
+		isV2, err := isChangeSetFileV2(fp)
+		if err != nil {
+			_ = fp.Close()
+			return nil, err
+		}
+		if isV2 {
+			f, err := newChangeSetFileV2(fp)
+			if err != nil {
+				_ = fp.Close()
+				return nil, fmt.Errorf("invalid change set file %s: %w", fileName, err)
+			}
+			return WrapReader(f.NewReader(startVersion), f), nil
+		}
 		reader = bufio.NewReader(fp)
 	}
 	return WrapReader(reader, fp), nil
 }
 
+type zstdFileCloser struct {
+	decoder *zstd.Decoder
+	fp      *os.File
+}
+
+func (c zstdFileCloser) Close() error {
+	c.decoder.Close()
+	return c.fp.Close()
+}
+
 // withChangeSetFile opens change set file and pass the reader to callback,
 // it closes the file immediately after callback returns.
 func withChangeSetFile(fileName string, fn func(Reader) error) error {
-	reader, err := openChangeSetFile(fileName)
+	return withChangeSetFileAt(fileName, 0, fn)
+}
+
+// withChangeSetFileAt is like `withChangeSetFile`, but skips the earlier versions with the index if possible,
+// see `openChangeSetFileAt`.
+func withChangeSetFileAt(fileName string, startVersion int64, fn func(Reader) error) error {
+	reader, err := openChangeSetFileAt(fileName, startVersion)
 	if err != nil {
 		return err
 	}
@@ -333,7 +379,8 @@ func SortFilesByFirstVerson(files []string) ([]FileWithVersion, error) {
 	return nonEmptyFiles, nil
 }
 
-// ReadFirstVersion parse the first version number in the change set file
+// ReadFirstVersion parse the first version number in the change set file,
+// only the first block is decoded for v2 files, returns `io.EOF` if the file is empty.
 func ReadFirstVersion(fileName string) (uint64, error) {
 	fp, err := openChangeSetFile(fileName)
 	if err != nil {
@@ -361,3 +408,32 @@ func uvarintSize(num uint64) int {
 	}
 	return size
 }
+
+// scanChangeSetFiles find change set files from the directory and sort them by the first version included, filter out
+// empty files.
+func scanChangeSetFiles(changeSetDir, store string) ([]FileWithVersion, error) {
+	files, err := scanChangeSetDir(filepath.Join(changeSetDir, store))
+	// assume the change set files are taken from older versions, don't include all stores.
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return files, err
+}
+
+// scanChangeSetDir find change set files in the directory and sort them by the first version included, the
+// unfinished temporary files written by the change set sink are ignored.
+func scanChangeSetDir(dir string) ([]FileWithVersion, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	fileNames := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasSuffix(name, TmpFileSuffix) || strings.HasSuffix(name, recoverFileSuffix) {
+			continue
+		}
+		fileNames = append(fileNames, filepath.Join(dir, name))
+	}
+	return SortFilesByFirstVerson(fileNames)
+}
@@ -4,11 +4,15 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"io"
 	"math"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/cosmos/iavl"
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/require"
 )
 
@@ -185,3 +189,131 @@ func TestChangeSetRoundTrip(t *testing.T) {
 		})
 	}
 }
+
+func TestZstdChangeSetFile(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "block-1"+ZstdFileSuffix)
+	fp, err := os.Create(fileName)
+	require.NoError(t, err)
+	writer, err := zstd.NewWriter(fp)
+	require.NoError(t, err)
+	for i, changeSet := range ChangeSets {
+		require.NoError(t, WriteChangeSet(writer, int64(i+1), changeSet))
+	}
+	require.NoError(t, writer.Close())
+	require.NoError(t, fp.Close())
+
+	reader, err := openChangeSetFile(fileName)
+	require.NoError(t, err)
+	defer reader.Close()
+	for i, expected := range ChangeSets {
+		version, _, changeSet, err := ReadChangeSet(reader, true)
+		require.NoError(t, err)
+		require.Equal(t, int64(i+1), version)
+		require.Equal(t, len(expected.Pairs), len(changeSet.Pairs))
+	}
+}
+
+func writeChangeSetFileV2(t *testing.T, fileName string, compression ChangeSetCompression, changeSets []*iavl.ChangeSet) {
+	fp, err := os.Create(fileName)
+	require.NoError(t, err)
+	writer, err := NewChangeSetWriterV2(fp, "test", compression)
+	require.NoError(t, err)
+	for i, changeSet := range changeSets {
+		require.NoError(t, writer.WriteChangeSet(int64(i+1), changeSet))
+	}
+	if len(changeSets) > 0 {
+		// the versions must be increasing
+		require.Error(t, writer.WriteChangeSet(int64(len(changeSets)), ChangeSets[0]))
+	}
+	require.NoError(t, writer.Close())
+	require.NoError(t, fp.Close())
+}
+
+func TestChangeSetFileV2(t *testing.T) {
+	for _, compression := range []ChangeSetCompression{CompressionNone, CompressionZstd} {
+		t.Run(fmt.Sprintf("compression-%d", compression), func(t *testing.T) {
+			fileName := filepath.Join(t.TempDir(), "block-1")
+			writeChangeSetFileV2(t, fileName, compression, ChangeSets)
+
+			// sequential read, same as v1 files
+			var versions []int64
+			require.NoError(t, withChangeSetFile(fileName, func(reader Reader) error {
+				_, err := IterateChangeSets(reader, func(version int64, changeSet *iavl.ChangeSet) (bool, error) {
+					require.Equal(t, ChangeSets[version-1].Pairs, changeSet.Pairs)
+					versions = append(versions, version)
+					return true, nil
+				})
+				return err
+			}))
+			require.Equal(t, []int64{1, 2, 3, 4, 5, 6, 7}, versions)
+
+			firstVersion, err := ReadFirstVersion(fileName)
+			require.NoError(t, err)
+			require.Equal(t, uint64(1), firstVersion)
+
+			store, err := ReadChangeSetFileStore(fileName)
+			require.NoError(t, err)
+			require.Equal(t, "test", store)
+
+			// skip the earlier versions with the index
+			versions = nil
+			require.NoError(t, withChangeSetFileAt(fileName, 5, func(reader Reader) error {
+				_, err := IterateVersions(reader, func(version int64) (bool, error) {
+					versions = append(versions, version)
+					return true, nil
+				})
+				return err
+			}))
+			require.Equal(t, []int64{5, 6, 7}, versions)
+
+			// random access
+			f, err := OpenChangeSetFileV2(fileName)
+			require.NoError(t, err)
+			defer f.Close()
+			require.Equal(t, "test", f.Store())
+			require.Len(t, f.Index(), len(ChangeSets))
+			for _, version := range []int64{7, 3, 1} {
+				changeSet, err := f.ReadChangeSet(version)
+				require.NoError(t, err)
+				require.Equal(t, ChangeSets[version-1].Pairs, changeSet.Pairs)
+			}
+			changeSet, err := f.ReadChangeSet(8)
+			require.NoError(t, err)
+			require.Nil(t, changeSet)
+		})
+	}
+}
+
+func TestChangeSetFileV2Invalid(t *testing.T) {
+	dir := t.TempDir()
+
+	// empty file is skipped like the v1 ones
+	emptyFile := filepath.Join(dir, "block-0")
+	writeChangeSetFileV2(t, emptyFile, CompressionZstd, nil)
+	_, err := ReadFirstVersion(emptyFile)
+	require.Equal(t, io.EOF, err)
+
+	fileName := filepath.Join(dir, "block-1")
+	writeChangeSetFileV2(t, fileName, CompressionZstd, ChangeSets)
+	bz, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+
+	// the incomplete file is rejected
+	truncated := filepath.Join(dir, "truncated")
+	require.NoError(t, os.WriteFile(truncated, bz[:len(bz)-1], 0o600))
+	_, err = OpenChangeSetFileV2(truncated)
+	require.Error(t, err)
+	_, err = openChangeSetFile(truncated)
+	require.Error(t, err)
+
+	// v1 file is not a v2 file
+	v1File := filepath.Join(dir, "v1")
+	var buf bytes.Buffer
+	require.NoError(t, WriteChangeSet(&buf, 1, ChangeSets[0]))
+	require.NoError(t, os.WriteFile(v1File, buf.Bytes(), 0o600))
+	_, err = OpenChangeSetFileV2(v1File)
+	require.Error(t, err)
+	store, err := ReadChangeSetFileStore(v1File)
+	require.NoError(t, err)
+	require.Empty(t, store)
+}
@@ -0,0 +1,425 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/cosmos/iavl"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// ChangeSetFormatV1 is the plain stream of change sets written by `WriteChangeSet`.
+	ChangeSetFormatV1 = "v1"
+	// ChangeSetFormatV2 is the container format with the block index, see `ChangeSetWriterV2`.
+	ChangeSetFormatV2 = "v2"
+
+	// changeSetV2Magic is at both the beginning and the end of the v2 files, interpreted as the first version number
+	// of a v1 file, it's far beyond any real block height.
+	changeSetV2Magic = "VDBCSv2\x00"
+	// maxStoreNameLength limits the store name in header, to detect the corrupted files early.
+	maxStoreNameLength = 256
+
+	// changeSetV2TrailerSize is the size of `indexOffset | count | magic` at the end of the v2 files.
+	changeSetV2TrailerSize = 8 + 8 + 8
+	// changeSetV2IndexEntrySize is the size of `version | offset` in the index.
+	changeSetV2IndexEntrySize = 16
+)
+
+// ChangeSetCompression is the compression of the blocks in v2 change set files.
+type ChangeSetCompression uint8
+
+const (
+	CompressionNone ChangeSetCompression = iota
+	CompressionZstd
+)
+
+// ChangeSetIndexEntry locates the block of a version in the v2 change set file.
+type ChangeSetIndexEntry struct {
+	Version int64
+	Offset  uint64
+}
+
+// ChangeSetWriterV2 writes the change sets of a store in the v2 format, each version is encoded the same way as
+// `WriteChangeSet` and compressed independently, so any version can be decoded without reading the earlier ones.
+//
+// Change set file v2 format:
+// ```
+// header:
+//
+//	magic: [8]byte
+//	compression: uint8
+//	storeLen: varint-uint64
+//	store
+//
+// blocks:
+//
+//	the (compressed) v1 encoding of a version
+//	repeat with next version
+//
+// index:
+//
+//	version: uint64
+//	offset: uint64      // offset of the block in file
+//	repeat with next version
+//
+// trailer:
+//
+//	indexOffset: uint64
+//	count: uint64       // number of index entries
+//	magic: [8]byte
+//
+// ```
+// the integers are little endian.
+type ChangeSetWriterV2 struct {
+	writer      io.Writer
+	compression ChangeSetCompression
+	encoder     *zstd.Encoder
+
+	offset uint64
+	index  []ChangeSetIndexEntry
+	buf    bytes.Buffer
+}
+
+// NewChangeSetWriterV2 writes the header to the writer, the caller should call `Close` to write the index after
+// all the change sets are written, it don't close the underlying writer.
+func NewChangeSetWriterV2(writer io.Writer, store string, compression ChangeSetCompression) (*ChangeSetWriterV2, error) {
+	if len(store) > maxStoreNameLength {
+		return nil, fmt.Errorf("store name too long: %d", len(store))
+	}
+
+	w := &ChangeSetWriterV2{writer: writer, compression: compression}
+	switch compression {
+	case CompressionNone:
+	case CompressionZstd:
+		encoder, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, err
+		}
+		w.encoder = encoder
+	default:
+		return nil, fmt.Errorf("unknown compression: %d", compression)
+	}
+
+	header := make([]byte, 0, len(changeSetV2Magic)+1+binary.MaxVarintLen64+len(store))
+	header = append(header, changeSetV2Magic...)
+	header = append(header, byte(compression))
+	header = binary.AppendUvarint(header, uint64(len(store)))
+	header = append(header, store...)
+	if err := w.write(header); err != nil {
+		return nil, errors.Join(err, w.closeEncoder())
+	}
+	return w, nil
+}
+
+// WriteChangeSet writes a version as a block, the versions must be increasing.
+func (w *ChangeSetWriterV2) WriteChangeSet(version int64, cs *iavl.ChangeSet) error {
+	if n := len(w.index); n > 0 && version <= w.index[n-1].Version {
+		return fmt.Errorf("version %d is not increasing, last version: %d", version, w.index[n-1].Version)
+	}
+
+	w.buf.Reset()
+	if err := WriteChangeSet(&w.buf, version, cs); err != nil {
+		return err
+	}
+	block := w.buf.Bytes()
+	if w.encoder != nil {
+		block = w.encoder.EncodeAll(block, nil)
+	}
+
+	w.index = append(w.index, ChangeSetIndexEntry{Version: version, Offset: w.offset})
+	return w.write(block)
+}
+
+// Close writes the index and trailer, and releases the encoder.
+func (w *ChangeSetWriterV2) Close() error {
+	indexOffset := w.offset
+	buf := make([]byte, 0, len(w.index)*changeSetV2IndexEntrySize+changeSetV2TrailerSize)
+	for _, entry := range w.index {
+		buf = binary.LittleEndian.AppendUint64(buf, uint64(entry.Version))
+		buf = binary.LittleEndian.AppendUint64(buf, entry.Offset)
+	}
+	buf = binary.LittleEndian.AppendUint64(buf, indexOffset)
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(len(w.index)))
+	buf = append(buf, changeSetV2Magic...)
+	return errors.Join(w.write(buf), w.closeEncoder())
+}
+
+func (w *ChangeSetWriterV2) write(bz []byte) error {
+	n, err := w.writer.Write(bz)
+	w.offset += uint64(n)
+	return err
+}
+
+func (w *ChangeSetWriterV2) closeEncoder() error {
+	if w.encoder == nil {
+		return nil
+	}
+	err := w.encoder.Close()
+	w.encoder = nil
+	return err
+}
+
+// ChangeSetFileV2 provides the random access to the change sets in a v2 file.
+type ChangeSetFileV2 struct {
+	fp          *os.File
+	store       string
+	compression ChangeSetCompression
+	decoder     *zstd.Decoder
+
+	// the offset of index, which is also the end of the last block
+	indexOffset uint64
+	index       []ChangeSetIndexEntry
+}
+
+// OpenChangeSetFileV2 opens a v2 change set file and loads the index.
+func OpenChangeSetFileV2(fileName string) (*ChangeSetFileV2, error) {
+	fp, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	f, err := newChangeSetFileV2(fp)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("invalid change set file %s: %w", fileName, err), fp.Close())
+	}
+	return f, nil
+}
+
+func newChangeSetFileV2(fp *os.File) (*ChangeSetFileV2, error) {
+	stat, err := fp.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := stat.Size()
+
+	// header
+	reader := bufio.NewReader(io.NewSectionReader(fp, 0, size))
+	var magic [len(changeSetV2Magic)]byte
+	if _, err := io.ReadFull(reader, magic[:]); err != nil {
+		return nil, err
+	}
+	if string(magic[:]) != changeSetV2Magic {
+		return nil, errors.New("not a v2 change set file")
+	}
+	compression, err := reader.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	storeLen, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, err
+	}
+	if storeLen > maxStoreNameLength {
+		return nil, fmt.Errorf("store name too long: %d", storeLen)
+	}
+	store := make([]byte, storeLen)
+	if _, err := io.ReadFull(reader, store); err != nil {
+		return nil, err
+	}
+	headerSize := uint64(len(changeSetV2Magic) + 1 + uvarintSize(storeLen) + int(storeLen))
+
+	// trailer
+	if size < int64(headerSize)+int64(changeSetV2TrailerSize) {
+		return nil, errors.New("incomplete file, trailer not found")
+	}
+	var trailer [changeSetV2TrailerSize]byte
+	if _, err := fp.ReadAt(trailer[:], size-changeSetV2TrailerSize); err != nil {
+		return nil, err
+	}
+	if string(trailer[16:]) != changeSetV2Magic {
+		return nil, errors.New("incomplete file, trailer not found")
+	}
+	indexOffset := binary.LittleEndian.Uint64(trailer[:8])
+	count := binary.LittleEndian.Uint64(trailer[8:16])
+	if indexOffset < headerSize || count > uint64(size)/changeSetV2IndexEntrySize ||
+		indexOffset+count*changeSetV2IndexEntrySize+changeSetV2TrailerSize != uint64(size) {
+		return nil, fmt.Errorf("invalid trailer, index offset: %d, count: %d", indexOffset, count)
+	}
+
+	// index
+	buf := make([]byte, count*changeSetV2IndexEntrySize)
+	if _, err := fp.ReadAt(buf, int64(indexOffset)); err != nil {
+		return nil, err
+	}
+	index := make([]ChangeSetIndexEntry, count)
+	for i := range index {
+		entry := ChangeSetIndexEntry{
+			Version: int64(binary.LittleEndian.Uint64(buf[i*changeSetV2IndexEntrySize:])),
+			Offset:  binary.LittleEndian.Uint64(buf[i*changeSetV2IndexEntrySize+8:]),
+		}
+		if i == 0 && entry.Offset != headerSize {
+			return nil, fmt.Errorf("invalid offset of the first block: %d", entry.Offset)
+		}
+		if i > 0 && (entry.Version <= index[i-1].Version || entry.Offset <= index[i-1].Offset) {
+			return nil, fmt.Errorf("index entries are not increasing, version: %d", entry.Version)
+		}
+		if entry.Offset >= indexOffset {
+			return nil, fmt.Errorf("invalid block offset: %d, version: %d", entry.Offset, entry.Version)
+		}
+		index[i] = entry
+	}
+
+	f := &ChangeSetFileV2{
+		fp:          fp,
+		store:       string(store),
+		compression: ChangeSetCompression(compression),
+		indexOffset: indexOffset,
+		index:       index,
+	}
+	switch f.compression {
+	case CompressionNone:
+	case CompressionZstd:
+		if f.decoder, err = zstd.NewReader(nil); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown compression: %d", compression)
+	}
+	return f, nil
+}
+
+// Store returns the store name in the header.
+func (f *ChangeSetFileV2) Store() string {
+	return f.store
+}
+
+// Index returns the versions and block offsets in the file.
+func (f *ChangeSetFileV2) Index() []ChangeSetIndexEntry {
+	return f.index
+}
+
+// ReadChangeSet decodes the change set of the version, returns `nil` if the version is not in the file.
+func (f *ChangeSetFileV2) ReadChangeSet(version int64) (*iavl.ChangeSet, error) {
+	i := f.search(version)
+	if i == len(f.index) || f.index[i].Version != version {
+		return nil, nil
+	}
+	block, err := f.readBlock(i)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBlock(block, version)
+}
+
+// NewReader returns a reader of the v1 encoded change sets, starting from the first version not smaller than
+// `startVersion`, so it can be consumed with `IterateChangeSets` like the v1 files.
+func (f *ChangeSetFileV2) NewReader(startVersion int64) Reader {
+	return &changeSetV2Reader{file: f, next: f.search(startVersion)}
+}
+
+// Close releases the decoder and closes the file.
+func (f *ChangeSetFileV2) Close() error {
+	if f.decoder != nil {
+		f.decoder.Close()
+	}
+	return f.fp.Close()
+}
+
+// search returns the position of the first version not smaller than the target version.
+func (f *ChangeSetFileV2) search(version int64) int {
+	return sort.Search(len(f.index), func(i int) bool {
+		return f.index[i].Version >= version
+	})
+}
+
+// readBlock reads and decompresses the i-th block.
+func (f *ChangeSetFileV2) readBlock(i int) ([]byte, error) {
+	end := f.indexOffset
+	if i+1 < len(f.index) {
+		end = f.index[i+1].Offset
+	}
+	block := make([]byte, end-f.index[i].Offset)
+	if _, err := f.fp.ReadAt(block, int64(f.index[i].Offset)); err != nil {
+		return nil, err
+	}
+	if f.decoder == nil {
+		return block, nil
+	}
+	return f.decoder.DecodeAll(block, nil)
+}
+
+// decodeBlock decodes the change set in a block, and checks it matches the index.
+func decodeBlock(block []byte, expVersion int64) (*iavl.ChangeSet, error) {
+	version, size, changeSet, err := ReadChangeSet(bytes.NewReader(block), true)
+	if err != nil {
+		return nil, err
+	}
+	if version != expVersion || size != int64(len(block)) {
+		return nil, fmt.Errorf("block don't match the index, version: %d, expected: %d", version, expVersion)
+	}
+	return changeSet, nil
+}
+
+// changeSetV2Reader concatenates the decompressed blocks.
+type changeSetV2Reader struct {
+	file  *ChangeSetFileV2
+	next  int
+	block bytes.Reader
+}
+
+func (r *changeSetV2Reader) Read(p []byte) (int, error) {
+	if err := r.fill(); err != nil {
+		return 0, err
+	}
+	return r.block.Read(p)
+}
+
+func (r *changeSetV2Reader) ReadByte() (byte, error) {
+	if err := r.fill(); err != nil {
+		return 0, err
+	}
+	return r.block.ReadByte()
+}
+
+// fill loads the next block if the current one is consumed.
+func (r *changeSetV2Reader) fill() error {
+	if r.block.Len() > 0 {
+		return nil
+	}
+	if r.next >= len(r.file.index) {
+		return io.EOF
+	}
+	block, err := r.file.readBlock(r.next)
+	if err != nil {
+		return err
+	}
+	r.block.Reset(block)
+	r.next++
+	return nil
+}
+
+// isChangeSetFileV2 checks the magic at the beginning of the file.
+func isChangeSetFileV2(fp *os.File) (bool, error) {
+	var magic [len(changeSetV2Magic)]byte
+	_, err := fp.ReadAt(magic[:], 0)
+	if err == io.EOF {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return string(magic[:]) == changeSetV2Magic, nil
+}
+
+// ReadChangeSetFileStore returns the store name in the header of a v2 file, empty for the other formats.
+func ReadChangeSetFileStore(fileName string) (string, error) {
+	fp, err := os.Open(fileName)
+	if err != nil {
+		return "", err
+	}
+	isV2, err := isChangeSetFileV2(fp)
+	if err != nil || !isV2 {
+		return "", errors.Join(err, fp.Close())
+	}
+	f, err := newChangeSetFileV2(fp)
+	if err != nil {
+		return "", errors.Join(fmt.Errorf("invalid change set file %s: %w", fileName, err), fp.Close())
+	}
+	return f.Store(), f.Close()
+}
@@ -0,0 +1,297 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+
+	"cosmossdk.io/store/types"
+	"github.com/cosmos/iavl"
+	"github.com/spf13/cobra"
+
+	"github.com/crypto-org-chain/cronos/memiavl"
+	"github.com/crypto-org-chain/cronos/versiondb"
+)
+
+const (
+	flagVersion = "version"
+	flagRepair  = "repair"
+	flagQuick   = "quick"
+)
+
+// CheckIssueType is the type of the inconsistency found by `CheckStore`.
+type CheckIssueType int
+
+const (
+	// CheckMissing means the key exists in memiavl but not in versiondb.
+	CheckMissing CheckIssueType = iota
+	// CheckExtra means the key exists in versiondb but not in memiavl.
+	CheckExtra
+	// CheckMismatch means the key exists in both but the values are different.
+	CheckMismatch
+)
+
+func (t CheckIssueType) String() string {
+	switch t {
+	case CheckMissing:
+		return "missing"
+	case CheckExtra:
+		return "extra"
+	case CheckMismatch:
+		return "mismatch"
+	default:
+		panic(fmt.Sprintf("unknown check issue type: %d", t))
+	}
+}
+
+// checkEntry is the json line outputted by the check-versiondb command.
+type checkEntry struct {
+	Store          string `json:"store"`
+	Type           string `json:"type"`
+	Key            []byte `json:"key"`
+	Value          []byte `json:"value,omitempty"`
+	VersionDBValue []byte `json:"versiondb_value,omitempty"`
+}
+
+// changeSetFeeder is implemented by the versiondb backends which can write a change set at an existing version
+// without changing the latest version.
+type changeSetFeeder interface {
+	FeedChangeSet(version int64, store string, changeSet *iavl.ChangeSet) error
+}
+
+func CheckVersionDBCmd(defaultStores []string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check-versiondb versiondb-dir memiavl-dir",
+		Short: "Compare the key-value pairs in versiondb against the memiavl db (e.g. data/memiavl.db) at the same version, output the missing, extra and mismatched keys as json lines, and optionally repair versiondb from memiavl",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) (returnErr error) {
+			version, err := cmd.Flags().GetInt64(flagVersion)
+			if err != nil {
+				return err
+			}
+			stores, err := GetStoresOrDefault(cmd, defaultStores)
+			if err != nil {
+				return err
+			}
+			backend, err := cmd.Flags().GetString(flagBackend)
+			if err != nil {
+				return err
+			}
+			repair, err := cmd.Flags().GetBool(flagRepair)
+			if err != nil {
+				return err
+			}
+			quick, err := cmd.Flags().GetBool(flagQuick)
+			if err != nil {
+				return err
+			}
+			if repair && quick {
+				return errors.New("--repair can't be used with --quick")
+			}
+
+			versionDB, closer, err := openVersionStore(args[0], backend)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				returnErr = errors.Join(returnErr, closer())
+			}()
+			if version == 0 {
+				if version, err = versionDB.GetLatestVersion(); err != nil {
+					return err
+				}
+			}
+
+			db, err := memiavl.Load(args[1], memiavl.Options{TargetVersion: uint32(version), ReadOnly: true, ZeroCopy: true})
+			if err != nil {
+				return fmt.Errorf("load memiavl version %d failed: %w", version, err)
+			}
+			defer func() {
+				returnErr = errors.Join(returnErr, db.Close())
+			}()
+			if db.Version() != version {
+				return fmt.Errorf("memiavl version %d don't match the target version %d", db.Version(), version)
+			}
+
+			if len(stores) == 0 {
+				for _, tree := range db.Trees() {
+					stores = append(stores, tree.Name)
+				}
+			}
+
+			var feeder changeSetFeeder
+			if repair {
+				var ok bool
+				if feeder, ok = versionDB.(changeSetFeeder); !ok {
+					return fmt.Errorf("versiondb backend %s don't support repair", backend)
+				}
+			}
+
+			var inconsistent []string
+			for _, store := range stores {
+				tree := db.TreeByName(store)
+				if tree == nil {
+					return fmt.Errorf("store %s not found in memiavl", store)
+				}
+
+				if quick {
+					vdbHash, treeHash, err := HashStore(versionDB, store, version, tree)
+					if err != nil {
+						return err
+					}
+					if !bytes.Equal(vdbHash, treeHash) {
+						inconsistent = append(inconsistent, store)
+					}
+					fmt.Fprintf(cmd.ErrOrStderr(), "%s versiondb: %X memiavl: %X\n", store, vdbHash, treeHash)
+					continue
+				}
+
+				var fixes iavl.ChangeSet
+				if err := CheckStore(versionDB, store, version, tree, func(typ CheckIssueType, key, value, vdbValue []byte) error {
+					bz, err := json.Marshal(checkEntry{Store: store, Type: typ.String(), Key: key, Value: value, VersionDBValue: vdbValue})
+					if err != nil {
+						return err
+					}
+					if _, err := fmt.Fprintln(cmd.OutOrStdout(), string(bz)); err != nil {
+						return err
+					}
+
+					if repair {
+						if typ == CheckExtra {
+							fixes.Pairs = append(fixes.Pairs, &iavl.KVPair{Key: bytes.Clone(key), Delete: true})
+						} else {
+							fixes.Pairs = append(fixes.Pairs, &iavl.KVPair{Key: bytes.Clone(key), Value: bytes.Clone(value)})
+						}
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+
+				if len(fixes.Pairs) == 0 {
+					continue
+				}
+				if !repair {
+					inconsistent = append(inconsistent, store)
+					continue
+				}
+				if err := feeder.FeedChangeSet(version, store, &fixes); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.ErrOrStderr(), "repaired %d keys in store %s\n", len(fixes.Pairs), store)
+			}
+
+			if repair {
+				return versionDB.Flush()
+			}
+			if len(inconsistent) > 0 {
+				return fmt.Errorf("versiondb is inconsistent with memiavl at version %d in stores: %v", version, inconsistent)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().Int64(flagVersion, 0, "the version to check, default to the latest version of versiondb")
+	cmd.Flags().String(flagStores, "", "list of store names, default to the current store list in application")
+	cmd.Flags().String(flagBackend, "rocksdb", "the db backend of versiondb, rocksdb or goleveldb")
+	cmd.Flags().Bool(flagRepair, false, "write the values in memiavl into versiondb at the version to fix the inconsistencies")
+	cmd.Flags().Bool(flagQuick, false, "only compare the hashes of the key-value pairs in both dbs, don't report the individual keys")
+	return cmd
+}
+
+// CheckStore compares the key-value pairs of a store in versiondb at the version with the memiavl tree at the same
+// version, calls fn for each inconsistent key in order, value is the one in memiavl, vdbValue is the one in versiondb.
+// The slices passed to fn are only valid until it returns.
+func CheckStore(
+	versionDB versiondb.VersionStore, store string, version int64, tree *memiavl.Tree,
+	fn func(typ CheckIssueType, key, value, vdbValue []byte) error,
+) error {
+	vdbIter, err := versionDB.IteratorAtVersion(store, nil, nil, &version)
+	if err != nil {
+		return err
+	}
+	defer vdbIter.Close()
+
+	treeIter := tree.Iterator(nil, nil, true)
+	defer treeIter.Close()
+
+	for vdbIter.Valid() || treeIter.Valid() {
+		var cmp int
+		switch {
+		case !vdbIter.Valid():
+			cmp = 1
+		case !treeIter.Valid():
+			cmp = -1
+		default:
+			cmp = bytes.Compare(vdbIter.Key(), treeIter.Key())
+		}
+
+		switch {
+		case cmp < 0:
+			if err := fn(CheckExtra, vdbIter.Key(), nil, vdbIter.Value()); err != nil {
+				return err
+			}
+			vdbIter.Next()
+		case cmp > 0:
+			if err := fn(CheckMissing, treeIter.Key(), treeIter.Value(), nil); err != nil {
+				return err
+			}
+			treeIter.Next()
+		default:
+			if !bytes.Equal(vdbIter.Value(), treeIter.Value()) {
+				if err := fn(CheckMismatch, treeIter.Key(), treeIter.Value(), vdbIter.Value()); err != nil {
+					return err
+				}
+			}
+			vdbIter.Next()
+			treeIter.Next()
+		}
+	}
+
+	return errors.Join(vdbIter.Error(), treeIter.Error())
+}
+
+// HashStore hashes the key-value pairs of a store in versiondb and the memiavl tree concurrently, the hashes are
+// equal if the contents are the same, it's faster than `CheckStore` but don't tell the inconsistent keys.
+func HashStore(versionDB versiondb.VersionStore, store string, version int64, tree *memiavl.Tree) ([]byte, []byte, error) {
+	vdbIter, err := versionDB.IteratorAtVersion(store, nil, nil, &version)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer vdbIter.Close()
+
+	treeIter := tree.Iterator(nil, nil, true)
+	defer treeIter.Close()
+
+	ch := make(chan []byte, 1)
+	go func() {
+		ch <- hashIterator(treeIter)
+	}()
+
+	vdbHash := hashIterator(vdbIter)
+	treeHash := <-ch
+	if err := errors.Join(vdbIter.Error(), treeIter.Error()); err != nil {
+		return nil, nil, err
+	}
+	return vdbHash, treeHash, nil
+}
+
+// hashIterator hashes the length prefixed keys and values in iterator.
+func hashIterator(it types.Iterator) []byte {
+	h := sha256.New()
+	for ; it.Valid(); it.Next() {
+		writeLengthPrefixed(h, it.Key())
+		writeLengthPrefixed(h, it.Value())
+	}
+	return h.Sum(nil)
+}
+
+func writeLengthPrefixed(h hash.Hash, bz []byte) {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], uint64(len(bz)))
+	h.Write(buf[:n])
+	h.Write(bz)
+}
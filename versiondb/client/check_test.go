@@ -0,0 +1,99 @@
+package client
+
+import (
+	"testing"
+
+	"cosmossdk.io/store/types"
+	"github.com/cosmos/iavl"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crypto-org-chain/cronos/memiavl"
+	"github.com/crypto-org-chain/cronos/versiondb/tsleveldb"
+)
+
+type checkIssue struct {
+	typ             CheckIssueType
+	key, value, vdb string
+}
+
+func collectIssues(t *testing.T, store tsleveldb.Store, version int64, tree *memiavl.Tree) []checkIssue {
+	var issues []checkIssue
+	require.NoError(t, CheckStore(store, "test", version, tree, func(typ CheckIssueType, key, value, vdbValue []byte) error {
+		issues = append(issues, checkIssue{typ, string(key), string(value), string(vdbValue)})
+		return nil
+	}))
+	return issues
+}
+
+func TestCheckStore(t *testing.T) {
+	store, err := tsleveldb.NewStore(t.TempDir())
+	require.NoError(t, err)
+	defer store.Close()
+
+	tree := memiavl.New(0)
+	for i, cs := range ChangeSets {
+		version := int64(i + 1)
+		tree.ApplyChangeSet(convertChangeSet(cs))
+		_, _, err := tree.SaveVersion(true)
+		require.NoError(t, err)
+
+		pairs := make([]*types.StoreKVPair, len(cs.Pairs))
+		for j, pair := range cs.Pairs {
+			pairs[j] = &types.StoreKVPair{StoreKey: "test", Key: pair.Key, Value: pair.Value, Delete: pair.Delete}
+		}
+		require.NoError(t, store.PutAtVersion(version, pairs))
+	}
+	version := tree.Version()
+
+	require.Empty(t, collectIssues(t, store, version, tree))
+	vdbHash, treeHash, err := HashStore(store, "test", version, tree)
+	require.NoError(t, err)
+	require.Equal(t, treeHash, vdbHash)
+
+	// corrupt the latest version of versiondb
+	var existing []string
+	for it := tree.Iterator(nil, nil, true); it.Valid(); it.Next() {
+		existing = append(existing, string(it.Key()))
+	}
+	require.GreaterOrEqual(t, len(existing), 2)
+	require.NoError(t, store.FeedChangeSet(version, "test", &iavl.ChangeSet{Pairs: []*iavl.KVPair{
+		{Key: []byte(existing[0]), Delete: true},
+		{Key: []byte(existing[1]), Value: []byte("corrupted")},
+		{Key: []byte("zzz-extra"), Value: []byte("extra")},
+	}}))
+
+	issues := collectIssues(t, store, version, tree)
+	require.Equal(t, []checkIssue{
+		{CheckMissing, existing[0], string(tree.Get([]byte(existing[0]))), ""},
+		{CheckMismatch, existing[1], string(tree.Get([]byte(existing[1]))), "corrupted"},
+		{CheckExtra, "zzz-extra", "", "extra"},
+	}, issues)
+	vdbHash, treeHash, err = HashStore(store, "test", version, tree)
+	require.NoError(t, err)
+	require.NotEqual(t, treeHash, vdbHash)
+
+	// the previous version is not affected
+	prevTree := memiavl.New(0)
+	for _, cs := range ChangeSets[:len(ChangeSets)-1] {
+		prevTree.ApplyChangeSet(convertChangeSet(cs))
+		_, _, err := prevTree.SaveVersion(true)
+		require.NoError(t, err)
+	}
+	require.Empty(t, collectIssues(t, store, version-1, prevTree))
+
+	// repair with the values in memiavl
+	var fixes iavl.ChangeSet
+	for _, issue := range issues {
+		if issue.typ == CheckExtra {
+			fixes.Pairs = append(fixes.Pairs, &iavl.KVPair{Key: []byte(issue.key), Delete: true})
+		} else {
+			fixes.Pairs = append(fixes.Pairs, &iavl.KVPair{Key: []byte(issue.key), Value: []byte(issue.value)})
+		}
+	}
+	require.NoError(t, store.FeedChangeSet(version, "test", &fixes))
+	require.Empty(t, collectIssues(t, store, version, tree))
+
+	latest, err := store.GetLatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, version, latest)
+}
@@ -2,15 +2,15 @@ package client
 
 import (
 	dbm "github.com/cosmos/cosmos-db"
-	"github.com/linxGnu/grocksdb"
 	"github.com/spf13/cobra"
 )
 
 // Options defines the customizable settings of ChangeSetGroupCmd
 type Options struct {
-	DefaultStores     []string
-	OpenReadOnlyDB    func(home string, backend dbm.BackendType) (dbm.DB, error)
-	AppRocksDBOptions func(sstFileWriter bool) *grocksdb.Options
+	DefaultStores  []string
+	OpenReadOnlyDB func(home string, backend dbm.BackendType) (dbm.DB, error)
+	// the settings of the commands only available in the binaries built with the `rocksdb` tag
+	RocksDBOptions
 }
 
 func ChangeSetGroupCmd(opts Options) *cobra.Command {
@@ -23,12 +23,12 @@ func ChangeSetGroupCmd(opts Options) *cobra.Command {
 		DumpChangeSetCmd(opts),
 		PrintChangeSetCmd(),
 		VerifyChangeSetCmd(opts.DefaultStores),
-		BuildVersionDBSSTCmd(opts.DefaultStores),
-		IngestVersionDBSSTCmd(),
-		ChangeSetToVersionDBCmd(),
-		RestoreAppDBCmd(opts),
-		RestoreVersionDBCmd(),
-		FixDataCmd(opts.DefaultStores),
+		DiffCmd(),
+		PruneVersionDBCmd(),
+		CheckVersionDBCmd(opts.DefaultStores),
+		RepackChangeSetCmd(),
+		DumpWALCmd(),
 	)
+	cmd.AddCommand(rocksDBCmds(opts)...)
 	return cmd
 }
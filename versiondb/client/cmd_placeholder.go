@@ -0,0 +1,23 @@
+//go:build !rocksdb
+// +build !rocksdb
+
+package client
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/crypto-org-chain/cronos/versiondb"
+)
+
+// RocksDBOptions is empty when built without rocksdb
+type RocksDBOptions struct{}
+
+func rocksDBCmds(Options) []*cobra.Command {
+	return nil
+}
+
+func openRocksDBVersionStore(string) (versiondb.VersionStore, func() error, error) {
+	return nil, nil, errors.New("rocksdb versiondb is not supported, build with the `rocksdb` tag")
+}
@@ -0,0 +1,45 @@
+//go:build rocksdb
+// +build rocksdb
+
+package client
+
+import (
+	"github.com/linxGnu/grocksdb"
+	"github.com/spf13/cobra"
+
+	"github.com/crypto-org-chain/cronos/versiondb"
+	"github.com/crypto-org-chain/cronos/versiondb/tsrocksdb"
+)
+
+// RocksDBOptions defines the settings of the commands that depend on rocksdb
+type RocksDBOptions struct {
+	AppRocksDBOptions func(sstFileWriter bool) *grocksdb.Options
+}
+
+// rocksDBCmds returns the commands that work on the rocksdb versiondb or app db directly
+func rocksDBCmds(opts Options) []*cobra.Command {
+	return []*cobra.Command{
+		BuildVersionDBSSTCmd(opts.DefaultStores),
+		IngestVersionDBSSTCmd(),
+		ChangeSetToVersionDBCmd(),
+		RestoreAppDBCmd(opts),
+		RestoreVersionDBCmd(),
+		FixDataCmd(opts.DefaultStores),
+	}
+}
+
+func openRocksDBVersionStore(dir string) (versiondb.VersionStore, func() error, error) {
+	db, cfHandle, err := tsrocksdb.OpenVersionDB(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	store, err := tsrocksdb.NewStoreWithDB(db, cfHandle)
+	if err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+	return store, func() error {
+		db.Close()
+		return nil
+	}, nil
+}
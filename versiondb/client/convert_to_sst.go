@@ -1,3 +1,6 @@
+//go:build rocksdb
+// +build rocksdb
+
 package client
 
 import (
@@ -175,26 +178,6 @@ func convertSingleStore(store string, changeSetDir, sstDir string, sstFileSize u
 	return sstWriter.Finish()
 }
 
-// scanChangeSetFiles find change set files from the directory and sort them by the first version included, filter out
-// empty files.
-func scanChangeSetFiles(changeSetDir, store string) ([]FileWithVersion, error) {
-	// scan directory to find the change set files
-	storeDir := filepath.Join(changeSetDir, store)
-	entries, err := os.ReadDir(storeDir)
-	if err != nil {
-		// assume the change set files are taken from older versions, don't include all stores.
-		if os.IsNotExist(err) {
-			return nil, nil
-		}
-		return nil, err
-	}
-	fileNames := make([]string, len(entries))
-	for i, entry := range entries {
-		fileNames[i] = filepath.Join(storeDir, entry.Name())
-	}
-	return SortFilesByFirstVerson(fileNames)
-}
-
 // sstFileName inserts the seq integer into the base file name
 func sstFileName(store string, seq int) string {
 	return fmt.Sprintf("%s-%d%s", store, seq, SSTFileExtension)
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/crypto-org-chain/cronos/memiavl"
+)
+
+// diffEntry is the json line outputted by the diff command.
+type diffEntry struct {
+	Store string `json:"store"`
+	Type  string `json:"type"`
+	Key   []byte `json:"key"`
+	Value []byte `json:"value,omitempty"`
+}
+
+func DiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff memiavl-dir from-version to-version",
+		Short: "Output the key-value pairs changed between two versions of a memiavl db (e.g. data/memiavl.db) as json lines, the unchanged subtrees are skipped, it's useful to audit the state changes across upgrades",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) (returnErr error) {
+			fromVersion, err := strconv.ParseUint(args[1], 10, 32)
+			if err != nil {
+				return err
+			}
+			toVersion, err := strconv.ParseUint(args[2], 10, 32)
+			if err != nil {
+				return err
+			}
+			// diff all the stores by default
+			stores, err := GetStoresOrDefault(cmd, nil)
+			if err != nil {
+				return err
+			}
+
+			from, err := memiavl.Load(args[0], memiavl.Options{TargetVersion: uint32(fromVersion), ReadOnly: true, ZeroCopy: true})
+			if err != nil {
+				return fmt.Errorf("load version %d failed: %w", fromVersion, err)
+			}
+			defer func() {
+				returnErr = errors.Join(returnErr, from.Close())
+			}()
+			to, err := memiavl.Load(args[0], memiavl.Options{TargetVersion: uint32(toVersion), ReadOnly: true, ZeroCopy: true})
+			if err != nil {
+				return fmt.Errorf("load version %d failed: %w", toVersion, err)
+			}
+			defer func() {
+				returnErr = errors.Join(returnErr, to.Close())
+			}()
+
+			output := func(store string, typ memiavl.DiffType, pair *memiavl.KVPair) bool {
+				var bz []byte
+				bz, returnErr = json.Marshal(diffEntry{Store: store, Type: typ.String(), Key: pair.Key, Value: pair.Value})
+				if returnErr != nil {
+					return false
+				}
+				_, returnErr = fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+				return returnErr == nil
+			}
+
+			if len(stores) == 0 {
+				from.MultiTree.Diff(&to.MultiTree, output)
+				return returnErr
+			}
+
+			for _, store := range stores {
+				fromTree, toTree := from.TreeByName(store), to.TreeByName(store)
+				if fromTree == nil || toTree == nil {
+					return fmt.Errorf("store %s doesn't exist in both versions", store)
+				}
+				fromTree.Diff(toTree, nil, nil, func(typ memiavl.DiffType, pair *memiavl.KVPair) bool {
+					return output(store, typ, pair)
+				})
+				if returnErr != nil {
+					return returnErr
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().String(flagStores, "", "list of store names, default to all the stores")
+	return cmd
+}
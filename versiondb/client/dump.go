@@ -5,6 +5,7 @@ import (
 	"compress/zlib"
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -18,6 +19,7 @@ import (
 	"github.com/alitto/pond"
 	dbm "github.com/cosmos/cosmos-db"
 	"github.com/cosmos/iavl"
+	"github.com/cosmos/iavl/keyformat"
 	"github.com/golang/snappy"
 	"github.com/spf13/cast"
 	"github.com/spf13/cobra"
@@ -26,10 +28,23 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/server"
 
-	"github.com/crypto-org-chain/cronos/versiondb/tsrocksdb"
+	"github.com/crypto-org-chain/cronos/memiavl"
 )
 
-const DefaultChunkSize = 1000000
+const (
+	DefaultChunkSize = 1000000
+
+	int64Size = 8
+	int32Size = 4
+
+	storeKeyPrefix = "s/k:%s/"
+)
+
+var (
+	nodeKeyFormat   = keyformat.NewKeyFormat('n', memiavl.SizeHash)              // n<hash>
+	rootKeyFormat   = keyformat.NewKeyFormat('r', int64Size)                     // r<version>
+	nodeKeyV1Format = keyformat.NewFastPrefixFormatter('s', int64Size+int32Size) // s<version><nonce>
+)
 
 func DumpChangeSetCmd(opts Options) *cobra.Command {
 	cmd := &cobra.Command{
@@ -69,6 +84,13 @@ func DumpChangeSetCmd(opts Options) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			format, err := cmd.Flags().GetString(flagFormat)
+			if err != nil {
+				return err
+			}
+			if format != ChangeSetFormatV1 && format != ChangeSetFormatV2 {
+				return fmt.Errorf("unknown change set format: %s", format)
+			}
 			stores, err := GetStoresOrDefault(cmd, opts.DefaultStores)
 			if err != nil {
 				return err
@@ -84,7 +106,7 @@ func DumpChangeSetCmd(opts Options) *cobra.Command {
 
 			if endVersion == 0 {
 				// use the latest version of the first store for all stores
-				prefix := []byte(fmt.Sprintf(tsrocksdb.StorePrefixTpl, stores[0]))
+				prefix := []byte(fmt.Sprintf(storeKeyPrefix, stores[0]))
 				tree := iavl.NewMutableTree(wrapper.NewDBWrapper((dbm.NewPrefixDB(db, prefix))), 0, true, log.NewNopLogger())
 				latestVersion, err := tree.LoadVersion(0)
 				if err != nil {
@@ -103,7 +125,7 @@ func DumpChangeSetCmd(opts Options) *cobra.Command {
 				fmt.Println("begin store", store, time.Now().Format(time.RFC3339))
 
 				// find the first version in the db, reading raw db because no public api for it.
-				prefix := []byte(fmt.Sprintf(tsrocksdb.StorePrefixTpl, store))
+				prefix := []byte(fmt.Sprintf(storeKeyPrefix, store))
 				storeStartVersion, err := getFirstVersion(dbm.NewPrefixDB(db, prefix), iavlVersion)
 				if err != nil {
 					return err
@@ -156,7 +178,7 @@ func DumpChangeSetCmd(opts Options) *cobra.Command {
 
 				// for each chunk, wait for related tasks to finish, and concatenate the result files in order
 				for _, chunk := range chunks {
-					if err := chunk.collect(outDir, zlibLevel); err != nil {
+					if err := chunk.collect(outDir, zlibLevel, format); err != nil {
 						return err
 					}
 				}
@@ -173,6 +195,7 @@ func DumpChangeSetCmd(opts Options) *cobra.Command {
 	cmd.Flags().Int(flagZlibLevel, 6, "level of zlib compression, 0: plain data, 1: fast, 9: best, default: 6, if not 0 the output file name will have .zz extension")
 	cmd.Flags().String(flagStores, "", "list of store names, default to the current store list in application")
 	cmd.Flags().Int(flagIAVLVersion, IAVLV1, "IAVL version, 0: v0, 1: v1")
+	cmd.Flags().String(flagFormat, ChangeSetFormatV1, "format of the output files, v1: plain stream, v2: indexed with zstd compressed blocks, zlib-level is ignored")
 	return cmd
 }
 
@@ -225,13 +248,16 @@ type chunk struct {
 }
 
 // collect wait for the tasks to complete and concatenate the files into a single output file.
-func (c *chunk) collect(outDir string, zlibLevel int) (returnErr error) {
+func (c *chunk) collect(outDir string, zlibLevel int, format string) (returnErr error) {
 	storeDir := filepath.Join(outDir, c.store)
 	if err := os.MkdirAll(storeDir, os.ModePerm); err != nil {
 		return err
 	}
 
 	output := filepath.Join(storeDir, fmt.Sprintf("block-%d", c.beginVersion))
+	if format == ChangeSetFormatV2 {
+		return c.collectV2(output)
+	}
 	if zlibLevel > 0 {
 		output += ZlibFileSuffix
 	}
@@ -282,6 +308,61 @@ func (c *chunk) collect(outDir string, zlibLevel int) (returnErr error) {
 	return bufWriter.Flush()
 }
 
+// collectV2 is like `collect`, but re-encodes the change sets in the temporary files into a v2 file.
+func (c *chunk) collectV2(output string) (returnErr error) {
+	if err := c.taskGroup.Wait(); err != nil {
+		return err
+	}
+
+	fp, err := createFile(output)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := fp.Close(); returnErr == nil {
+			returnErr = err
+		}
+	}()
+
+	bufWriter := bufio.NewWriter(fp)
+	writer, err := NewChangeSetWriterV2(bufWriter, c.store, CompressionZstd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// release the encoder even if failed
+		err := writer.Close()
+		if returnErr == nil {
+			returnErr = errors.Join(err, bufWriter.Flush())
+		}
+	}()
+
+	for _, taskFile := range c.taskFiles {
+		if err := withSnappyFile(taskFile, func(reader Reader) error {
+			_, err := IterateChangeSets(reader, func(version int64, changeSet *iavl.ChangeSet) (bool, error) {
+				return true, writer.WriteChangeSet(version, changeSet)
+			})
+			return err
+		}); err != nil {
+			return err
+		}
+		if err := os.Remove(taskFile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withSnappyFile opens the snappy compressed temporary file and pass the reader to callback.
+func withSnappyFile(fileName string, fn func(Reader) error) error {
+	fp, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+	return fn(snappy.NewReader(fp))
+}
+
 // copyTmpFile append the snappy compressed temporary file to writer
 func copyTmpFile(writer io.Writer, tmpFile string) error {
 	fp, err := os.Open(tmpFile)
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/crypto-org-chain/cronos/memiavl"
+)
+
+const flagCompression = "compression"
+
+func DumpWALCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dump-wal memiavl-path out-dir",
+		Short: "Extract changesets from the write-ahead-log of memiavl db, for the nodes don't have the iavl db",
+		Long: `Extract changesets from the write-ahead-log of memiavl db, the files are written in the same layout as the dump command
+(<out-dir>/<store>/block-<version>), together with a manifest.json recording the store upgrades, only the versions remaining in the wal can be dumped.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			startVersion, err := cmd.Flags().GetInt64(flagStartVersion)
+			if err != nil {
+				return err
+			}
+			endVersion, err := cmd.Flags().GetInt64(flagEndVersion)
+			if err != nil {
+				return err
+			}
+			compression, err := cmd.Flags().GetString(flagCompression)
+			if err != nil {
+				return err
+			}
+			chunkSize, err := cmd.Flags().GetInt64(flagChunkSize)
+			if err != nil {
+				return err
+			}
+			if endVersion > 0 {
+				if endVersion <= max(startVersion, 1) {
+					return fmt.Errorf("empty version range: [%d, %d)", startVersion, endVersion)
+				}
+				// the end version of the wal dump is inclusive
+				endVersion--
+			}
+
+			manifest, err := memiavl.DumpWAL(args[0], startVersion, endVersion, args[1], compression, chunkSize)
+			if err != nil {
+				return err
+			}
+			for _, file := range manifest.Files {
+				cmd.Printf("%s: [%d, %d]\n", file.File, file.StartVersion, file.EndVersion)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().Int64(flagStartVersion, 0, "The start version, default to the first version in the wal")
+	cmd.Flags().Int64(flagEndVersion, 0, "The end version, exclusive, default to the last version in the wal + 1")
+	cmd.Flags().String(flagCompression, memiavl.WALArchiveCompressionZlib, "compression of the change set files, none, zlib or zstd")
+	cmd.Flags().Int64(flagChunkSize, DefaultChunkSize, "max number of blocks in each change set file, 0 means no limit")
+	return cmd
+}
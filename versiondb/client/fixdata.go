@@ -1,3 +1,6 @@
+//go:build rocksdb
+// +build rocksdb
+
 package client
 
 import (
@@ -44,7 +47,10 @@ func FixDataCmd(defaultStores []string) *cobra.Command {
 				return err
 			}
 
-			versionDB := tsrocksdb.NewStoreWithDB(db, cfHandle)
+			versionDB, err := tsrocksdb.NewStoreWithDB(db, cfHandle)
+			if err != nil {
+				return err
+			}
 			if err := versionDB.FixData(stores, dryRun); err != nil {
 				return err
 			}
@@ -1,3 +1,6 @@
+//go:build rocksdb
+// +build rocksdb
+
 package client
 
 import (
@@ -39,7 +42,10 @@ func IngestVersionDBSSTCmd() *cobra.Command {
 			}
 			if maxVersion > 0 {
 				// update latest version
-				store := tsrocksdb.NewStoreWithDB(db, cfHandle)
+				store, err := tsrocksdb.NewStoreWithDB(db, cfHandle)
+				if err != nil {
+					return err
+				}
 				latestVersion, err := store.GetLatestVersion()
 				if err != nil {
 					return err
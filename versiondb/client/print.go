@@ -28,7 +28,7 @@ func PrintChangeSetCmd() *cobra.Command {
 				return err
 			}
 
-			return withChangeSetFile(args[0], func(reader Reader) error {
+			return withChangeSetFileAt(args[0], startVersion, func(reader Reader) error {
 				if noParseChangeset {
 					// print the version numbers only
 					_, err := IterateVersions(reader, func(version int64) (bool, error) {
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/crypto-org-chain/cronos/versiondb"
+	"github.com/crypto-org-chain/cronos/versiondb/tsleveldb"
+)
+
+const (
+	flagBefore  = "before"
+	flagBackend = "backend"
+)
+
+func PruneVersionDBCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune-versiondb versiondb-path",
+		Short: "Garbage-collect the versions older than the target height in versiondb and compact the db, the newest value not newer than the height is preserved for each key, the queries before the height won't be supported anymore",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (returnErr error) {
+			before, err := cmd.Flags().GetInt64(flagBefore)
+			if err != nil {
+				return err
+			}
+			if before <= 0 {
+				return errors.New("--before is required")
+			}
+			// prune all the stores by default
+			stores, err := GetStoresOrDefault(cmd, nil)
+			if err != nil {
+				return err
+			}
+			backend, err := cmd.Flags().GetString(flagBackend)
+			if err != nil {
+				return err
+			}
+
+			versionDB, closer, err := openVersionStore(args[0], backend)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				returnErr = errors.Join(returnErr, closer())
+			}()
+			pruner, ok := versionDB.(versiondb.HistoryPruner)
+			if !ok {
+				return fmt.Errorf("versiondb backend %s don't support pruning", backend)
+			}
+
+			return pruner.PruneBefore(before, stores, true)
+		},
+	}
+	cmd.Flags().Int64(flagBefore, 0, "the versions older than this height are pruned")
+	cmd.Flags().String(flagStores, "", "list of store names to prune, default to all the stores")
+	cmd.Flags().String(flagBackend, "rocksdb", "the db backend of versiondb, rocksdb or goleveldb")
+	return cmd
+}
+
+// openVersionStore opens the versiondb with the backend, returns the function to close it.
+func openVersionStore(dir, backend string) (versiondb.VersionStore, func() error, error) {
+	switch backend {
+	case "rocksdb":
+		return openRocksDBVersionStore(dir)
+	case "goleveldb":
+		store, err := tsleveldb.NewStore(dir)
+		if err != nil {
+			return nil, nil, err
+		}
+		return store, store.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown versiondb backend: %s", backend)
+	}
+}
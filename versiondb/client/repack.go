@@ -0,0 +1,297 @@
+package client
+
+import (
+	"bufio"
+	"compress/zlib"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cosmos/iavl"
+	"github.com/spf13/cobra"
+)
+
+// RepackOptions defines the output of `Repack`.
+type RepackOptions struct {
+	// the output files are aligned to multiples of the chunk size
+	ChunkSize int64
+	// zlib level of the v1 files, 0 means no compression
+	ZlibLevel int
+	// the versions out of `[StartVersion, EndVersion)` are dropped, 0 end version means no end
+	StartVersion, EndVersion int64
+	// ChangeSetFormatV1 or ChangeSetFormatV2
+	Format string
+	// the store name written in the header of v2 files
+	Store string
+}
+
+// RepackResult summarizes the repacked change sets.
+type RepackResult struct {
+	FirstVersion, LastVersion int64
+	Versions                  int64
+	// the versions found in more than one input file, they are verified to be identical
+	Duplicates int64
+	Files      []string
+}
+
+func RepackChangeSetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "repack in-dir out-dir",
+		Short: "Merge, split and re-chunk the change set files of a store into a normalized set",
+		Long: `Merge the change set files of a store in in-dir, the overlapping versions are verified to be identical,
+then split them into files aligned to the chunk size in out-dir, the versions out of the range are dropped.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				opts RepackOptions
+				err  error
+			)
+			if opts.ChunkSize, err = cmd.Flags().GetInt64(flagChunkSize); err != nil {
+				return err
+			}
+			if opts.ZlibLevel, err = cmd.Flags().GetInt(flagZlibLevel); err != nil {
+				return err
+			}
+			if opts.StartVersion, err = cmd.Flags().GetInt64(flagStartVersion); err != nil {
+				return err
+			}
+			if opts.EndVersion, err = cmd.Flags().GetInt64(flagEndVersion); err != nil {
+				return err
+			}
+			if opts.Format, err = cmd.Flags().GetString(flagFormat); err != nil {
+				return err
+			}
+			if opts.Store, err = cmd.Flags().GetString(flagStore); err != nil {
+				return err
+			}
+			if len(opts.Store) == 0 {
+				opts.Store = filepath.Base(filepath.Clean(args[0]))
+			}
+
+			res, err := Repack(args[0], args[1], opts)
+			if err != nil {
+				return err
+			}
+			cmd.Printf("repacked %d versions in [%d, %d] into %d files, %d duplicated versions verified\n",
+				res.Versions, res.FirstVersion, res.LastVersion, len(res.Files), res.Duplicates)
+			return nil
+		},
+	}
+	cmd.Flags().Int64(flagChunkSize, DefaultChunkSize, "size of the block chunk, the output files are aligned to multiples of it")
+	cmd.Flags().Int(flagZlibLevel, 6, "level of zlib compression, 0: plain data, 1: fast, 9: best, default: 6, if not 0 the output file name will have .zz extension")
+	cmd.Flags().Int64(flagStartVersion, 0, "The versions before it are dropped")
+	cmd.Flags().Int64(flagEndVersion, 0, "The versions at or after it are dropped, 0 means no end")
+	cmd.Flags().String(flagFormat, ChangeSetFormatV1, "format of the output files, v1: plain stream, v2: indexed with zstd compressed blocks, zlib-level is ignored")
+	cmd.Flags().String(flagStore, "", "store name written in the header of v2 files, default to the base name of in-dir")
+	return cmd
+}
+
+// Repack reads the change set files in inDir in version order, and writes them into the files aligned to the chunk
+// size in outDir. The versions contained in multiple input files are verified to be identical, it fails if a version
+// is found after a newer one is written already, which means the input files interleave with gaps.
+func Repack(inDir, outDir string, opts RepackOptions) (res RepackResult, returnErr error) {
+	if opts.ChunkSize <= 0 {
+		return res, fmt.Errorf("invalid chunk size: %d", opts.ChunkSize)
+	}
+	if opts.Format != ChangeSetFormatV1 && opts.Format != ChangeSetFormatV2 {
+		return res, fmt.Errorf("unknown change set format: %s", opts.Format)
+	}
+	absIn, err := filepath.Abs(inDir)
+	if err != nil {
+		return res, err
+	}
+	absOut, err := filepath.Abs(outDir)
+	if err != nil {
+		return res, err
+	}
+	if absIn == absOut {
+		return res, errors.New("the output directory must be different from the input one")
+	}
+
+	files, err := scanChangeSetDir(inDir)
+	if err != nil {
+		return res, err
+	}
+	if err := os.MkdirAll(outDir, os.ModePerm); err != nil {
+		return res, err
+	}
+
+	var (
+		writer     *chunkFileWriter
+		chunkBegin int64
+		last       int64 = -1
+		// the hashes of the written versions which could be overlapped by the remaining files
+		hashes = make(map[int64][sha256.Size]byte)
+	)
+	defer func() {
+		switch {
+		case writer == nil:
+		case returnErr != nil:
+			returnErr = errors.Join(returnErr, writer.abort())
+		default:
+			returnErr = writer.Close()
+		}
+	}()
+
+	for i, file := range files {
+		// the remaining files start from the first version of current file
+		for version := range hashes {
+			if version < int64(file.Version) {
+				delete(hashes, version)
+			}
+		}
+		var nextFileVersion int64 = -1
+		if i+1 < len(files) {
+			nextFileVersion = int64(files[i+1].Version)
+		}
+
+		if err := withChangeSetFileAt(file.FileName, opts.StartVersion, func(reader Reader) error {
+			_, err := IterateChangeSets(reader, func(version int64, changeSet *iavl.ChangeSet) (bool, error) {
+				if version < opts.StartVersion {
+					return true, nil
+				}
+				if opts.EndVersion > 0 && version >= opts.EndVersion {
+					return false, nil
+				}
+
+				if version <= last {
+					expected, ok := hashes[version]
+					if !ok {
+						return false, fmt.Errorf("version %d in %s is older than the written version %d", version, file.FileName, last)
+					}
+					hash, err := hashChangeSet(version, changeSet)
+					if err != nil {
+						return false, err
+					}
+					if hash != expected {
+						return false, fmt.Errorf("conflicting change sets of version %d in %s", version, file.FileName)
+					}
+					res.Duplicates++
+					return true, nil
+				}
+
+				if begin := version / opts.ChunkSize * opts.ChunkSize; writer == nil || begin != chunkBegin {
+					if writer != nil {
+						err := writer.Close()
+						writer = nil
+						if err != nil {
+							return false, err
+						}
+					}
+					name := filepath.Join(outDir, fmt.Sprintf("block-%d", begin))
+					if opts.Format == ChangeSetFormatV1 && opts.ZlibLevel > 0 {
+						name += ZlibFileSuffix
+					}
+					if writer, err = newChunkFileWriter(name, opts); err != nil {
+						return false, err
+					}
+					chunkBegin = begin
+					res.Files = append(res.Files, name)
+				}
+				if err := writer.WriteChangeSet(version, changeSet); err != nil {
+					return false, err
+				}
+
+				if nextFileVersion >= 0 && version >= nextFileVersion {
+					hash, err := hashChangeSet(version, changeSet)
+					if err != nil {
+						return false, err
+					}
+					hashes[version] = hash
+				}
+				if res.Versions == 0 {
+					res.FirstVersion = version
+				}
+				res.LastVersion = version
+				res.Versions++
+				last = version
+				return true, nil
+			})
+			return err
+		}); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// hashChangeSet hashes the encoding of the change set, to compare the duplicated versions.
+func hashChangeSet(version int64, changeSet *iavl.ChangeSet) ([sha256.Size]byte, error) {
+	var hash [sha256.Size]byte
+	hasher := sha256.New()
+	if err := WriteChangeSet(hasher, version, changeSet); err != nil {
+		return hash, err
+	}
+	hasher.Sum(hash[:0])
+	return hash, nil
+}
+
+// chunkFileWriter writes an output file of `Repack`, under a temporary name until it's closed.
+type chunkFileWriter struct {
+	name      string
+	fp        *os.File
+	bufWriter *bufio.Writer
+	zwriter   *zlib.Writer
+	v2        *ChangeSetWriterV2
+}
+
+func newChunkFileWriter(name string, opts RepackOptions) (*chunkFileWriter, error) {
+	fp, err := createFile(name + TmpFileSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &chunkFileWriter{name: name, fp: fp, bufWriter: bufio.NewWriter(fp)}
+	switch {
+	case opts.Format == ChangeSetFormatV2:
+		w.v2, err = NewChangeSetWriterV2(w.bufWriter, opts.Store, CompressionZstd)
+	case opts.ZlibLevel > 0:
+		w.zwriter, err = zlib.NewWriterLevel(w.bufWriter, opts.ZlibLevel)
+	}
+	if err != nil {
+		return nil, errors.Join(err, fp.Close())
+	}
+	return w, nil
+}
+
+func (w *chunkFileWriter) WriteChangeSet(version int64, changeSet *iavl.ChangeSet) error {
+	switch {
+	case w.v2 != nil:
+		return w.v2.WriteChangeSet(version, changeSet)
+	case w.zwriter != nil:
+		return WriteChangeSet(w.zwriter, version, changeSet)
+	default:
+		return WriteChangeSet(w.bufWriter, version, changeSet)
+	}
+}
+
+// Close finishes the file and renames it to the final name.
+func (w *chunkFileWriter) Close() error {
+	var err error
+	switch {
+	case w.v2 != nil:
+		err = w.v2.Close()
+	case w.zwriter != nil:
+		err = w.zwriter.Close()
+	}
+	if err == nil {
+		err = w.bufWriter.Flush()
+	}
+	if err != nil {
+		return errors.Join(err, w.fp.Close())
+	}
+	if err := w.fp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(w.name+TmpFileSuffix, w.name)
+}
+
+// abort closes and removes the unfinished file.
+func (w *chunkFileWriter) abort() error {
+	if w.v2 != nil {
+		_ = w.v2.closeEncoder()
+	}
+	return errors.Join(w.fp.Close(), os.Remove(w.name+TmpFileSuffix))
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"compress/zlib"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cosmos/iavl"
+	"github.com/stretchr/testify/require"
+)
+
+// writeV1File writes the versions of `ChangeSets` in the range `[begin, end]`.
+func writeV1File(t *testing.T, fileName string, begin, end int64, zlibLevel int) {
+	fp, err := os.Create(fileName)
+	require.NoError(t, err)
+	zwriter, err := zlib.NewWriterLevel(fp, zlibLevel)
+	require.NoError(t, err)
+	for version := begin; version <= end; version++ {
+		if zlibLevel > 0 {
+			require.NoError(t, WriteChangeSet(zwriter, version, ChangeSets[version-1]))
+		} else {
+			require.NoError(t, WriteChangeSet(fp, version, ChangeSets[version-1]))
+		}
+	}
+	if zlibLevel > 0 {
+		require.NoError(t, zwriter.Close())
+	}
+	require.NoError(t, fp.Close())
+}
+
+func readVersions(t *testing.T, dir string) map[string][]int64 {
+	files, err := scanChangeSetDir(dir)
+	require.NoError(t, err)
+	res := make(map[string][]int64)
+	for _, file := range files {
+		require.NoError(t, withChangeSetFile(file.FileName, func(reader Reader) error {
+			_, err := IterateChangeSets(reader, func(version int64, changeSet *iavl.ChangeSet) (bool, error) {
+				require.Equal(t, ChangeSets[version-1].Pairs, changeSet.Pairs)
+				res[filepath.Base(file.FileName)] = append(res[filepath.Base(file.FileName)], version)
+				return true, nil
+			})
+			return err
+		}))
+	}
+	return res
+}
+
+func TestRepack(t *testing.T) {
+	inDir := filepath.Join(t.TempDir(), "test")
+	require.NoError(t, os.MkdirAll(inDir, os.ModePerm))
+	writeV1File(t, filepath.Join(inDir, "block-1"), 1, 4, 0)
+	writeV1File(t, filepath.Join(inDir, "block-3.zz"), 3, 5, 6)
+	writeChangeSetFileV2(t, filepath.Join(inDir, "block-v2"), CompressionZstd, ChangeSets)
+	// the unfinished file of the sink is ignored
+	require.NoError(t, os.WriteFile(filepath.Join(inDir, "block-8"+TmpFileSuffix), []byte("garbage"), 0o600))
+
+	for _, format := range []string{ChangeSetFormatV1, ChangeSetFormatV2} {
+		t.Run(format, func(t *testing.T) {
+			outDir := t.TempDir()
+			res, err := Repack(inDir, outDir, RepackOptions{ChunkSize: 3, ZlibLevel: 6, Format: format, Store: "test"})
+			require.NoError(t, err)
+			require.Equal(t, int64(1), res.FirstVersion)
+			require.Equal(t, int64(7), res.LastVersion)
+			require.Equal(t, int64(7), res.Versions)
+			// 14 versions in the input files
+			require.Equal(t, int64(7), res.Duplicates)
+
+			suffix := ZlibFileSuffix
+			if format == ChangeSetFormatV2 {
+				suffix = ""
+				store, err := ReadChangeSetFileStore(filepath.Join(outDir, "block-0"))
+				require.NoError(t, err)
+				require.Equal(t, "test", store)
+			}
+			require.Equal(t, map[string][]int64{
+				"block-0" + suffix: {1, 2},
+				"block-3" + suffix: {3, 4, 5},
+				"block-6" + suffix: {6, 7},
+			}, readVersions(t, outDir))
+		})
+	}
+
+	// drop the versions out of range
+	outDir := t.TempDir()
+	_, err := Repack(inDir, outDir, RepackOptions{ChunkSize: 10, StartVersion: 2, EndVersion: 6, Format: ChangeSetFormatV1})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]int64{"block-0": {2, 3, 4, 5}}, readVersions(t, outDir))
+
+	_, err = Repack(inDir, inDir, RepackOptions{ChunkSize: 3, Format: ChangeSetFormatV1})
+	require.Error(t, err)
+}
+
+func TestRepackConflict(t *testing.T) {
+	inDir := t.TempDir()
+	writeV1File(t, filepath.Join(inDir, "block-1"), 1, 4, 0)
+
+	// a different change set of version 4
+	fp, err := os.Create(filepath.Join(inDir, "block-4"))
+	require.NoError(t, err)
+	require.NoError(t, WriteChangeSet(fp, 4, ChangeSets[0]))
+	require.NoError(t, fp.Close())
+
+	outDir := t.TempDir()
+	_, err = Repack(inDir, outDir, RepackOptions{ChunkSize: 3, Format: ChangeSetFormatV1})
+	require.ErrorContains(t, err, "conflicting change sets of version 4")
+
+	// the unfinished output is removed
+	entries, err := os.ReadDir(outDir)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		require.NotContains(t, entry.Name(), TmpFileSuffix)
+	}
+
+	// the versions of the files interleave
+	inDir = t.TempDir()
+	for name, versions := range map[string][]int64{"block-1": {1, 4}, "block-2": {2, 3}} {
+		fp, err := os.Create(filepath.Join(inDir, name))
+		require.NoError(t, err)
+		for _, version := range versions {
+			require.NoError(t, WriteChangeSet(fp, version, ChangeSets[version-1]))
+		}
+		require.NoError(t, fp.Close())
+	}
+	_, err = Repack(inDir, t.TempDir(), RepackOptions{ChunkSize: 3, Format: ChangeSetFormatV1})
+	require.ErrorContains(t, err, "version 2")
+}
@@ -1,3 +1,6 @@
+//go:build rocksdb
+// +build rocksdb
+
 package client
 
 import (
@@ -14,7 +17,6 @@ import (
 	"cosmossdk.io/errors"
 	"github.com/alitto/pond"
 	gogotypes "github.com/cosmos/gogoproto/types"
-	"github.com/cosmos/iavl/keyformat"
 	"github.com/linxGnu/grocksdb"
 	"github.com/spf13/cobra"
 
@@ -26,10 +28,6 @@ import (
 )
 
 const (
-	int64Size = 8
-	int32Size = 4
-
-	storeKeyPrefix   = "s/k:%s/"
 	latestVersionKey = "s/latest"
 	commitInfoKeyFmt = "s/%d" // s/<version>
 
@@ -41,12 +39,6 @@ const (
 	DefaultSorterChunkSizeIAVL = 64 * 1024 * 1024
 )
 
-var (
-	nodeKeyFormat   = keyformat.NewKeyFormat('n', memiavl.SizeHash)              // n<hash>
-	rootKeyFormat   = keyformat.NewKeyFormat('r', int64Size)                     // r<version>
-	nodeKeyV1Format = keyformat.NewFastPrefixFormatter('s', int64Size+int32Size) // s<version><nonce>
-)
-
 func RestoreAppDBCmd(opts Options) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "restore-app-db snapshot-dir application.db",
@@ -0,0 +1,371 @@
+package client
+
+import (
+	"bufio"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"cosmossdk.io/store/types"
+	"github.com/cosmos/iavl"
+
+	"github.com/crypto-org-chain/cronos/versiondb"
+)
+
+const (
+	// TmpFileSuffix is appended to the name of the change set file being written by the sink.
+	TmpFileSuffix = ".tmp"
+	// LatestVersionFileName is the file in the sink directory which records the last version written completely.
+	LatestVersionFileName = "latest-version"
+
+	recoverFileSuffix = ".recover"
+)
+
+var _ versiondb.ChangeSetSink = (*ChangeSetFileSink)(nil)
+
+// ChangeSetFileSink implements `versiondb.ChangeSetSink`, it writes the change sets into files in the same layout as
+// the `dump` command: `<dir>/<store>/block-<begin>[.zz]`, so the change set archive is produced continuously.
+//
+// A new file is started at the versions of multiples of `blocksPerFile`, and where the sink is restarted, the file
+// being written has the `.tmp` suffix, and is renamed after finished, the leftover temporary files of an unclean
+// shutdown are recovered on startup.
+//
+// The last version written completely is recorded in the `latest-version` file, the versions not newer than it are
+// skipped, so the files don't contain duplicated versions if the version store is rolled back, and a gap between it
+// and the version store fails the sink, the missing versions must be dumped with the `changeset dump` command.
+type ChangeSetFileSink struct {
+	dir           string
+	blocksPerFile int64
+	zlibLevel     int
+
+	// the first version of current files, 0 means not started.
+	beginVersion int64
+	// the last version written completely, 0 means nothing is written yet.
+	lastVersion int64
+	writers     map[string]*changeSetFileWriter
+}
+
+// NewChangeSetFileSink creates the sink, zlibLevel 0 means no compression, latestVersion is the latest version of the
+// version store, which is persisted before the change sets are passed to the sink.
+func NewChangeSetFileSink(dir string, blocksPerFile int64, zlibLevel int, latestVersion int64) (*ChangeSetFileSink, error) {
+	if blocksPerFile <= 0 {
+		return nil, fmt.Errorf("invalid blocks per file: %d", blocksPerFile)
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	lastVersion, err := readLastVersion(dir)
+	if err != nil {
+		return nil, err
+	}
+	// the versions after the record are written partially, they are dropped and written again.
+	recovered, err := recoverTmpFiles(dir, zlibLevel, lastVersion)
+	if err != nil {
+		return nil, err
+	}
+	if lastVersion == 0 {
+		// no record, fallback to the last version in the files
+		if lastVersion, err = lastFileVersion(dir); err != nil {
+			return nil, err
+		}
+		lastVersion = max(lastVersion, recovered)
+	}
+	if lastVersion > 0 && lastVersion < latestVersion {
+		return nil, fmt.Errorf(
+			"change set files end at version %d, behind the version store at %d, dump the missing versions first",
+			lastVersion, latestVersion,
+		)
+	}
+	return &ChangeSetFileSink{
+		dir:           dir,
+		blocksPerFile: blocksPerFile,
+		zlibLevel:     zlibLevel,
+		lastVersion:   lastVersion,
+		writers:       make(map[string]*changeSetFileWriter),
+	}, nil
+}
+
+// WriteChangeSet implements `versiondb.ChangeSetSink`.
+func (s *ChangeSetFileSink) WriteChangeSet(version int64, changeSet []*types.StoreKVPair) error {
+	if version <= s.lastVersion {
+		// written already
+		return nil
+	}
+	if s.lastVersion > 0 && version != s.lastVersion+1 {
+		return fmt.Errorf("change set files end at version %d, can't write version %d", s.lastVersion, version)
+	}
+
+	if s.beginVersion > 0 && version/s.blocksPerFile != s.beginVersion/s.blocksPerFile {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	if s.beginVersion == 0 {
+		s.beginVersion = version
+	}
+
+	// the pairs are ordered by store key, group them into the change sets of each store
+	for i := 0; i < len(changeSet); {
+		store := changeSet[i].StoreKey
+		var cs iavl.ChangeSet
+		for ; i < len(changeSet) && changeSet[i].StoreKey == store; i++ {
+			pair := changeSet[i]
+			cs.Pairs = append(cs.Pairs, &iavl.KVPair{Delete: pair.Delete, Key: pair.Key, Value: pair.Value})
+		}
+
+		writer, err := s.writer(store)
+		if err != nil {
+			return err
+		}
+		if err := writer.write(version, &cs); err != nil {
+			return err
+		}
+	}
+
+	if err := writeLastVersion(s.dir, version); err != nil {
+		return err
+	}
+	s.lastVersion = version
+	return nil
+}
+
+// Close finishes the current files.
+func (s *ChangeSetFileSink) Close() error {
+	return s.rotate()
+}
+
+func (s *ChangeSetFileSink) writer(store string) (*changeSetFileWriter, error) {
+	if w, ok := s.writers[store]; ok {
+		return w, nil
+	}
+
+	storeDir := filepath.Join(s.dir, store)
+	if err := os.MkdirAll(storeDir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	name := filepath.Join(storeDir, fmt.Sprintf("block-%d", s.beginVersion))
+	if s.zlibLevel > 0 {
+		name += ZlibFileSuffix
+	}
+	w, err := newChangeSetFileWriter(name, name+TmpFileSuffix, s.zlibLevel)
+	if err != nil {
+		return nil, err
+	}
+	s.writers[store] = w
+	return w, nil
+}
+
+// rotate finishes all the current files, the next change set will start new ones.
+func (s *ChangeSetFileSink) rotate() error {
+	errs := make([]error, 0, len(s.writers))
+	for _, w := range s.writers {
+		errs = append(errs, w.finish())
+	}
+	s.writers = make(map[string]*changeSetFileWriter)
+	s.beginVersion = 0
+	return errors.Join(errs...)
+}
+
+// changeSetFileWriter writes a change set file under a temporary name, and renames it when finished.
+type changeSetFileWriter struct {
+	name, tmpName string
+
+	fp        *os.File
+	bufWriter *bufio.Writer
+	zwriter   *zlib.Writer
+	writer    io.Writer
+}
+
+func newChangeSetFileWriter(name, tmpName string, zlibLevel int) (*changeSetFileWriter, error) {
+	fp, err := createFile(tmpName)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &changeSetFileWriter{name: name, tmpName: tmpName, fp: fp, bufWriter: bufio.NewWriter(fp)}
+	w.writer = w.bufWriter
+	if zlibLevel > 0 {
+		w.zwriter, err = zlib.NewWriterLevel(w.bufWriter, zlibLevel)
+		if err != nil {
+			return nil, errors.Join(err, fp.Close())
+		}
+		w.writer = w.zwriter
+	}
+	return w, nil
+}
+
+// write writes a version of change set, and flushes it to the file, so it can be recovered after an unclean
+// shutdown.
+func (w *changeSetFileWriter) write(version int64, cs *iavl.ChangeSet) error {
+	if err := WriteChangeSet(w.writer, version, cs); err != nil {
+		return err
+	}
+	if w.zwriter != nil {
+		if err := w.zwriter.Flush(); err != nil {
+			return err
+		}
+	}
+	return w.bufWriter.Flush()
+}
+
+// finish closes the file and renames it to the final name.
+func (w *changeSetFileWriter) finish() error {
+	if w.zwriter != nil {
+		if err := w.zwriter.Close(); err != nil {
+			return errors.Join(err, w.fp.Close())
+		}
+	}
+	if err := w.bufWriter.Flush(); err != nil {
+		return errors.Join(err, w.fp.Close())
+	}
+	if err := w.fp.Sync(); err != nil {
+		return errors.Join(err, w.fp.Close())
+	}
+	if err := w.fp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(w.tmpName, w.name)
+}
+
+// readLastVersion reads the last version written completely from the `latest-version` file, returns 0 if missing.
+func readLastVersion(dir string) (int64, error) {
+	bz, err := os.ReadFile(filepath.Join(dir, LatestVersionFileName))
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	version, err := strconv.ParseInt(strings.TrimSpace(string(bz)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s file: %w", LatestVersionFileName, err)
+	}
+	return version, nil
+}
+
+// lastFileVersion returns the last version in the finished change set files of all stores.
+func lastFileVersion(dir string) (int64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+	var lastVersion int64
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		files, err := scanChangeSetDir(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return 0, err
+		}
+		if len(files) == 0 {
+			continue
+		}
+		if err := withChangeSetFile(files[len(files)-1].FileName, func(reader Reader) error {
+			_, err := IterateChangeSets(reader, func(version int64, _ *iavl.ChangeSet) (bool, error) {
+				lastVersion = max(lastVersion, version)
+				return true, nil
+			})
+			return err
+		}); err != nil {
+			return 0, err
+		}
+	}
+	return lastVersion, nil
+}
+
+// writeLastVersion records the last version written completely, replaces the file atomically.
+func writeLastVersion(dir string, version int64) error {
+	name := filepath.Join(dir, LatestVersionFileName)
+	if err := os.WriteFile(name+TmpFileSuffix, []byte(strconv.FormatInt(version, 10)), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(name+TmpFileSuffix, name)
+}
+
+// recoverTmpFiles rewrites the complete change sets in the leftover temporary files into the final files, the ones
+// after `untilVersion` are dropped if it's not 0, returns the last version recovered.
+func recoverTmpFiles(dir string, zlibLevel int, untilVersion int64) (int64, error) {
+	// the partial output of an interrupted recovery, the temporary file is still there.
+	partials, err := filepath.Glob(filepath.Join(dir, "*", "*"+recoverFileSuffix))
+	if err != nil {
+		return 0, err
+	}
+	for _, name := range partials {
+		if err := os.Remove(name); err != nil {
+			return 0, err
+		}
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*", "*"+TmpFileSuffix))
+	if err != nil {
+		return 0, err
+	}
+	var lastVersion int64
+	for _, tmpName := range files {
+		version, err := recoverTmpFile(tmpName, zlibLevel, untilVersion)
+		if err != nil {
+			return 0, fmt.Errorf("recover %s failed: %w", tmpName, err)
+		}
+		lastVersion = max(lastVersion, version)
+	}
+	return lastVersion, nil
+}
+
+// recoverTmpFile copies the complete change sets into the final file, the trailing incomplete one and the ones after
+// `untilVersion` are dropped, returns the last version recovered.
+func recoverTmpFile(tmpName string, zlibLevel int, untilVersion int64) (int64, error) {
+	origName := strings.TrimSuffix(tmpName, TmpFileSuffix)
+	// the recovered file is written in current compression setting
+	name := strings.TrimSuffix(origName, ZlibFileSuffix)
+	if zlibLevel > 0 {
+		name += ZlibFileSuffix
+	}
+
+	fp, err := os.Open(tmpName)
+	if err != nil {
+		return 0, err
+	}
+	defer fp.Close()
+
+	var reader Reader = bufio.NewReader(fp)
+	if strings.HasSuffix(origName, ZlibFileSuffix) {
+		zreader, err := zlib.NewReader(reader)
+		if err != nil {
+			// the zlib header is not even written
+			return 0, os.Remove(tmpName)
+		}
+		reader = bufio.NewReader(zreader)
+	}
+
+	var (
+		w           *changeSetFileWriter
+		lastVersion int64
+	)
+	for {
+		version, _, cs, err := ReadChangeSet(reader, true)
+		if err != nil || (untilVersion > 0 && version > untilVersion) {
+			break
+		}
+		if w == nil {
+			if w, err = newChangeSetFileWriter(name, name+recoverFileSuffix, zlibLevel); err != nil {
+				return 0, err
+			}
+		}
+		if err := WriteChangeSet(w.writer, version, cs); err != nil {
+			return 0, errors.Join(err, w.fp.Close())
+		}
+		lastVersion = version
+	}
+
+	if w != nil {
+		if err := w.finish(); err != nil {
+			return 0, err
+		}
+	}
+	return lastVersion, os.Remove(tmpName)
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"compress/zlib"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cosmossdk.io/store/types"
+	"github.com/cosmos/iavl"
+	"github.com/stretchr/testify/require"
+)
+
+func sinkChangeSet(version int64) []*types.StoreKVPair {
+	return []*types.StoreKVPair{
+		{StoreKey: "bank", Key: []byte("hello"), Value: []byte(fmt.Sprintf("world%d", version))},
+		{StoreKey: "bank", Key: []byte(fmt.Sprintf("hello%d", version)), Delete: true},
+		{StoreKey: "staking", Key: []byte("hello"), Value: []byte(fmt.Sprintf("world%d", version))},
+	}
+}
+
+// readSinkFiles reads back the change sets of a store written by the sink, returns the first versions of the files.
+func readSinkFiles(t *testing.T, dir, store string) ([]uint64, []int64, []*iavl.ChangeSet) {
+	files, err := filepath.Glob(filepath.Join(dir, store, "*"))
+	require.NoError(t, err)
+	sorted, err := SortFilesByFirstVerson(files)
+	require.NoError(t, err)
+
+	var (
+		firstVersions []uint64
+		versions      []int64
+		changeSets    []*iavl.ChangeSet
+	)
+	for _, f := range sorted {
+		firstVersions = append(firstVersions, f.Version)
+		require.NoError(t, withChangeSetFile(f.FileName, func(reader Reader) error {
+			_, err := IterateChangeSets(reader, func(version int64, changeSet *iavl.ChangeSet) (bool, error) {
+				versions = append(versions, version)
+				changeSets = append(changeSets, changeSet)
+				return true, nil
+			})
+			return err
+		}))
+	}
+	return firstVersions, versions, changeSets
+}
+
+func TestChangeSetFileSink(t *testing.T) {
+	for _, zlibLevel := range []int{0, zlib.BestSpeed} {
+		t.Run(fmt.Sprintf("zlib-%d", zlibLevel), func(t *testing.T) {
+			dir := t.TempDir()
+			sink, err := NewChangeSetFileSink(dir, 3, zlibLevel, 0)
+			require.NoError(t, err)
+
+			for v := int64(1); v <= 7; v++ {
+				require.NoError(t, sink.WriteChangeSet(v, sinkChangeSet(v)))
+			}
+			require.NoError(t, sink.Close())
+
+			// the files are aligned to the multiples of blocks per file
+			firstVersions, versions, changeSets := readSinkFiles(t, dir, "bank")
+			require.Equal(t, []uint64{1, 3, 6}, firstVersions)
+			require.Equal(t, []int64{1, 2, 3, 4, 5, 6, 7}, versions)
+			for i, cs := range changeSets {
+				v := versions[i]
+				require.Equal(t, []*iavl.KVPair{
+					{Key: []byte("hello"), Value: []byte(fmt.Sprintf("world%d", v))},
+					{Key: []byte(fmt.Sprintf("hello%d", v)), Delete: true},
+				}, cs.Pairs)
+			}
+
+			_, versions, _ = readSinkFiles(t, dir, "staking")
+			require.Equal(t, []int64{1, 2, 3, 4, 5, 6, 7}, versions)
+
+			tmpFiles, err := filepath.Glob(filepath.Join(dir, "*", "*"+TmpFileSuffix))
+			require.NoError(t, err)
+			require.Empty(t, tmpFiles)
+		})
+	}
+}
+
+func TestChangeSetFileSinkRecover(t *testing.T) {
+	for _, zlibLevel := range []int{0, zlib.BestSpeed} {
+		t.Run(fmt.Sprintf("zlib-%d", zlibLevel), func(t *testing.T) {
+			dir := t.TempDir()
+			sink, err := NewChangeSetFileSink(dir, 10, zlibLevel, 0)
+			require.NoError(t, err)
+			for v := int64(1); v <= 3; v++ {
+				require.NoError(t, sink.WriteChangeSet(v, sinkChangeSet(v)))
+			}
+
+			// simulate an unclean shutdown in the middle of writing a change set, version 4 is only written to
+			// the bank store, it's dropped and written again.
+			w := sink.writers["bank"]
+			require.NoError(t, w.write(4, &iavl.ChangeSet{Pairs: []*iavl.KVPair{{Key: []byte("partial"), Value: []byte("4")}}}))
+			_, err = w.bufWriter.Write([]byte{4, 0, 0})
+			require.NoError(t, err)
+			require.NoError(t, w.bufWriter.Flush())
+			for _, w := range sink.writers {
+				require.NoError(t, w.fp.Close())
+			}
+
+			sink, err = NewChangeSetFileSink(dir, 10, zlibLevel, 3)
+			require.NoError(t, err)
+			for v := int64(4); v <= 5; v++ {
+				require.NoError(t, sink.WriteChangeSet(v, sinkChangeSet(v)))
+			}
+			require.NoError(t, sink.Close())
+
+			firstVersions, versions, changeSets := readSinkFiles(t, dir, "bank")
+			require.Equal(t, []uint64{1, 4}, firstVersions)
+			require.Equal(t, []int64{1, 2, 3, 4, 5}, versions)
+			require.Equal(t, []byte("hello"), changeSets[3].Pairs[0].Key)
+		})
+	}
+}
+
+func TestRecoverEmptyTmpFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "bank"), os.ModePerm))
+	tmpName := filepath.Join(dir, "bank", "block-1"+ZlibFileSuffix+TmpFileSuffix)
+	require.NoError(t, os.WriteFile(tmpName, nil, 0o600))
+
+	_, err := NewChangeSetFileSink(dir, 10, 0, 0)
+	require.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "bank", "*"))
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
+
+func TestChangeSetFileSinkLatestVersion(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := NewChangeSetFileSink(dir, 10, 0, 0)
+	require.NoError(t, err)
+	for v := int64(1); v <= 2; v++ {
+		require.NoError(t, sink.WriteChangeSet(v, sinkChangeSet(v)))
+	}
+	require.NoError(t, sink.Close())
+
+	// the files are behind the version store
+	_, err = NewChangeSetFileSink(dir, 10, 0, 3)
+	require.ErrorContains(t, err, "behind the version store")
+
+	sink, err = NewChangeSetFileSink(dir, 10, 0, 2)
+	require.NoError(t, err)
+	// the versions written already are skipped, a gap is rejected
+	require.NoError(t, sink.WriteChangeSet(2, sinkChangeSet(2)))
+	require.Error(t, sink.WriteChangeSet(4, sinkChangeSet(4)))
+	require.NoError(t, sink.WriteChangeSet(3, sinkChangeSet(3)))
+	require.NoError(t, sink.Close())
+
+	_, versions, _ := readSinkFiles(t, dir, "bank")
+	require.Equal(t, []int64{1, 2, 3}, versions)
+
+	// fallback to the last version in the files without the record
+	require.NoError(t, os.Remove(filepath.Join(dir, LatestVersionFileName)))
+	_, err = NewChangeSetFileSink(dir, 10, 0, 4)
+	require.ErrorContains(t, err, "behind the version store")
+	sink, err = NewChangeSetFileSink(dir, 10, 0, 3)
+	require.NoError(t, err)
+	require.NoError(t, sink.WriteChangeSet(3, sinkChangeSet(3)))
+	require.NoError(t, sink.WriteChangeSet(4, sinkChangeSet(4)))
+	require.NoError(t, sink.Close())
+
+	_, versions, _ = readSinkFiles(t, dir, "bank")
+	require.Equal(t, []int64{1, 2, 3, 4}, versions)
+}
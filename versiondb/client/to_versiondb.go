@@ -1,6 +1,11 @@
+//go:build rocksdb
+// +build rocksdb
+
 package client
 
 import (
+	"fmt"
+
 	"github.com/cosmos/iavl"
 	"github.com/crypto-org-chain/cronos/versiondb/tsrocksdb"
 	"github.com/spf13/cobra"
@@ -23,9 +28,20 @@ func ChangeSetToVersionDBCmd() *cobra.Command {
 			}
 
 			for _, plainFile := range args[1:] {
+				fileStore := store
+				if len(fileStore) == 0 {
+					// the v2 files carry the store name in header
+					if fileStore, err = ReadChangeSetFileStore(plainFile); err != nil {
+						return err
+					}
+					if len(fileStore) == 0 {
+						return fmt.Errorf("store name is not specified for file: %s", plainFile)
+					}
+				}
+
 				if err := withChangeSetFile(plainFile, func(reader Reader) error {
 					_, err := IterateChangeSets(reader, func(version int64, changeSet *iavl.ChangeSet) (bool, error) {
-						if err := versionDB.FeedChangeSet(version, store, changeSet); err != nil {
+						if err := versionDB.FeedChangeSet(version, fileStore, changeSet); err != nil {
 							return false, err
 						}
 						return true, nil
@@ -41,6 +57,6 @@ func ChangeSetToVersionDBCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().String(flagStore, "", "store name, the keys are prefixed with \"s/k:{store}/\"")
+	cmd.Flags().String(flagStore, "", "store name, the keys are prefixed with \"s/k:{store}/\", default to the store name in the header of v2 files")
 	return cmd
 }
@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"sort"
 	"sync"
 
@@ -57,27 +59,57 @@ func VerifyChangeSetCmd(defaultStores []string) *cobra.Command {
 				return err
 			}
 
-			if len(saveSnapshot) > 0 {
-				// detect the write permission early on.
-				if err := os.MkdirAll(saveSnapshot, os.ModePerm); err != nil {
-					return err
+			workDir, err := cmd.Flags().GetString(flagWorkDir)
+			if err != nil {
+				return err
+			}
+			checkpointInterval, err := cmd.Flags().GetInt64(flagCheckpointInterval)
+			if err != nil {
+				return err
+			}
+			checkpointKeep, err := cmd.Flags().GetInt(flagCheckpointKeep)
+			if err != nil {
+				return err
+			}
+			reportHeights, err := cmd.Flags().GetInt64Slice(flagReportHeights)
+			if err != nil {
+				return err
+			}
+			reportFile, err := cmd.Flags().GetString(flagReport)
+			if err != nil {
+				return err
+			}
+			if checkpointInterval < 0 {
+				return fmt.Errorf("invalid checkpoint interval: %d", checkpointInterval)
+			}
+			if checkpointInterval > 0 && len(workDir) == 0 {
+				return errors.New("--checkpoint-interval requires --work-dir")
+			}
+			if checkpointKeep < 0 {
+				return fmt.Errorf("invalid checkpoint keep recent: %d", checkpointKeep)
+			}
+
+			for _, dir := range []string{saveSnapshot, workDir} {
+				if len(dir) > 0 {
+					// detect the write permission early on.
+					if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+						return err
+					}
 				}
 			}
 
 			changeSetDir := args[0]
 
-			// create fixed size task pool with big enough buffer.
-			pool := pond.New(concurrency, 0)
-			defer pool.StopAndWait()
-			group, _ := pool.GroupContext(context.Background())
-
-			var (
-				lastestVersion int64
-				storeInfosLock sync.Mutex
-			)
-			storeInfos := []storetypes.StoreInfo{
-				// https://github.com/cosmos/cosmos-sdk/issues/14916
-				{Name: capabilitytypes.MemStoreKey, CommitId: storetypes.CommitID{}},
+			var checkpoint int64
+			if len(workDir) > 0 {
+				// resume from the latest checkpoint
+				if checkpoint, err = latestCheckpoint(workDir, targetVersion); err != nil {
+					return err
+				}
+				if checkpoint > 0 {
+					loadSnapshot = filepath.Join(workDir, checkpointName(checkpoint))
+					fmt.Println("resume from checkpoint", checkpoint)
+				}
 			}
 
 			mtree := memiavl.NewEmptyMultiTree(0, 0)
@@ -89,48 +121,74 @@ func VerifyChangeSetCmd(defaultStores []string) *cobra.Command {
 				}
 			}
 
+			trees := make(map[string]*memiavl.Tree, len(stores))
 			for _, store := range stores {
-				// https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
-				store := store
 				tree := mtree.TreeByName(store)
 				if tree == nil {
 					tree = memiavl.New(0)
 				}
-				group.Submit(func() error {
-					storeInfo, err := verifyOneStore(tree, store, changeSetDir, saveSnapshot, targetVersion)
-					if err != nil {
+				trees[store] = tree
+			}
+
+			var report []VerifyResult
+			if len(reportFile) > 0 {
+				// keep the results before the resumed version
+				if report, err = readVerifyReport(reportFile, mtree.Version()); err != nil {
+					return err
+				}
+			}
+
+			// create fixed size task pool with big enough buffer.
+			pool := pond.New(concurrency, 0)
+			defer pool.StopAndWait()
+
+			// replay the stores in lockstep to the checkpoints, the report heights and the target version in turn,
+			// to have the full commit info at these versions.
+			var (
+				commitInfo storetypes.CommitInfo
+				current    = mtree.Version()
+			)
+			for {
+				end := nextVerifyBoundary(current, targetVersion, checkpointInterval, reportHeights)
+				if commitInfo, err = replayStores(pool, trees, changeSetDir, end); err != nil {
+					return err
+				}
+				// the change set files are exhausted, or the target version is reached
+				done := end == 0 || commitInfo.Version < end || commitInfo.Version == targetVersion
+				current = commitInfo.Version
+
+				if done || slices.Contains(reportHeights, current) {
+					if report, err = appendVerifyResult(report, &commitInfo); err != nil {
 						return err
 					}
-					if storeInfo == nil {
-						// the store don't exist before target version, don't affect the commit info and app hash.
-						return nil
+					if len(reportFile) > 0 {
+						if err := writeJSONFile(reportFile, report); err != nil {
+							return err
+						}
 					}
-
-					storeInfosLock.Lock()
-					defer storeInfosLock.Unlock()
-					storeInfos = append(storeInfos, *storeInfo)
-					if storeInfo.CommitId.Version > lastestVersion {
-						lastestVersion = storeInfo.CommitId.Version
+				}
+				if checkpointInterval > 0 && current > checkpoint && current%checkpointInterval == 0 {
+					if err := writeCheckpoint(workDir, trees, &commitInfo, checkpointKeep); err != nil {
+						return err
 					}
-					return nil
-				})
-			}
-			if err := group.Wait(); err != nil {
-				return err
-			}
+					checkpoint = current
+				}
+				if len(workDir) > 0 {
+					if err := writeJSONFile(filepath.Join(workDir, VerifyProgressFileName), VerifyProgress{
+						Version:    current,
+						Checkpoint: checkpoint,
+					}); err != nil {
+						return err
+					}
+				}
 
-			commitInfo := buildCommitInfo(storeInfos, lastestVersion)
+				if done {
+					break
+				}
+			}
 
 			if len(saveSnapshot) > 0 {
-				// write multitree metadata
-				metadata := memiavl.MultiTreeMetadata{
-					CommitInfo: convertCommitInfo(&commitInfo),
-				}
-				bz, err := metadata.Marshal()
-				if err != nil {
-					return err
-				}
-				if err := memiavl.WriteFileSync(filepath.Join(saveSnapshot, memiavl.MetadataFileName), bz); err != nil {
+				if err := writeMultiTreeSnapshot(saveSnapshot, trees, &commitInfo); err != nil {
 					return err
 				}
 			}
@@ -180,13 +238,60 @@ func VerifyChangeSetCmd(defaultStores []string) *cobra.Command {
 	cmd.Flags().Int(flagConcurrency, runtime.NumCPU(), "Number concurrent goroutines to parallelize the work")
 	cmd.Flags().Bool(flagCheck, false, "Check the replayed hash with the one stored in change set directory")
 	cmd.Flags().Bool(flagSave, false, "Save the verify result to change set directory, otherwise output to stdout")
+	cmd.Flags().String(flagWorkDir, "", "directory to save the checkpoints and the progress, the verification resumes from the latest checkpoint in it")
+	cmd.Flags().Int64(flagCheckpointInterval, 0, "save a checkpoint to the work directory every N versions, 0 means no checkpoints")
+	cmd.Flags().Int(flagCheckpointKeep, 1, "number of the latest checkpoints to keep in the work directory, the older ones are deleted, 0 means keep all")
+	cmd.Flags().Int64Slice(flagReportHeights, nil, "the versions to output commit info in the report, besides the target version")
+	cmd.Flags().String(flagReport, "", "write the app hashes and commit infos of the report heights to the json file")
 
 	return cmd
 }
 
+// replayStores replays the change sets of the stores in parallel to the target version, or exhaust the change set
+// files if it's 0, and returns the commit info of the replayed version.
+func replayStores(pool *pond.WorkerPool, trees map[string]*memiavl.Tree, changeSetDir string, targetVersion int64) (storetypes.CommitInfo, error) {
+	group, _ := pool.GroupContext(context.Background())
+
+	var (
+		lastestVersion int64
+		storeInfosLock sync.Mutex
+	)
+	storeInfos := []storetypes.StoreInfo{
+		// https://github.com/cosmos/cosmos-sdk/issues/14916
+		{Name: capabilitytypes.MemStoreKey, CommitId: storetypes.CommitID{}},
+	}
+	for store, tree := range trees {
+		// https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		store, tree := store, tree
+		group.Submit(func() error {
+			storeInfo, err := verifyOneStore(tree, store, changeSetDir, targetVersion)
+			if err != nil {
+				return err
+			}
+			if storeInfo == nil {
+				// the store don't exist before target version, don't affect the commit info and app hash.
+				return nil
+			}
+
+			storeInfosLock.Lock()
+			defer storeInfosLock.Unlock()
+			storeInfos = append(storeInfos, *storeInfo)
+			if storeInfo.CommitId.Version > lastestVersion {
+				lastestVersion = storeInfo.CommitId.Version
+			}
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return storetypes.CommitInfo{}, err
+	}
+
+	return buildCommitInfo(storeInfos, lastestVersion), nil
+}
+
 // verifyOneStore process a single store, can run in parallel with other stores.
 // if the store don't exist before the `targetVersion`, returns nil without error.
-func verifyOneStore(tree *memiavl.Tree, store, changeSetDir, saveSnapshot string, targetVersion int64) (*storetypes.StoreInfo, error) {
+func verifyOneStore(tree *memiavl.Tree, store, changeSetDir string, targetVersion int64) (*storetypes.StoreInfo, error) {
 	filesWithVersion, err := scanChangeSetFiles(changeSetDir, store)
 	if err != nil {
 		return nil, err
@@ -205,12 +310,16 @@ func verifyOneStore(tree *memiavl.Tree, store, changeSetDir, saveSnapshot string
 		return nil, err
 	}
 
-	for _, file := range filesWithVersion {
+	for i, file := range filesWithVersion {
 		if targetVersion > 0 && file.Version > uint64(targetVersion) {
 			break
 		}
+		if i+1 < len(filesWithVersion) && filesWithVersion[i+1].Version <= uint64(tree.Version())+1 {
+			// the remaining change sets of the file are covered by the next file, skip it to resume faster
+			continue
+		}
 
-		err = withChangeSetFile(file.FileName, func(reader Reader) error {
+		err = withChangeSetFileAt(file.FileName, tree.Version()+1, func(reader Reader) error {
 			_, err := IterateChangeSets(reader, func(version int64, changeSet *iavl.ChangeSet) (bool, error) {
 				if version <= tree.Version() {
 					// skip old change sets
@@ -244,16 +353,6 @@ func verifyOneStore(tree *memiavl.Tree, store, changeSetDir, saveSnapshot string
 		return nil, err
 	}
 
-	if len(saveSnapshot) > 0 {
-		snapshotDir := filepath.Join(saveSnapshot, store)
-		if err := os.MkdirAll(snapshotDir, os.ModePerm); err != nil {
-			return nil, err
-		}
-		if err := tree.WriteSnapshot(snapshotDir); err != nil {
-			return nil, err
-		}
-	}
-
 	return &storetypes.StoreInfo{
 		Name:     store,
 		CommitId: lastCommitID(tree),
@@ -278,7 +377,7 @@ func buildCommitInfo(storeInfos []storetypes.StoreInfo, version int64) storetype
 	})
 
 	return storetypes.CommitInfo{
-		Version:    storeInfos[0].CommitId.Version,
+		Version:    version,
 		StoreInfos: storeInfos,
 	}
 }
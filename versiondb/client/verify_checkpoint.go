@@ -0,0 +1,216 @@
+package client
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/gogoproto/jsonpb"
+
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/crypto-org-chain/cronos/memiavl"
+)
+
+// VerifyProgressFileName is the file in the work directory of `verify` command which records the progress.
+const VerifyProgressFileName = "progress.json"
+
+// VerifyProgress is the content of the progress file, it's updated every time the replay reaches a checkpoint,
+// a report height or the target version.
+type VerifyProgress struct {
+	// the version replayed
+	Version int64 `json:"version"`
+	// the version of the latest checkpoint, 0 if no checkpoint is saved
+	Checkpoint int64 `json:"checkpoint"`
+}
+
+// VerifyResult is an entry of the json report of `verify` command.
+type VerifyResult struct {
+	Version    int64           `json:"version"`
+	AppHash    string          `json:"app_hash"`
+	CommitInfo json.RawMessage `json:"commit_info"`
+}
+
+// nextVerifyBoundary returns the next version after the current one to stop the replay at, which is the closest one
+// among the target version, the next checkpoint and the report heights, returns 0 if there's none.
+func nextVerifyBoundary(current, targetVersion, checkpointInterval int64, reportHeights []int64) int64 {
+	next := targetVersion
+	closer := func(version int64) {
+		if version > current && (next == 0 || version < next) {
+			next = version
+		}
+	}
+	if checkpointInterval > 0 {
+		closer((current/checkpointInterval + 1) * checkpointInterval)
+	}
+	for _, height := range reportHeights {
+		closer(height)
+	}
+	return next
+}
+
+// checkpointName returns the directory name of the checkpoint, the same as the memiavl snapshots, so the work
+// directory can be used as the snapshot directory of the historical proofs.
+func checkpointName(version int64) string {
+	return fmt.Sprintf("%s%020d", memiavl.SnapshotPrefix, version)
+}
+
+// listCheckpoints returns the versions of the checkpoints in the work directory which are not newer than the target
+// version, in ascending order, 0 target version means no limit.
+func listCheckpoints(workDir string, targetVersion int64) ([]int64, error) {
+	entries, err := os.ReadDir(workDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []int64
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || len(name) != memiavl.SnapshotDirLen || !strings.HasPrefix(name, memiavl.SnapshotPrefix) {
+			continue
+		}
+		version, err := strconv.ParseInt(name[len(memiavl.SnapshotPrefix):], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid checkpoint name: %s, %w", name, err)
+		}
+		if targetVersion > 0 && version > targetVersion {
+			continue
+		}
+		versions = append(versions, version)
+	}
+	slices.Sort(versions)
+	return versions, nil
+}
+
+// latestCheckpoint returns the latest checkpoint version in the work directory which is not newer than the target
+// version, returns 0 if not found.
+func latestCheckpoint(workDir string, targetVersion int64) (int64, error) {
+	versions, err := listCheckpoints(workDir, targetVersion)
+	if err != nil || len(versions) == 0 {
+		return 0, err
+	}
+	return versions[len(versions)-1], nil
+}
+
+// writeCheckpoint saves the trees into the work directory, the checkpoint is written into a temporary directory and
+// renamed after finished, so an interrupted one is never loaded. After that, only the latest `keepRecent` ones not
+// newer than it are kept, 0 means keep all.
+func writeCheckpoint(workDir string, trees map[string]*memiavl.Tree, commitInfo *storetypes.CommitInfo, keepRecent int) error {
+	dir := filepath.Join(workDir, checkpointName(commitInfo.Version))
+	tmpDir := dir + TmpFileSuffix
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return err
+	}
+	if err := writeMultiTreeSnapshot(tmpDir, trees, commitInfo); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpDir, dir); err != nil {
+		return err
+	}
+
+	if keepRecent <= 0 {
+		return nil
+	}
+	versions, err := listCheckpoints(workDir, commitInfo.Version)
+	if err != nil {
+		return err
+	}
+	for _, version := range versions[:max(len(versions)-keepRecent, 0)] {
+		if err := os.RemoveAll(filepath.Join(workDir, checkpointName(version))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeMultiTreeSnapshot writes the trees in the commit info and the metadata as a memiavl snapshot.
+func writeMultiTreeSnapshot(dir string, trees map[string]*memiavl.Tree, commitInfo *storetypes.CommitInfo) error {
+	for _, storeInfo := range commitInfo.StoreInfos {
+		tree, ok := trees[storeInfo.Name]
+		if !ok {
+			// the memory stores
+			continue
+		}
+		snapshotDir := filepath.Join(dir, storeInfo.Name)
+		if err := os.MkdirAll(snapshotDir, os.ModePerm); err != nil {
+			return err
+		}
+		if err := tree.WriteSnapshot(snapshotDir); err != nil {
+			return err
+		}
+	}
+
+	// write multitree metadata
+	metadata := memiavl.MultiTreeMetadata{
+		CommitInfo: convertCommitInfo(commitInfo),
+	}
+	bz, err := metadata.Marshal()
+	if err != nil {
+		return err
+	}
+	return memiavl.WriteFileSync(filepath.Join(dir, memiavl.MetadataFileName), bz)
+}
+
+// readVerifyReport reads the existing report, and keeps the results not newer than the version,
+// returns empty report if not exists.
+func readVerifyReport(fileName string, version int64) ([]VerifyResult, error) {
+	bz, err := os.ReadFile(fileName)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var report []VerifyResult
+	if err := json.Unmarshal(bz, &report); err != nil {
+		return nil, err
+	}
+	for i, result := range report {
+		if result.Version > version {
+			return report[:i], nil
+		}
+	}
+	return report, nil
+}
+
+// appendVerifyResult appends the commit info to the report, unless the version is recorded already.
+func appendVerifyResult(report []VerifyResult, commitInfo *storetypes.CommitInfo) ([]VerifyResult, error) {
+	if len(report) > 0 && report[len(report)-1].Version >= commitInfo.Version {
+		return report, nil
+	}
+
+	var buf bytes.Buffer
+	marshaler := jsonpb.Marshaler{}
+	if err := marshaler.Marshal(&buf, commitInfo); err != nil {
+		return nil, err
+	}
+	return append(report, VerifyResult{
+		Version:    commitInfo.Version,
+		AppHash:    hex.EncodeToString(commitInfo.Hash()),
+		CommitInfo: buf.Bytes(),
+	}), nil
+}
+
+// writeJSONFile replaces the json file atomically.
+func writeJSONFile(fileName string, v any) error {
+	bz, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmpFile := fileName + TmpFileSuffix
+	if err := memiavl.WriteFileSync(tmpFile, bz); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, fileName)
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextVerifyBoundary(t *testing.T) {
+	testCases := []struct {
+		current, target, interval int64
+		reportHeights             []int64
+		expected                  int64
+	}{
+		{0, 0, 0, nil, 0},
+		{0, 10, 0, nil, 10},
+		{0, 10, 4, nil, 4},
+		{4, 10, 4, nil, 8},
+		{8, 10, 4, nil, 10},
+		{10, 10, 4, nil, 10},
+		{0, 0, 4, []int64{3, 5}, 3},
+		{3, 0, 4, []int64{3, 5}, 4},
+		{4, 0, 4, []int64{3, 5}, 5},
+		{5, 0, 0, []int64{3, 5}, 0},
+		{5, 7, 0, []int64{3, 5, 9}, 7},
+	}
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, nextVerifyBoundary(tc.current, tc.target, tc.interval, tc.reportHeights), tc)
+	}
+}
+
+func runVerify(t *testing.T, args ...string) {
+	cmd := VerifyChangeSetCmd([]string{"store1", "store2"})
+	cmd.SetArgs(args)
+	require.NoError(t, cmd.Execute())
+}
+
+func readReport(t *testing.T, fileName string) map[int64]string {
+	bz, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	var report []VerifyResult
+	require.NoError(t, json.Unmarshal(bz, &report))
+	res := make(map[int64]string, len(report))
+	for _, result := range report {
+		res[result.Version] = result.AppHash
+	}
+	return res
+}
+
+func TestVerifyCheckpoint(t *testing.T) {
+	changeSetDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(changeSetDir, "store1"), os.ModePerm))
+	require.NoError(t, os.MkdirAll(filepath.Join(changeSetDir, "store2"), os.ModePerm))
+	writeV1File(t, filepath.Join(changeSetDir, "store1", "block-1"), 1, 7, 0)
+	// store2 is added at version 3
+	writeV1File(t, filepath.Join(changeSetDir, "store2", "block-3"), 3, 7, 0)
+
+	// the app hashes computed in separate runs
+	expected := make(map[int64]string)
+	for _, version := range []int64{3, 5, 7} {
+		reportFile := filepath.Join(t.TempDir(), "report.json")
+		runVerify(t, changeSetDir, "--target-version", strconv.FormatInt(version, 10), "--report", reportFile)
+		report := readReport(t, reportFile)
+		require.Len(t, report, 1)
+		expected[version] = report[version]
+	}
+
+	workDir := t.TempDir()
+	reportFile := filepath.Join(t.TempDir(), "report.json")
+	runVerify(t, changeSetDir, "--work-dir", workDir, "--checkpoint-interval", "2", "--checkpoint-keep-recent", "2", "--report-heights", "3,5", "--report", reportFile)
+	require.Equal(t, expected, readReport(t, reportFile))
+	// only the latest 2 checkpoints are kept
+	require.NoDirExists(t, filepath.Join(workDir, checkpointName(2)))
+	for _, version := range []int64{4, 6} {
+		require.DirExists(t, filepath.Join(workDir, checkpointName(version)))
+	}
+	bz, err := os.ReadFile(filepath.Join(workDir, VerifyProgressFileName))
+	require.NoError(t, err)
+	var progress VerifyProgress
+	require.NoError(t, json.Unmarshal(bz, &progress))
+	require.Equal(t, VerifyProgress{Version: 7, Checkpoint: 6}, progress)
+
+	// resume from the checkpoint of version 4, the results after it are recomputed
+	checkpoint, err := latestCheckpoint(workDir, 5)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), checkpoint)
+	runVerify(t, changeSetDir, "--work-dir", workDir, "--target-version", "5", "--report-heights", "3", "--report", reportFile)
+	require.Equal(t, map[int64]string{3: expected[3], 5: expected[5]}, readReport(t, reportFile))
+
+	// the checkpoint can be loaded as the snapshot
+	reportFile = filepath.Join(t.TempDir(), "report.json")
+	runVerify(t, changeSetDir, "--load-snapshot", filepath.Join(workDir, checkpointName(6)), "--report", reportFile)
+	require.Equal(t, map[int64]string{7: expected[7]}, readReport(t, reportFile))
+
+	// only the latest checkpoint is kept by default
+	workDir = t.TempDir()
+	runVerify(t, changeSetDir, "--work-dir", workDir, "--checkpoint-interval", "2")
+	versions, err := listCheckpoints(workDir, 0)
+	require.NoError(t, err)
+	require.Equal(t, []int64{6}, versions)
+}
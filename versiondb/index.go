@@ -0,0 +1,168 @@
+package versiondb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+
+	"cosmossdk.io/store/types"
+)
+
+// ErrIndexNotAvailable is returned when querying an index at a version before it's enabled.
+var ErrIndexNotAvailable = errors.New("index is not available at the version")
+
+// IndexExtractor returns the secondary index keys of a key-value pair in the store, nil if it's not indexed.
+type IndexExtractor func(key, value []byte) [][]byte
+
+// Index defines a secondary index on a store, the index entries are versioned like the primary key-value pairs,
+// so the primary keys matching an index key can be looked up at any version.
+type Index struct {
+	Name    string
+	Store   string
+	Extract IndexExtractor
+}
+
+type Indexes []Index
+
+// IndexedStore is implemented by the VersionStore backends which support the secondary indexes.
+type IndexedStore interface {
+	// IndexIteratorAtVersion iterates the index entries at the version, the key of the entry is
+	// `IndexKeyPrefix(indexKey) || primaryKey`, the value is the primary key, the start and end are bounds on the entry
+	// keys, `nil` version means the latest version. It returns `ErrIndexNotAvailable` if the index is enabled after
+	// the version.
+	IndexIteratorAtVersion(index string, start, end []byte, version *int64) (Iterator, error)
+}
+
+// Validate checks the index names are unique and non-empty.
+func (idxs Indexes) Validate() error {
+	names := make(map[string]struct{}, len(idxs))
+	for _, idx := range idxs {
+		if len(idx.Name) == 0 || len(idx.Store) == 0 || idx.Extract == nil {
+			return fmt.Errorf("invalid index: %q", idx.Name)
+		}
+		if _, ok := names[idx.Name]; ok {
+			return fmt.Errorf("duplicated index: %s", idx.Name)
+		}
+		names[idx.Name] = struct{}{}
+	}
+	return nil
+}
+
+// Get returns the index by name.
+func (idxs Indexes) Get(name string) (Index, bool) {
+	for _, idx := range idxs {
+		if idx.Name == name {
+			return idx, true
+		}
+	}
+	return Index{}, false
+}
+
+// ImportEntries returns the index entries of an imported key-value pair, same format as `Changes`.
+func (idxs Indexes) ImportEntries(storeKey string, key, value []byte) []*types.StoreKVPair {
+	var entries []*types.StoreKVPair
+	for _, idx := range idxs {
+		if idx.Store != storeKey {
+			continue
+		}
+		for _, indexKey := range idx.Extract(key, value) {
+			entries = append(entries, indexEntry(idx.Name, indexKey, key, false))
+		}
+	}
+	return entries
+}
+
+// Changes computes the index entries to write for a change set, getLatest returns the value of the primary key
+// before the change set is applied. The entries are returned as key-value pairs, the `StoreKey` is the index name,
+// the `Key` is `IndexKeyPrefix(indexKey) || primaryKey`, the `Value` is the primary key, the stale entries are deleted.
+func (idxs Indexes) Changes(
+	changeSet []*types.StoreKVPair,
+	getLatest func(storeKey string, key []byte) ([]byte, error),
+) ([]*types.StoreKVPair, error) {
+	if len(idxs) == 0 {
+		return nil, nil
+	}
+
+	var entries []*types.StoreKVPair
+	for _, pair := range changeSet {
+		var oldValue []byte
+		loaded := false
+		for _, idx := range idxs {
+			if idx.Store != pair.StoreKey {
+				continue
+			}
+			if !loaded {
+				var err error
+				if oldValue, err = getLatest(pair.StoreKey, pair.Key); err != nil {
+					return nil, err
+				}
+				loaded = true
+			}
+
+			var oldKeys, newKeys [][]byte
+			if oldValue != nil {
+				oldKeys = idx.Extract(pair.Key, oldValue)
+			}
+			if !pair.Delete {
+				newKeys = idx.Extract(pair.Key, pair.Value)
+			}
+
+			for _, indexKey := range oldKeys {
+				if !containsKey(newKeys, indexKey) {
+					entries = append(entries, indexEntry(idx.Name, indexKey, pair.Key, true))
+				}
+			}
+			for _, indexKey := range newKeys {
+				if !containsKey(oldKeys, indexKey) {
+					entries = append(entries, indexEntry(idx.Name, indexKey, pair.Key, false))
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].StoreKey != entries[j].StoreKey {
+			return entries[i].StoreKey < entries[j].StoreKey
+		}
+		return bytes.Compare(entries[i].Key, entries[j].Key) < 0
+	})
+	return entries, nil
+}
+
+// IndexKeyPrefix returns the prefix of the index entries of the index key, the index key is prefixed by its length in
+// uvarint, so the variable-length index keys and primary keys don't collide when concatenated.
+func IndexKeyPrefix(indexKey []byte) []byte {
+	prefix := make([]byte, 0, binary.MaxVarintLen64+len(indexKey))
+	prefix = binary.AppendUvarint(prefix, uint64(len(indexKey)))
+	return append(prefix, indexKey...)
+}
+
+// SplitIndexEntryKey splits the key of an index entry into the index key and the primary key.
+func SplitIndexEntryKey(key []byte) (indexKey, primaryKey []byte, err error) {
+	length, n := binary.Uvarint(key)
+	if n <= 0 || uint64(len(key)-n) < length {
+		return nil, nil, fmt.Errorf("invalid index entry key: %X", key)
+	}
+	end := n + int(length)
+	return key[n:end], key[end:], nil
+}
+
+func indexEntry(index string, indexKey, primaryKey []byte, deleted bool) *types.StoreKVPair {
+	key := append(IndexKeyPrefix(indexKey), primaryKey...)
+	entry := &types.StoreKVPair{StoreKey: index, Key: key, Delete: deleted}
+	if !deleted {
+		entry.Value = primaryKey
+	}
+	return entry
+}
+
+func containsKey(keys [][]byte, key []byte) bool {
+	for _, k := range keys {
+		if bytes.Equal(k, key) {
+			return true
+		}
+	}
+	return false
+}
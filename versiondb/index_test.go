@@ -0,0 +1,33 @@
+package versiondb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexEntryKey(t *testing.T) {
+	indexes := Indexes{
+		{Name: "value", Store: "test", Extract: func(_, value []byte) [][]byte { return [][]byte{value} }},
+	}
+
+	// the same concatenation of different index keys and primary keys don't collide
+	entries1 := indexes.ImportEntries("test", []byte("c"), []byte("ab"))
+	entries2 := indexes.ImportEntries("test", []byte("bc"), []byte("a"))
+	require.Len(t, entries1, 1)
+	require.Len(t, entries2, 1)
+	require.NotEqual(t, entries1[0].Key, entries2[0].Key)
+
+	indexKey, primaryKey, err := SplitIndexEntryKey(entries1[0].Key)
+	require.NoError(t, err)
+	require.Equal(t, []byte("ab"), indexKey)
+	require.Equal(t, []byte("c"), primaryKey)
+
+	indexKey, primaryKey, err = SplitIndexEntryKey(entries2[0].Key)
+	require.NoError(t, err)
+	require.Equal(t, []byte("a"), indexKey)
+	require.Equal(t, []byte("bc"), primaryKey)
+
+	_, _, err = SplitIndexEntryKey([]byte{3, 'a'})
+	require.Error(t, err)
+}
@@ -0,0 +1,159 @@
+package versiondb
+
+import (
+	"context"
+	"errors"
+
+	"cosmossdk.io/store/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultIndexQueryLimit is the default max number of entries returned by the `IndexedKeys` query.
+	DefaultIndexQueryLimit = 100
+	// MaxIndexQueryLimit is the max number of entries can be returned by the `IndexedKeys` query.
+	MaxIndexQueryLimit = 1000
+
+	// DefaultKeyHistoryLimit is the default max number of entries returned by the `KeyHistory` query.
+	DefaultKeyHistoryLimit = 100
+	// MaxKeyHistoryLimit is the max number of entries can be returned by the `KeyHistory` query.
+	MaxKeyHistoryLimit = 1000
+)
+
+var _ QueryServer = queryServer{}
+
+type queryServer struct {
+	store   VersionStore
+	indexes Indexes
+}
+
+// NewQueryServer creates the gRPC query service of versiondb, the store must implement `IndexedStore` to serve
+// the index queries.
+func NewQueryServer(store VersionStore, indexes Indexes) QueryServer {
+	return queryServer{store: store, indexes: indexes}
+}
+
+// IndexedKeys implements QueryServer interface, it iterates the index entries of the index key, and loads the
+// primary key-value pairs at the same height.
+func (s queryServer) IndexedKeys(_ context.Context, req *QueryIndexedKeysRequest) (*QueryIndexedKeysResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	indexedStore, ok := s.store.(IndexedStore)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "versiondb backend don't support indexes")
+	}
+	idx, ok := s.indexes.Get(req.Index)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "index not found: %s", req.Index)
+	}
+	if req.Height < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid height: %d", req.Height)
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = DefaultIndexQueryLimit
+	}
+	limit = min(limit, MaxIndexQueryLimit)
+
+	// pin the latest version, so the index entries and the primary values are consistent
+	height := req.Height
+	if height == 0 {
+		var err error
+		if height, err = s.store.GetLatestVersion(); err != nil {
+			return nil, err
+		}
+	}
+	version := &height
+
+	var start, end []byte
+	if len(req.Prefix) > 0 {
+		prefix := IndexKeyPrefix(req.Prefix)
+		start, end = prefix, types.PrefixEndBytes(prefix)
+	}
+	if len(req.NextKey) > 0 {
+		start = req.NextKey
+	}
+
+	it, err := indexedStore.IndexIteratorAtVersion(req.Index, start, end, version)
+	if err != nil {
+		if errors.Is(err, ErrIndexNotAvailable) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, err
+	}
+	defer it.Close()
+
+	res := &QueryIndexedKeysResponse{}
+	for ; it.Valid(); it.Next() {
+		if len(res.Pairs) == limit {
+			res.NextKey = append([]byte(nil), it.Key()...)
+			break
+		}
+
+		indexKey, primaryKey, err := SplitIndexEntryKey(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		value, err := s.store.GetAtVersion(idx.Store, primaryKey, version)
+		if err != nil {
+			return nil, err
+		}
+		res.Pairs = append(res.Pairs, IndexedPair{
+			IndexKey: append([]byte(nil), indexKey...),
+			Key:      append([]byte(nil), primaryKey...),
+			Value:    value,
+		})
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// KeyHistory implements QueryServer interface, it returns the changes of the key in the height range, paginated by
+// the height.
+func (s queryServer) KeyHistory(_ context.Context, req *QueryKeyHistoryRequest) (*QueryKeyHistoryResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if len(req.Store) == 0 || len(req.Key) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty store or key")
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = DefaultKeyHistoryLimit
+	}
+	limit = min(limit, MaxKeyHistoryLimit)
+
+	toHeight := req.ToHeight
+	if toHeight == 0 {
+		var err error
+		if toHeight, err = s.store.GetLatestVersion(); err != nil {
+			return nil, err
+		}
+	}
+	if req.FromHeight < 0 || req.FromHeight > toHeight {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid height range: [%d, %d]", req.FromHeight, toHeight)
+	}
+
+	// one more change to tell the next height
+	changes, err := s.store.KeyHistory(req.Store, req.Key, req.FromHeight, toHeight, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &QueryKeyHistoryResponse{}
+	if len(changes) > limit {
+		res.NextHeight = changes[limit].Version
+		changes = changes[:limit]
+	}
+	res.Entries = make([]KeyHistoryEntry, len(changes))
+	for i, change := range changes {
+		res.Entries[i] = KeyHistoryEntry{Height: change.Version, Value: change.Value, Deleted: change.Deleted}
+	}
+	return res, nil
+}
@@ -2,6 +2,9 @@ package versiondb
 
 import (
 	"context"
+	"errors"
+	"io"
+	"sync"
 
 	abci "github.com/cometbft/cometbft/abci/types"
 
@@ -10,16 +13,44 @@ import (
 
 var _ types.ABCIListener = &StreamingService{}
 
+// DefaultPruneInterval is the default number of blocks between two background pruning runs when the retain blocks
+// is set.
+const DefaultPruneInterval = 1000
+
+// ChangeSetSink receives the ordered change set of each committed block besides the version store,
+// for example, to produce the change set files continuously.
+type ChangeSetSink interface {
+	io.Closer
+
+	// WriteChangeSet is called after the change set is persisted to the version store,
+	// the `changeSet` is ordered by (storeKey, key).
+	WriteChangeSet(version int64, changeSet []*types.StoreKVPair) error
+}
+
 // StreamingService is a concrete implementation of StreamingService that accumulate the state changes in current block,
 // writes the ordered changeset out to version storage.
 type StreamingService struct {
 	versionStore       VersionStore
 	currentBlockNumber int64 // the current block number
+
+	// keep only the recent blocks of history, 0 means keep all
+	retainBlocks int64
+	// the number of blocks between two background pruning runs
+	pruneInterval int64
+
+	sinks []ChangeSetSink
+
+	pruneMtx sync.Mutex
+	pruning  bool
+	// the error of the last background pruning, returned in next commit or in `Close`
+	pruneErr error
+	// tracks the background pruning, waited in `Close`
+	pruneWG sync.WaitGroup
 }
 
 // NewStreamingService creates a new StreamingService for the provided writeDir, (optional) filePrefix, and storeKeys
 func NewStreamingService(versionStore VersionStore) *StreamingService {
-	return &StreamingService{versionStore: versionStore}
+	return &StreamingService{versionStore: versionStore, pruneInterval: DefaultPruneInterval}
 }
 
 // ListenFinalizeBlock satisfies the types.ABCIListener interface
@@ -28,6 +59,81 @@ func (fss *StreamingService) ListenFinalizeBlock(ctx context.Context, req abci.R
 	return nil
 }
 
+// SetRetainBlocks enables the background pruning to keep only the recent blocks of history,
+// the version store must implement `HistoryPruner`.
+func (fss *StreamingService) SetRetainBlocks(retainBlocks int64) {
+	fss.retainBlocks = retainBlocks
+}
+
+// SetPruneInterval sets the number of blocks between two background pruning runs, it's ignored if not positive.
+func (fss *StreamingService) SetPruneInterval(interval int64) {
+	if interval > 0 {
+		fss.pruneInterval = interval
+	}
+}
+
+// AddSink registers a sink to receive the change sets after they are persisted to the version store.
+func (fss *StreamingService) AddSink(sink ChangeSetSink) {
+	fss.sinks = append(fss.sinks, sink)
+}
+
 func (fss *StreamingService) ListenCommit(ctx context.Context, res abci.ResponseCommit, changeSet []*types.StoreKVPair) error {
-	return fss.versionStore.PutAtVersion(fss.currentBlockNumber, changeSet)
+	if err := fss.versionStore.PutAtVersion(fss.currentBlockNumber, changeSet); err != nil {
+		return err
+	}
+	for _, sink := range fss.sinks {
+		if err := sink.WriteChangeSet(fss.currentBlockNumber, changeSet); err != nil {
+			return err
+		}
+	}
+	return fss.tryPrune(fss.currentBlockNumber)
+}
+
+// Close waits for the background pruning to finish and closes the sinks, the pruning error not returned in commit
+// yet is returned.
+func (fss *StreamingService) Close() error {
+	fss.pruneWG.Wait()
+
+	errs := make([]error, 0, len(fss.sinks)+1)
+	fss.pruneMtx.Lock()
+	errs = append(errs, fss.pruneErr)
+	fss.pruneErr = nil
+	fss.pruneMtx.Unlock()
+
+	for _, sink := range fss.sinks {
+		errs = append(errs, sink.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// tryPrune starts a background pruning every `pruneInterval` blocks, skip if the previous one is not finished yet.
+func (fss *StreamingService) tryPrune(version int64) error {
+	fss.pruneMtx.Lock()
+	defer fss.pruneMtx.Unlock()
+
+	if err := fss.pruneErr; err != nil {
+		fss.pruneErr = nil
+		return err
+	}
+
+	if fss.retainBlocks <= 0 || version%fss.pruneInterval != 0 || version <= fss.retainBlocks || fss.pruning {
+		return nil
+	}
+	pruner, ok := fss.versionStore.(HistoryPruner)
+	if !ok {
+		return nil
+	}
+
+	fss.pruning = true
+	fss.pruneWG.Add(1)
+	go func() {
+		defer fss.pruneWG.Done()
+		err := pruner.PruneBefore(version-fss.retainBlocks, nil, false)
+
+		fss.pruneMtx.Lock()
+		defer fss.pruneMtx.Unlock()
+		fss.pruning = false
+		fss.pruneErr = err
+	}()
+	return nil
 }
@@ -0,0 +1,79 @@
+package versiondb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cosmossdk.io/store/types"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingPruner is a version store which records the pruning runs, each run blocks until released.
+type blockingPruner struct {
+	VersionStore
+
+	started chan int64
+	release chan error
+}
+
+func (p *blockingPruner) PutAtVersion(int64, []*types.StoreKVPair) error {
+	return nil
+}
+
+func (p *blockingPruner) PruneBefore(version int64, _ []string, _ bool) error {
+	p.started <- version
+	return <-p.release
+}
+
+func TestStreamingServicePrune(t *testing.T) {
+	pruner := &blockingPruner{started: make(chan int64, 1), release: make(chan error)}
+	service := NewStreamingService(pruner)
+	service.SetRetainBlocks(10)
+	service.SetPruneInterval(5)
+
+	commit := func(version int64) error {
+		require.NoError(t, service.ListenFinalizeBlock(context.Background(), abci.RequestFinalizeBlock{Height: version}, abci.ResponseFinalizeBlock{}))
+		return service.ListenCommit(context.Background(), abci.ResponseCommit{}, nil)
+	}
+
+	// not triggered before the retained blocks are exceeded, or off the interval
+	for version := int64(1); version <= 14; version++ {
+		require.NoError(t, commit(version))
+	}
+	require.Empty(t, pruner.started)
+
+	require.NoError(t, commit(15))
+	require.Equal(t, int64(5), <-pruner.started)
+
+	// skipped while the previous pruning is running
+	for version := int64(16); version <= 20; version++ {
+		require.NoError(t, commit(version))
+	}
+	require.Empty(t, pruner.started)
+
+	// the error of the background pruning is returned in the next commit
+	pruneErr := errors.New("prune failed")
+	pruner.release <- pruneErr
+	service.pruneWG.Wait()
+	require.ErrorIs(t, commit(21), pruneErr)
+
+	// the next run is triggered at the interval again, close waits for it and returns its error
+	for version := int64(22); version <= 25; version++ {
+		require.NoError(t, commit(version))
+	}
+	require.Equal(t, int64(15), <-pruner.started)
+	closed := make(chan error)
+	go func() {
+		closed <- service.Close()
+	}()
+	select {
+	case <-closed:
+		t.Fatal("close returned before the pruning finished")
+	case <-time.After(100 * time.Millisecond):
+	}
+	pruner.release <- pruneErr
+	require.ErrorIs(t, <-closed, pruneErr)
+}
@@ -0,0 +1,48 @@
+package tsleveldb
+
+import (
+	"bytes"
+
+	"github.com/syndtr/goleveldb/leveldb/comparer"
+)
+
+// tsComparer orders the internal keys by the user key first, then by the timestamp suffix, the timestamp is encoded
+// as the bitwise not of the big endian version, so larger (newer) versions come first, which means a seek operation
+// finds the newest version less than or equal to the target version.
+//
+// The plain bytewise order don't work with the `key||^version` encoding, because a user key could be the prefix of
+// another one.
+type tsComparer struct{}
+
+var _ comparer.Comparer = tsComparer{}
+
+func (tsComparer) Name() string {
+	return "versiondb.BytewiseComparator.u64ts"
+}
+
+func (tsComparer) Compare(a, b []byte) int {
+	userA, tsA := splitTS(a)
+	userB, tsB := splitTS(b)
+	if ret := bytes.Compare(userA, userB); ret != 0 {
+		return ret
+	}
+	return bytes.Compare(tsA, tsB)
+}
+
+// Separator don't shorten the keys, because the result must still carry a valid timestamp suffix.
+func (tsComparer) Separator(_, _, _ []byte) []byte {
+	return nil
+}
+
+// Successor don't shorten the keys, because the result must still carry a valid timestamp suffix.
+func (tsComparer) Successor(_, _ []byte) []byte {
+	return nil
+}
+
+// splitTS splits the internal key into user key and timestamp.
+func splitTS(key []byte) ([]byte, []byte) {
+	if len(key) < TimestampSize {
+		return key, nil
+	}
+	return key[:len(key)-TimestampSize], key[len(key)-TimestampSize:]
+}
@@ -0,0 +1,173 @@
+package tsleveldb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+
+	"github.com/crypto-org-chain/cronos/versiondb"
+)
+
+// levelDBIterator iterates the newest version not newer than the target version of each user key in the range,
+// the deleted keys are skipped. The source iterator is always moved past the current user key, so it's positioned
+// at the candidate of the next one.
+type levelDBIterator struct {
+	source             iterator.Iterator
+	prefix, start, end []byte
+	version            uint64
+	isReverse          bool
+	isInvalid          bool
+
+	// current entry
+	key, value []byte
+	timestamp  uint64
+}
+
+var _ versiondb.Iterator = (*levelDBIterator)(nil)
+
+func newLevelDBIterator(source iterator.Iterator, prefix, start, end []byte, version uint64, isReverse bool) *levelDBIterator {
+	if isReverse {
+		if end == nil {
+			source.Last()
+		} else if source.Seek(encodeKey(end, math.MaxUint64)) {
+			source.Prev()
+		} else {
+			source.Last()
+		}
+	} else {
+		if start == nil {
+			source.First()
+		} else {
+			source.Seek(encodeKey(start, math.MaxUint64))
+		}
+	}
+	it := &levelDBIterator{
+		source:    source,
+		prefix:    prefix,
+		start:     start,
+		end:       end,
+		version:   version,
+		isReverse: isReverse,
+	}
+	it.settle()
+	return it
+}
+
+// Domain implements Iterator.
+func (itr *levelDBIterator) Domain() ([]byte, []byte) {
+	return itr.start, itr.end
+}
+
+// Valid implements Iterator.
+func (itr *levelDBIterator) Valid() bool {
+	return !itr.isInvalid
+}
+
+// Timestamp implements Iterator, it's little endian encoded, same as the tsrocksdb backend.
+func (itr *levelDBIterator) Timestamp() []byte {
+	itr.assertIsValid()
+	var ts [TimestampSize]byte
+	binary.LittleEndian.PutUint64(ts[:], itr.timestamp)
+	return ts[:]
+}
+
+// Key implements Iterator.
+func (itr *levelDBIterator) Key() []byte {
+	itr.assertIsValid()
+	return itr.key[len(itr.prefix):]
+}
+
+// Value implements Iterator.
+func (itr *levelDBIterator) Value() []byte {
+	itr.assertIsValid()
+	return itr.value
+}
+
+// Next implements Iterator.
+func (itr *levelDBIterator) Next() {
+	itr.assertIsValid()
+	itr.settle()
+}
+
+// Error implements Iterator.
+func (itr *levelDBIterator) Error() error {
+	return itr.source.Error()
+}
+
+// Close implements Iterator.
+func (itr *levelDBIterator) Close() error {
+	itr.source.Release()
+	return nil
+}
+
+func (itr *levelDBIterator) assertIsValid() {
+	if !itr.Valid() {
+		panic("iterator is invalid")
+	}
+}
+
+// settle finds the next visible user key from the current position of the source iterator.
+func (itr *levelDBIterator) settle() {
+	for {
+		if !itr.source.Valid() {
+			itr.isInvalid = true
+			return
+		}
+
+		userKey, _ := splitTS(itr.source.Key())
+		userKey = bytes.Clone(userKey)
+		if itr.isReverse {
+			if itr.start != nil && bytes.Compare(userKey, itr.start) < 0 {
+				itr.isInvalid = true
+				return
+			}
+		} else {
+			if itr.end != nil && bytes.Compare(userKey, itr.end) >= 0 {
+				itr.isInvalid = true
+				return
+			}
+		}
+
+		// seek to the newest version not newer than the target version
+		var (
+			value  []byte
+			exists bool
+			ts     uint64
+		)
+		if itr.source.Seek(encodeKey(userKey, itr.version)) {
+			foundKey, foundTS := splitTS(itr.source.Key())
+			if bytes.Equal(foundKey, userKey) {
+				value, exists = decodeValue(itr.source.Value())
+				ts = decodeTS(foundTS)
+			}
+		}
+
+		itr.skip(userKey)
+
+		if exists {
+			itr.key, itr.value, itr.timestamp = userKey, value, ts
+			return
+		}
+	}
+}
+
+// skip moves the source iterator past all the versions of the user key, in the iteration direction.
+func (itr *levelDBIterator) skip(userKey []byte) {
+	if itr.isReverse {
+		// the newest version is the first entry of the user key
+		if itr.source.Seek(encodeKey(userKey, math.MaxUint64)) {
+			itr.source.Prev()
+		}
+		return
+	}
+
+	// the version 0 is the last possible entry of the user key
+	if itr.source.Seek(encodeKey(userKey, 0)) {
+		foundKey, _ := splitTS(itr.source.Key())
+		if bytes.Equal(foundKey, userKey) {
+			itr.source.Next()
+		}
+	}
+}
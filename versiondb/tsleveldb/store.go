@@ -0,0 +1,888 @@
+package tsleveldb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"cosmossdk.io/store/types"
+	"github.com/cosmos/iavl"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/crypto-org-chain/cronos/versiondb"
+)
+
+const (
+	TimestampSize = 8
+
+	StorePrefixTpl   = "s/k:%s/"
+	latestVersionKey = "s/latest"
+	// the common prefix of all the stores
+	storesPrefix = "s/k:"
+
+	IndexPrefixTpl = "i/k:%s/"
+	// the common prefix of all the indexes
+	indexesPrefix = "i/k:"
+	// the first version of the index entries, the index is not available before it.
+	indexStartVersionKeyTpl = "s/index:%s"
+	// the last version written with the index, the index is rebuilt if it's behind the latest version.
+	indexedVersionKeyTpl = "s/indexed:%s"
+	// the versions before which the history is pruned, for all the stores or for a single store.
+	prunedVersionKey       = "s/pruned"
+	prunedVersionKeyPrefix = "s/pruned:"
+	// the first version of which the changed keys are recorded, for the incremental pruning.
+	changesSinceKey = "s/changes"
+	// the changed keys of each version, `changesPrefix||version||key`, the version is big endian, so the
+	// entries are ordered by version.
+	changesPrefix = "c/"
+
+	ImportCommitBatchSize = 10000
+)
+
+const (
+	// the first byte of the stored values, distinguish the deletion markers from the empty values.
+	valueDeleted byte = iota
+	valueExists
+)
+
+var (
+	errKeyEmpty = errors.New("key cannot be empty")
+
+	_ versiondb.VersionStore  = Store{}
+	_ versiondb.HistoryPruner = Store{}
+	_ versiondb.IndexedStore  = Store{}
+
+	defaultSyncWriteOpts = &opt.WriteOptions{Sync: true}
+)
+
+// Store implements `versiondb.VersionStore` on goleveldb, it don't need cgo.
+// Each version of a key is stored as a separate entry with the key `storePrefix||key||^version`,
+// the `tsComparer` makes the newer versions of the same user key come first, so the point lookups and iterations
+// at a version are done with seek operations.
+type Store struct {
+	db *leveldb.DB
+
+	// the secondary indexes maintained in `PutAtVersion`, stored under a separate key prefix.
+	indexes           versiondb.Indexes
+	indexStartVersion map[string]int64
+
+	pruned *prunedVersions
+}
+
+// prunedVersions caches the persisted pruned versions, the queries before them are rejected.
+type prunedVersions struct {
+	mtx sync.RWMutex
+	// the pruned version of all the stores
+	all    int64
+	stores map[string]int64
+	// the changed keys are recorded since the version, after all the stores are pruned the first time,
+	// so the following prunings only visit the keys changed after the previous one, 0 means not started.
+	changesSince int64
+}
+
+// get returns the pruned version of the store.
+func (p *prunedVersions) get(store string) int64 {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	return max(p.all, p.stores[store])
+}
+
+// tracking returns if the changed keys are being recorded.
+func (p *prunedVersions) tracking() bool {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	return p.changesSince > 0
+}
+
+// trackChanges returns if the changed keys of the version should be recorded, it starts the recording in the
+// batch if all the stores are pruned but it's not started yet.
+func (p *prunedVersions) trackChanges(batch *leveldb.Batch, version int64) bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.all == 0 {
+		return false
+	}
+	if p.changesSince == 0 {
+		var ts [TimestampSize]byte
+		binary.LittleEndian.PutUint64(ts[:], uint64(version))
+		batch.Put(encodeKey([]byte(changesSinceKey), 0), ts[:])
+		p.changesSince = version
+	}
+	return true
+}
+
+func NewStore(dir string) (Store, error) {
+	db, err := OpenVersionDB(dir)
+	if err != nil {
+		return Store{}, err
+	}
+	s, err := NewStoreWithDB(db)
+	if err != nil {
+		return Store{}, errors.Join(err, db.Close())
+	}
+	return s, nil
+}
+
+// NewStoreWithDB creates the store with the opened db, it loads the pruned versions.
+func NewStoreWithDB(db *leveldb.DB) (Store, error) {
+	s := Store{db: db, pruned: &prunedVersions{stores: make(map[string]int64)}}
+	if err := s.loadPrunedVersions(); err != nil {
+		return Store{}, err
+	}
+	return s, nil
+}
+
+func (s Store) loadPrunedVersions() error {
+	bz, err := s.db.Get(encodeKey([]byte(prunedVersionKey), 0), nil)
+	switch {
+	case err == nil:
+		s.pruned.all = int64(binary.LittleEndian.Uint64(bz))
+	case !errors.Is(err, leveldb.ErrNotFound):
+		return err
+	}
+	bz, err = s.db.Get(encodeKey([]byte(changesSinceKey), 0), nil)
+	switch {
+	case err == nil:
+		s.pruned.changesSince = int64(binary.LittleEndian.Uint64(bz))
+	case !errors.Is(err, leveldb.ErrNotFound):
+		return err
+	}
+
+	prefix := []byte(prunedVersionKeyPrefix)
+	it := s.db.NewIterator(&util.Range{
+		Start: encodeKey(prefix, math.MaxUint64),
+		Limit: encodeKey(cpIncr(prefix), math.MaxUint64),
+	}, nil)
+	defer it.Release()
+	for ok := it.First(); ok; ok = it.Next() {
+		key, _ := splitTS(it.Key())
+		s.pruned.stores[string(key[len(prefix):])] = int64(binary.LittleEndian.Uint64(it.Value()))
+	}
+	return it.Error()
+}
+
+// checkPruned rejects the queries of the store before the pruned version.
+func (s Store) checkPruned(storeKey string, version int64) error {
+	if pruned := s.pruned.get(storeKey); version < pruned {
+		return fmt.Errorf("%w: store %s is pruned before version %d", versiondb.ErrVersionPruned, storeKey, pruned)
+	}
+	return nil
+}
+
+// NewStoreWithIndexes opens the store with the secondary indexes, the newly added indexes, or the ones which missed
+// some versions written without them, are rebuilt at the latest version, and available from it.
+func NewStoreWithIndexes(dir string, indexes versiondb.Indexes) (Store, error) {
+	if err := indexes.Validate(); err != nil {
+		return Store{}, err
+	}
+	s, err := NewStore(dir)
+	if err != nil {
+		return Store{}, err
+	}
+	if err := s.initIndexes(indexes); err != nil {
+		return Store{}, errors.Join(err, s.Close())
+	}
+	return s, nil
+}
+
+// initIndexes loads the start versions of the indexes, rebuilds the new ones and the ones behind the latest version.
+func (s *Store) initIndexes(indexes versiondb.Indexes) error {
+	latest, err := s.GetLatestVersion()
+	if err != nil {
+		return err
+	}
+
+	s.indexes = indexes
+	s.indexStartVersion = make(map[string]int64, len(indexes))
+	for _, idx := range indexes {
+		start, found, err := s.getVersionKey(fmt.Sprintf(indexStartVersionKeyTpl, idx.Name))
+		if err != nil {
+			return err
+		}
+		indexed, indexedFound, err := s.getVersionKey(fmt.Sprintf(indexedVersionKeyTpl, idx.Name))
+		if err != nil {
+			return err
+		}
+		if found && indexedFound && indexed >= latest {
+			s.indexStartVersion[idx.Name] = start
+			continue
+		}
+
+		if err := s.rebuildIndex(idx, latest); err != nil {
+			return fmt.Errorf("rebuild index %s failed: %w", idx.Name, err)
+		}
+		s.indexStartVersion[idx.Name] = latest
+	}
+	return nil
+}
+
+// getVersionKey reads a version stored in plain state, returns false if the key don't exists.
+func (s Store) getVersionKey(key string) (int64, bool, error) {
+	bz, err := s.db.Get(encodeKey([]byte(key), 0), nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return int64(binary.LittleEndian.Uint64(bz)), true, nil
+}
+
+// rebuildIndex rewrites the entries of the index at the version from the key-value pairs of the store, the stale
+// entries are deleted at the same version, the index is available from the version.
+func (s Store) rebuildIndex(idx versiondb.Index, version int64) error {
+	batch := new(leveldb.Batch)
+	writeBatch := func(force bool) error {
+		if batch.Len() == 0 || (!force && batch.Len() < ImportCommitBatchSize) {
+			return nil
+		}
+		if err := s.db.Write(batch, nil); err != nil {
+			return err
+		}
+		batch.Reset()
+		return nil
+	}
+
+	// the index entries shadow the old ones, they are recorded if the recording is started
+	track := s.pruned.tracking()
+	prefix := indexPrefix(idx.Name)
+	if err := s.scanAtVersion(prefix, version, func(key, _ []byte) error {
+		putPair(batch, prefix, &types.StoreKVPair{Key: key, Delete: true}, uint64(version), track)
+		return writeBatch(false)
+	}); err != nil {
+		return err
+	}
+
+	indexes := versiondb.Indexes{idx}
+	if err := s.scanAtVersion(storePrefix(idx.Store), version, func(key, value []byte) error {
+		for _, entry := range indexes.ImportEntries(idx.Store, key, value) {
+			putPair(batch, indexPrefix(entry.StoreKey), entry, uint64(version), track)
+		}
+		return writeBatch(false)
+	}); err != nil {
+		return err
+	}
+	if err := writeBatch(true); err != nil {
+		return err
+	}
+
+	var ts [TimestampSize]byte
+	binary.LittleEndian.PutUint64(ts[:], uint64(version))
+	batch.Put(encodeKey([]byte(fmt.Sprintf(indexStartVersionKeyTpl, idx.Name)), 0), ts[:])
+	batch.Put(encodeKey([]byte(fmt.Sprintf(indexedVersionKeyTpl, idx.Name)), 0), ts[:])
+	return s.db.Write(batch, defaultSyncWriteOpts)
+}
+
+// scanAtVersion calls fn with the key-value pairs under the prefix at the version, the prefix is stripped.
+func (s Store) scanAtVersion(prefix []byte, version int64, fn func(key, value []byte) error) error {
+	start, end := iterateWithPrefix(prefix, nil, nil)
+	it := newLevelDBIterator(s.db.NewIterator(nil, nil), prefix, start, end, uint64(version), false)
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		if err := fn(it.Key(), it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// OpenVersionDB opens the goleveldb database with the timestamp comparator.
+func OpenVersionDB(dir string) (*leveldb.DB, error) {
+	return leveldb.OpenFile(dir, &opt.Options{
+		Comparer: tsComparer{},
+	})
+}
+
+func (s Store) SetLatestVersion(version int64) error {
+	var ts [TimestampSize]byte
+	binary.LittleEndian.PutUint64(ts[:], uint64(version))
+	return s.db.Put(encodeKey([]byte(latestVersionKey), 0), ts[:], defaultSyncWriteOpts)
+}
+
+// setIndexedVersion records the version is written with the indexes.
+func (s Store) setIndexedVersion(version int64) error {
+	if len(s.indexes) == 0 {
+		return nil
+	}
+	var ts [TimestampSize]byte
+	binary.LittleEndian.PutUint64(ts[:], uint64(version))
+	batch := new(leveldb.Batch)
+	for _, idx := range s.indexes {
+		batch.Put(encodeKey([]byte(fmt.Sprintf(indexedVersionKeyTpl, idx.Name)), 0), ts[:])
+	}
+	return s.db.Write(batch, nil)
+}
+
+// PutAtVersion implements VersionStore interface
+func (s Store) PutAtVersion(version int64, changeSet []*types.StoreKVPair) error {
+	var ts [TimestampSize]byte
+	binary.LittleEndian.PutUint64(ts[:], uint64(version))
+
+	batch := new(leveldb.Batch)
+	batch.Put(encodeKey([]byte(latestVersionKey), 0), ts[:])
+
+	track := s.pruned.trackChanges(batch, version)
+	for _, pair := range changeSet {
+		putPair(batch, storePrefix(pair.StoreKey), pair, uint64(version), track)
+	}
+
+	// the index changes are computed against the latest values before the change set
+	entries, err := s.indexes.Changes(changeSet, func(storeKey string, key []byte) ([]byte, error) {
+		return s.GetAtVersion(storeKey, key, nil)
+	})
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		putPair(batch, indexPrefix(entry.StoreKey), entry, uint64(version), track)
+	}
+	for _, idx := range s.indexes {
+		batch.Put(encodeKey([]byte(fmt.Sprintf(indexedVersionKeyTpl, idx.Name)), 0), ts[:])
+	}
+
+	return s.db.Write(batch, defaultSyncWriteOpts)
+}
+
+// FeedChangeSet writes the change set of a store at the version without changing the latest version,
+// it's used to migrate legacy change sets or repair the data, the secondary indexes are not maintained.
+func (s Store) FeedChangeSet(version int64, store string, changeSet *iavl.ChangeSet) error {
+	prefix := storePrefix(store)
+
+	batch := new(leveldb.Batch)
+	track := s.pruned.trackChanges(batch, version)
+	for _, pair := range changeSet.Pairs {
+		putPair(batch, prefix, &types.StoreKVPair{Key: pair.Key, Value: pair.Value, Delete: pair.Delete}, uint64(version), track)
+	}
+
+	return s.db.Write(batch, nil)
+}
+
+// putPair writes the pair at the version, and records the changed key if `track` is set.
+func putPair(batch *leveldb.Batch, prefix []byte, pair *types.StoreKVPair, version uint64, track bool) {
+	userKey := cloneAppend(prefix, pair.Key)
+	key := encodeKey(userKey, version)
+	if pair.Delete {
+		batch.Put(key, []byte{valueDeleted})
+	} else {
+		batch.Put(key, encodeValue(pair.Value))
+	}
+	if track {
+		batch.Put(encodeKey(changeKey(version, userKey), 0), nil)
+	}
+}
+
+// GetAtVersion implements VersionStore interface
+func (s Store) GetAtVersion(storeKey string, key []byte, version *int64) ([]byte, error) {
+	value, _, err := s.getAtVersion(storeKey, key, version)
+	return value, err
+}
+
+// HasAtVersion implements VersionStore interface
+func (s Store) HasAtVersion(storeKey string, key []byte, version *int64) (bool, error) {
+	_, found, err := s.getAtVersion(storeKey, key, version)
+	return found, err
+}
+
+// getAtVersion finds the newest entry of the key not newer than the version.
+func (s Store) getAtVersion(storeKey string, key []byte, version *int64) ([]byte, bool, error) {
+	if version != nil {
+		if err := s.checkPruned(storeKey, *version); err != nil {
+			return nil, false, err
+		}
+	}
+	userKey := prependStoreKey(storeKey, key)
+
+	it := s.db.NewIterator(nil, nil)
+	defer it.Release()
+
+	if !it.Seek(encodeKey(userKey, readVersion(version))) {
+		return nil, false, it.Error()
+	}
+	foundKey, _ := splitTS(it.Key())
+	if string(foundKey) != string(userKey) {
+		return nil, false, nil
+	}
+	value, exists := decodeValue(it.Value())
+	return value, exists, nil
+}
+
+// KeyHistory implements VersionStore interface, the versions of the key are ordered descendingly, so it seeks to the
+// oldest one not older than `fromVersion`, and scans backward until the versions newer than `toVersion` or the limit.
+func (s Store) KeyHistory(storeKey string, key []byte, fromVersion, toVersion int64, limit int) ([]versiondb.KeyChange, error) {
+	if len(key) == 0 {
+		return nil, errKeyEmpty
+	}
+	if fromVersion < 0 || fromVersion > toVersion {
+		return nil, fmt.Errorf("invalid version range: [%d, %d]", fromVersion, toVersion)
+	}
+	if err := s.checkPruned(storeKey, fromVersion); err != nil {
+		return nil, err
+	}
+	userKey := prependStoreKey(storeKey, key)
+
+	it := s.db.NewIterator(nil, nil)
+	defer it.Release()
+
+	// the seek finds the newest version not newer than `fromVersion`, the previous one is the oldest version newer
+	// than it if it's not equal.
+	ok := it.Seek(encodeKey(userKey, uint64(fromVersion)))
+	if !ok {
+		ok = it.Last()
+	} else if foundKey, ts := splitTS(it.Key()); !bytes.Equal(foundKey, userKey) || decodeTS(ts) != uint64(fromVersion) {
+		ok = it.Prev()
+	}
+
+	var changes []versiondb.KeyChange
+	for ; ok; ok = it.Prev() {
+		foundKey, ts := splitTS(it.Key())
+		if !bytes.Equal(foundKey, userKey) {
+			break
+		}
+		version := decodeTS(ts)
+		if version > uint64(toVersion) {
+			break
+		}
+		value, exists := decodeValue(it.Value())
+		changes = append(changes, versiondb.KeyChange{Version: int64(version), Value: value, Deleted: !exists})
+		if limit > 0 && len(changes) >= limit {
+			break
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// GetLatestVersion returns the latest version stored in plain state,
+// it's committed after the changesets, so the data for this version is guaranteed to be persisted.
+// returns 0 if the key don't exists.
+func (s Store) GetLatestVersion() (int64, error) {
+	bz, err := s.db.Get(encodeKey([]byte(latestVersionKey), 0), nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return int64(binary.LittleEndian.Uint64(bz)), nil
+}
+
+// IteratorAtVersion implements VersionStore interface
+func (s Store) IteratorAtVersion(storeKey string, start, end []byte, version *int64) (versiondb.Iterator, error) {
+	return s.iteratorAtVersion(storeKey, start, end, version, false)
+}
+
+// ReverseIteratorAtVersion implements VersionStore interface
+func (s Store) ReverseIteratorAtVersion(storeKey string, start, end []byte, version *int64) (versiondb.Iterator, error) {
+	return s.iteratorAtVersion(storeKey, start, end, version, true)
+}
+
+func (s Store) iteratorAtVersion(storeKey string, start, end []byte, version *int64, reverse bool) (versiondb.Iterator, error) {
+	if (start != nil && len(start) == 0) || (end != nil && len(end) == 0) {
+		return nil, errKeyEmpty
+	}
+	if version != nil {
+		if err := s.checkPruned(storeKey, *version); err != nil {
+			return nil, err
+		}
+	}
+
+	prefix := storePrefix(storeKey)
+	start, end = iterateWithPrefix(prefix, start, end)
+
+	return newLevelDBIterator(s.db.NewIterator(nil, nil), prefix, start, end, readVersion(version), reverse), nil
+}
+
+// IndexIteratorAtVersion implements IndexedStore interface
+func (s Store) IndexIteratorAtVersion(index string, start, end []byte, version *int64) (versiondb.Iterator, error) {
+	if (start != nil && len(start) == 0) || (end != nil && len(end) == 0) {
+		return nil, errKeyEmpty
+	}
+	startVersion, ok := s.indexStartVersion[index]
+	if !ok {
+		return nil, fmt.Errorf("index not found: %s", index)
+	}
+	if version != nil && *version < startVersion {
+		return nil, fmt.Errorf("%w: index %s starts at version %d", versiondb.ErrIndexNotAvailable, index, startVersion)
+	}
+	if version != nil {
+		// the indexes are pruned together with the stores
+		for _, idx := range s.indexes {
+			if idx.Name != index {
+				continue
+			}
+			if err := s.checkPruned(idx.Store, *version); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	prefix := indexPrefix(index)
+	start, end = iterateWithPrefix(prefix, start, end)
+
+	return newLevelDBIterator(s.db.NewIterator(nil, nil), prefix, start, end, readVersion(version), false), nil
+}
+
+// Import loads the initial version of the state
+func (s Store) Import(version int64, ch <-chan versiondb.ImportEntry) error {
+	batch := new(leveldb.Batch)
+
+	var counter int
+	for entry := range ch {
+		key := encodeKey(prependStoreKey(entry.StoreKey, entry.Key), uint64(version))
+		batch.Put(key, encodeValue(entry.Value))
+		for _, indexEntry := range s.indexes.ImportEntries(entry.StoreKey, entry.Key, entry.Value) {
+			putPair(batch, indexPrefix(indexEntry.StoreKey), indexEntry, uint64(version), false)
+		}
+
+		counter++
+		if counter%ImportCommitBatchSize == 0 {
+			if err := s.db.Write(batch, nil); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+
+	if batch.Len() > 0 {
+		if err := s.db.Write(batch, nil); err != nil {
+			return err
+		}
+	}
+
+	if err := s.setIndexedVersion(version); err != nil {
+		return err
+	}
+	return s.SetLatestVersion(version)
+}
+
+// PruneBefore implements HistoryPruner interface, it deletes the versions of each key shadowed by the newest one not
+// newer than the target version, the newest one is also deleted if it's a deletion marker. The pruned version is
+// persisted before the deletion, the queries before it are rejected.
+//
+// The changed keys are recorded after all the stores are pruned the first time, so the following prunings of all
+// the stores only visit the keys changed after the previous pruned version, the other keys have at most one version
+// before it already.
+func (s Store) PruneBefore(version int64, stores []string, compact bool) error {
+	s.pruned.mtx.RLock()
+	prevPruned, changesSince := s.pruned.all, s.pruned.changesSince
+	s.pruned.mtx.RUnlock()
+
+	if err := s.setPrunedVersion(version, stores); err != nil {
+		return err
+	}
+
+	if len(stores) == 0 && version > prevPruned && changesSince > 0 && changesSince <= prevPruned+1 {
+		if err := s.pruneChanges(uint64(version)); err != nil {
+			return err
+		}
+		if compact {
+			for _, prefix := range []string{storesPrefix, indexesPrefix} {
+				if err := s.db.CompactRange(util.Range{
+					Start: encodeKey([]byte(prefix), math.MaxUint64),
+					Limit: encodeKey(cpIncr([]byte(prefix)), math.MaxUint64),
+				}); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	prefixes := make([][]byte, 0, len(stores))
+	for _, store := range stores {
+		prefixes = append(prefixes, storePrefix(store))
+		// the indexes on the store
+		for _, idx := range s.indexes {
+			if idx.Store == store {
+				prefixes = append(prefixes, indexPrefix(idx.Name))
+			}
+		}
+	}
+	if len(prefixes) == 0 {
+		// all the stores and indexes
+		prefixes = append(prefixes, []byte(storesPrefix), []byte(indexesPrefix))
+	}
+
+	for _, prefix := range prefixes {
+		r := &util.Range{
+			Start: encodeKey(prefix, math.MaxUint64),
+			Limit: encodeKey(cpIncr(prefix), math.MaxUint64),
+		}
+		if err := s.pruneRange(r, uint64(version)); err != nil {
+			return err
+		}
+		if compact {
+			if err := s.db.CompactRange(*r); err != nil {
+				return err
+			}
+		}
+	}
+	if len(stores) == 0 {
+		// the recorded changes are covered by the full pruning
+		return s.pruneChangesWith(uint64(version), nil)
+	}
+	return nil
+}
+
+// pruneChanges prunes the keys changed not after the version, and deletes the records of them.
+func (s Store) pruneChanges(version uint64) error {
+	it := s.db.NewIterator(nil, nil)
+	defer it.Release()
+
+	return s.pruneChangesWith(version, func(batch *leveldb.Batch, key []byte) {
+		// the versions of the key are contiguous, starts from the newest one not newer than the target version
+		found := false
+		for ok := it.Seek(encodeKey(key, version)); ok; ok = it.Next() {
+			userKey, _ := splitTS(it.Key())
+			if !bytes.Equal(userKey, key) {
+				break
+			}
+			if found {
+				batch.Delete(it.Key())
+				continue
+			}
+			found = true
+			if _, exists := decodeValue(it.Value()); !exists {
+				batch.Delete(it.Key())
+			}
+		}
+	})
+}
+
+// pruneChangesWith iterates the changed keys recorded not after the version, calls fn to prune each of them and
+// deletes the records in the same batches.
+func (s Store) pruneChangesWith(version uint64, fn func(batch *leveldb.Batch, key []byte)) error {
+	it := s.db.NewIterator(&util.Range{
+		Start: encodeKey([]byte(changesPrefix), math.MaxUint64),
+		Limit: encodeKey(changeKey(version+1, nil), math.MaxUint64),
+	}, nil)
+	defer it.Release()
+
+	batch := new(leveldb.Batch)
+	for ok := it.First(); ok; ok = it.Next() {
+		key, _ := splitTS(it.Key())
+		if fn != nil {
+			fn(batch, key[len(changesPrefix)+TimestampSize:])
+		}
+		batch.Delete(it.Key())
+
+		if batch.Len() >= ImportCommitBatchSize {
+			if err := s.db.Write(batch, nil); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	if batch.Len() > 0 {
+		return s.db.Write(batch, defaultSyncWriteOpts)
+	}
+	return nil
+}
+
+// setPrunedVersion persists the pruned version of the stores, empty stores means all the stores, it's not decreased.
+func (s Store) setPrunedVersion(version int64, stores []string) error {
+	s.pruned.mtx.Lock()
+	defer s.pruned.mtx.Unlock()
+
+	var ts [TimestampSize]byte
+	binary.LittleEndian.PutUint64(ts[:], uint64(version))
+
+	batch := new(leveldb.Batch)
+	if len(stores) == 0 {
+		if version <= s.pruned.all {
+			return nil
+		}
+		batch.Put(encodeKey([]byte(prunedVersionKey), 0), ts[:])
+	}
+	for _, store := range stores {
+		if version > s.pruned.stores[store] {
+			batch.Put(encodeKey([]byte(prunedVersionKeyPrefix+store), 0), ts[:])
+		}
+	}
+	if err := s.db.Write(batch, defaultSyncWriteOpts); err != nil {
+		return err
+	}
+
+	if len(stores) == 0 {
+		s.pruned.all = version
+	}
+	for _, store := range stores {
+		s.pruned.stores[store] = max(s.pruned.stores[store], version)
+	}
+	return nil
+}
+
+func (s Store) pruneRange(r *util.Range, version uint64) error {
+	it := s.db.NewIterator(r, nil)
+	defer it.Release()
+
+	batch := new(leveldb.Batch)
+	var (
+		userKey []byte
+		// if the newest version not newer than the target version is found for current user key
+		found bool
+	)
+	for ok := it.First(); ok; ok = it.Next() {
+		key, ts := splitTS(it.Key())
+		if !bytes.Equal(key, userKey) {
+			userKey = bytes.Clone(key)
+			found = false
+		}
+		if decodeTS(ts) > version {
+			continue
+		}
+
+		if found {
+			batch.Delete(it.Key())
+		} else {
+			found = true
+			if _, exists := decodeValue(it.Value()); !exists {
+				batch.Delete(it.Key())
+			}
+		}
+
+		if batch.Len() >= ImportCommitBatchSize {
+			if err := s.db.Write(batch, nil); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	if batch.Len() > 0 {
+		return s.db.Write(batch, defaultSyncWriteOpts)
+	}
+	return nil
+}
+
+// Flush implements VersionStore interface, the writes of goleveldb are persisted in the journal already,
+// only sync it to make sure the imported data is on disk.
+func (s Store) Flush() error {
+	return s.db.Write(new(leveldb.Batch), defaultSyncWriteOpts)
+}
+
+func (s Store) Close() error {
+	return s.db.Close()
+}
+
+// readVersion returns the version to read at, `nil` means the latest version.
+func readVersion(version *int64) uint64 {
+	if version == nil {
+		return math.MaxUint64
+	}
+	return uint64(*version)
+}
+
+// encodeKey appends the timestamp suffix to the user key, the bitwise not makes newer versions come first in
+// bytewise order.
+func encodeKey(key []byte, version uint64) []byte {
+	res := make([]byte, len(key)+TimestampSize)
+	copy(res, key)
+	binary.BigEndian.PutUint64(res[len(key):], ^version)
+	return res
+}
+
+// decodeTS returns the version from the timestamp suffix.
+func decodeTS(ts []byte) uint64 {
+	return ^binary.BigEndian.Uint64(ts)
+}
+
+func encodeValue(value []byte) []byte {
+	res := make([]byte, len(value)+1)
+	res[0] = valueExists
+	copy(res[1:], value)
+	return res
+}
+
+// decodeValue returns a copy of the value, and false if it's a deletion marker.
+func decodeValue(bz []byte) ([]byte, bool) {
+	if len(bz) == 0 || bz[0] == valueDeleted {
+		return nil, false
+	}
+	value := make([]byte, len(bz)-1)
+	copy(value, bz[1:])
+	return value, true
+}
+
+// changeKey returns the record of the key changed at the version, without the timestamp suffix.
+func changeKey(version uint64, key []byte) []byte {
+	res := make([]byte, 0, len(changesPrefix)+TimestampSize+len(key))
+	res = append(res, changesPrefix...)
+	res = binary.BigEndian.AppendUint64(res, version)
+	return append(res, key...)
+}
+
+func storePrefix(storeKey string) []byte {
+	return []byte(fmt.Sprintf(StorePrefixTpl, storeKey))
+}
+
+func indexPrefix(index string) []byte {
+	return []byte(fmt.Sprintf(IndexPrefixTpl, index))
+}
+
+// prependStoreKey prepends storeKey to the key
+func prependStoreKey(storeKey string, key []byte) []byte {
+	return append(storePrefix(storeKey), key...)
+}
+
+func cloneAppend(bz []byte, tail []byte) (res []byte) {
+	res = make([]byte, len(bz)+len(tail))
+	copy(res, bz)
+	copy(res[len(bz):], tail)
+	return
+}
+
+// Returns a slice of the same length (big endian)
+// except incremented by one.
+// Returns nil on overflow (e.g. if bz bytes are all 0xFF)
+// CONTRACT: len(bz) > 0
+func cpIncr(bz []byte) (ret []byte) {
+	if len(bz) == 0 {
+		panic("cpIncr expects non-zero bz length")
+	}
+	ret = make([]byte, len(bz))
+	copy(ret, bz)
+	for i := len(bz) - 1; i >= 0; i-- {
+		if ret[i] < byte(0xFF) {
+			ret[i]++
+			return
+		}
+		ret[i] = byte(0x00)
+		if i == 0 {
+			// Overflow
+			return nil
+		}
+	}
+	return nil
+}
+
+// iterateWithPrefix calculate the acual iterate range
+func iterateWithPrefix(prefix, begin, end []byte) ([]byte, []byte) {
+	if len(prefix) == 0 {
+		return begin, end
+	}
+
+	begin = cloneAppend(prefix, begin)
+
+	if end == nil {
+		end = cpIncr(prefix)
+	} else {
+		end = cloneAppend(prefix, end)
+	}
+
+	return begin, end
+}
@@ -0,0 +1,471 @@
+package tsleveldb
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"cosmossdk.io/store/types"
+	abci "github.com/cometbft/cometbft/abci/types"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/cosmos/iavl"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/crypto-org-chain/cronos/versiondb"
+)
+
+func TestTSVersionDB(t *testing.T) {
+	versiondb.Run(t, func() versiondb.VersionStore {
+		store, err := NewStore(t.TempDir())
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, store.Close())
+		})
+		return store
+	})
+}
+
+func TestIndexes(t *testing.T) {
+	versiondb.RunIndexes(t, func(indexes versiondb.Indexes) versiondb.VersionStore {
+		store, err := NewStoreWithIndexes(t.TempDir(), indexes)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, store.Close())
+		})
+		return store
+	})
+}
+
+// TestIndexRebuild tests the index added to an existing db, or missed some versions, is rebuilt at the latest version.
+func TestIndexRebuild(t *testing.T) {
+	dir := t.TempDir()
+	indexes := versiondb.Indexes{
+		{Name: "value", Store: "test", Extract: func(_, value []byte) [][]byte { return [][]byte{value} }},
+	}
+
+	store, err := NewStore(dir)
+	require.NoError(t, err)
+	require.NoError(t, store.PutAtVersion(1, []*types.StoreKVPair{
+		{StoreKey: "test", Key: []byte("a"), Value: []byte{1}},
+	}))
+	require.NoError(t, store.Close())
+
+	store, err = NewStoreWithIndexes(dir, indexes)
+	require.NoError(t, err)
+	require.NoError(t, store.PutAtVersion(2, []*types.StoreKVPair{
+		{StoreKey: "test", Key: []byte("b"), Value: []byte{1}},
+	}))
+
+	v := int64(0)
+	_, err = store.IndexIteratorAtVersion("value", nil, nil, &v)
+	require.ErrorIs(t, err, versiondb.ErrIndexNotAvailable)
+	v = 1
+	it, err := store.IndexIteratorAtVersion("value", nil, nil, &v)
+	require.NoError(t, err)
+	require.Equal(t, []kvPair{{[]byte{1, 1, 'a'}, []byte("a")}}, consumeIterator(it))
+
+	// the version is written without the index
+	require.NoError(t, store.Close())
+	store, err = NewStore(dir)
+	require.NoError(t, err)
+	require.NoError(t, store.PutAtVersion(3, []*types.StoreKVPair{
+		{StoreKey: "test", Key: []byte("a"), Value: []byte{2}},
+	}))
+	require.NoError(t, store.Close())
+
+	// the stale entry of "a" is deleted by the rebuild, the start version is persisted
+	for i := 0; i < 2; i++ {
+		store, err = NewStoreWithIndexes(dir, indexes)
+		require.NoError(t, err)
+
+		v = 2
+		_, err = store.IndexIteratorAtVersion("value", nil, nil, &v)
+		require.ErrorIs(t, err, versiondb.ErrIndexNotAvailable)
+		it, err = store.IndexIteratorAtVersion("value", nil, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, []kvPair{
+			{[]byte{1, 1, 'b'}, []byte("b")},
+			{[]byte{1, 2, 'a'}, []byte("a")},
+		}, consumeIterator(it))
+		require.NoError(t, store.Close())
+	}
+}
+
+func TestIndexQueryServer(t *testing.T) {
+	indexes := versiondb.Indexes{
+		// index by the first byte of the value
+		{Name: "value", Store: "test", Extract: func(_, value []byte) [][]byte { return [][]byte{value[:1]} }},
+	}
+	store, err := NewStoreWithIndexes(t.TempDir(), indexes)
+	require.NoError(t, err)
+	defer store.Close()
+
+	require.NoError(t, store.PutAtVersion(1, []*types.StoreKVPair{
+		{StoreKey: "test", Key: []byte("a"), Value: []byte("x1")},
+		{StoreKey: "test", Key: []byte("b"), Value: []byte("x2")},
+		{StoreKey: "test", Key: []byte("c"), Value: []byte("x3")},
+		{StoreKey: "test", Key: []byte("d"), Value: []byte("y1")},
+	}))
+	require.NoError(t, store.PutAtVersion(2, []*types.StoreKVPair{
+		{StoreKey: "test", Key: []byte("a"), Delete: true},
+		{StoreKey: "test", Key: []byte("b"), Value: []byte("x4")},
+	}))
+
+	server := versiondb.NewQueryServer(store, indexes)
+	ctx := context.Background()
+
+	// paginate at the historical version
+	res, err := server.IndexedKeys(ctx, &versiondb.QueryIndexedKeysRequest{Index: "value", Prefix: []byte("x"), Height: 1, Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, []versiondb.IndexedPair{
+		{IndexKey: []byte("x"), Key: []byte("a"), Value: []byte("x1")},
+		{IndexKey: []byte("x"), Key: []byte("b"), Value: []byte("x2")},
+	}, res.Pairs)
+	require.NotEmpty(t, res.NextKey)
+
+	res, err = server.IndexedKeys(ctx, &versiondb.QueryIndexedKeysRequest{Index: "value", Prefix: []byte("x"), Height: 1, Limit: 2, NextKey: res.NextKey})
+	require.NoError(t, err)
+	require.Equal(t, []versiondb.IndexedPair{
+		{IndexKey: []byte("x"), Key: []byte("c"), Value: []byte("x3")},
+	}, res.Pairs)
+	require.Empty(t, res.NextKey)
+
+	// latest version
+	res, err = server.IndexedKeys(ctx, &versiondb.QueryIndexedKeysRequest{Index: "value", Prefix: []byte("x")})
+	require.NoError(t, err)
+	require.Equal(t, []versiondb.IndexedPair{
+		{IndexKey: []byte("x"), Key: []byte("b"), Value: []byte("x4")},
+		{IndexKey: []byte("x"), Key: []byte("c"), Value: []byte("x3")},
+	}, res.Pairs)
+
+	_, err = server.IndexedKeys(ctx, &versiondb.QueryIndexedKeysRequest{Index: "not-exists"})
+	require.Error(t, err)
+}
+
+func TestKeyHistoryQueryServer(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	require.NoError(t, err)
+	defer store.Close()
+
+	for version := int64(1); version <= 5; version++ {
+		pair := &types.StoreKVPair{StoreKey: "test", Key: []byte("a"), Value: []byte{byte(version)}}
+		if version == 4 {
+			pair = &types.StoreKVPair{StoreKey: "test", Key: []byte("a"), Delete: true}
+		}
+		require.NoError(t, store.PutAtVersion(version, []*types.StoreKVPair{pair}))
+	}
+
+	server := versiondb.NewQueryServer(store, nil)
+	ctx := context.Background()
+
+	res, err := server.KeyHistory(ctx, &versiondb.QueryKeyHistoryRequest{Store: "test", Key: []byte("a"), FromHeight: 2, Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, []versiondb.KeyHistoryEntry{
+		{Height: 2, Value: []byte{2}},
+		{Height: 3, Value: []byte{3}},
+	}, res.Entries)
+	require.Equal(t, int64(4), res.NextHeight)
+
+	res, err = server.KeyHistory(ctx, &versiondb.QueryKeyHistoryRequest{Store: "test", Key: []byte("a"), FromHeight: res.NextHeight, Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, []versiondb.KeyHistoryEntry{
+		{Height: 4, Deleted: true},
+		{Height: 5, Value: []byte{5}},
+	}, res.Entries)
+	require.Zero(t, res.NextHeight)
+
+	_, err = server.KeyHistory(ctx, &versiondb.QueryKeyHistoryRequest{Store: "test", Key: []byte("a"), FromHeight: 6})
+	require.Error(t, err)
+}
+
+// TestPrefixKeys tests the user keys which are prefix of each other, the internal keys of different versions
+// interleave in plain bytewise order.
+func TestPrefixKeys(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	require.NoError(t, err)
+	defer store.Close()
+
+	storeKey := "test"
+	require.NoError(t, store.PutAtVersion(1, []*types.StoreKVPair{
+		{StoreKey: storeKey, Key: []byte("a"), Value: []byte{1}},
+		{StoreKey: storeKey, Key: []byte("a\x00"), Value: []byte{1}},
+		{StoreKey: storeKey, Key: []byte("a\xff"), Value: []byte{1}},
+	}))
+	require.NoError(t, store.PutAtVersion(2, []*types.StoreKVPair{
+		{StoreKey: storeKey, Key: []byte("a"), Value: []byte{2}},
+		{StoreKey: storeKey, Key: []byte("a\x00"), Delete: true},
+		{StoreKey: storeKey, Key: []byte("a\xff"), Value: []byte{}},
+	}))
+
+	v := int64(1)
+	it, err := store.IteratorAtVersion(storeKey, nil, nil, &v)
+	require.NoError(t, err)
+	require.Equal(t, []kvPair{
+		{[]byte("a"), []byte{1}},
+		{[]byte("a\x00"), []byte{1}},
+		{[]byte("a\xff"), []byte{1}},
+	}, consumeIterator(it))
+
+	it, err = store.ReverseIteratorAtVersion(storeKey, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []kvPair{
+		{[]byte("a\xff"), []byte{}},
+		{[]byte("a"), []byte{2}},
+	}, consumeIterator(it))
+
+	ok, err := store.HasAtVersion(storeKey, []byte("a\x00"), nil)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// empty value is supported
+	ok, err = store.HasAtVersion(storeKey, []byte("a\xff"), nil)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// the keys in other stores are not visible
+	it, err = store.IteratorAtVersion("tes", nil, nil, nil)
+	require.NoError(t, err)
+	require.Empty(t, consumeIterator(it))
+
+	latest, err := store.GetLatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), latest)
+}
+
+// TestFeedChangeSet tests the legacy change sets are written at the versions without changing the latest version.
+func TestFeedChangeSet(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+
+	require.NoError(t, store.PutAtVersion(3, []*types.StoreKVPair{
+		{StoreKey: "test", Key: []byte("a"), Value: []byte("3")},
+	}))
+	require.NoError(t, store.FeedChangeSet(1, "test", &iavl.ChangeSet{Pairs: []*iavl.KVPair{
+		{Key: []byte("a"), Value: []byte("1")},
+		{Key: []byte("b"), Value: []byte("1")},
+	}}))
+	require.NoError(t, store.FeedChangeSet(2, "test", &iavl.ChangeSet{Pairs: []*iavl.KVPair{
+		{Key: []byte("b"), Delete: true},
+	}}))
+
+	latest, err := store.GetLatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), latest)
+
+	v := int64(1)
+	value, err := store.GetAtVersion("test", []byte("a"), &v)
+	require.NoError(t, err)
+	require.Equal(t, []byte("1"), value)
+	value, err = store.GetAtVersion("test", []byte("b"), &v)
+	require.NoError(t, err)
+	require.Equal(t, []byte("1"), value)
+
+	v = 2
+	ok, err := store.HasAtVersion("test", []byte("b"), &v)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	value, err = store.GetAtVersion("test", []byte("a"), nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("3"), value)
+}
+
+func TestPruneBefore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewStore(dir)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+
+	storeKey := "test"
+	for i := int64(1); i <= 10; i++ {
+		changeSet := []*types.StoreKVPair{
+			{StoreKey: storeKey, Key: []byte("a"), Value: []byte{byte(i)}},
+			{StoreKey: "other", Key: []byte("a"), Value: []byte{byte(i)}},
+		}
+		if i == 5 {
+			changeSet = append(changeSet, &types.StoreKVPair{StoreKey: storeKey, Key: []byte("b"), Delete: true})
+		} else if i < 5 {
+			changeSet = append(changeSet, &types.StoreKVPair{StoreKey: storeKey, Key: []byte("b"), Value: []byte{byte(i)}})
+		}
+		require.NoError(t, store.PutAtVersion(i, changeSet))
+	}
+
+	require.NoError(t, store.PruneBefore(8, []string{storeKey}, true))
+
+	// version 8, 9, 10 of "a" are kept, all the versions of "b" are deleted
+	require.Equal(t, 3, countEntries(t, store, storeKey))
+	require.Equal(t, 10, countEntries(t, store, "other"))
+
+	v := int64(8)
+	value, err := store.GetAtVersion(storeKey, []byte("a"), &v)
+	require.NoError(t, err)
+	require.Equal(t, []byte{8}, value)
+	ok, err := store.HasAtVersion(storeKey, []byte("b"), &v)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// the queries before the pruned version are rejected, also after reopening
+	checkPruned := func(store Store, storeKey string, version int64) {
+		_, err := store.GetAtVersion(storeKey, []byte("a"), &version)
+		require.ErrorIs(t, err, versiondb.ErrVersionPruned)
+		_, err = store.IteratorAtVersion(storeKey, nil, nil, &version)
+		require.ErrorIs(t, err, versiondb.ErrVersionPruned)
+		_, err = store.KeyHistory(storeKey, []byte("a"), version, 10, 0)
+		require.ErrorIs(t, err, versiondb.ErrVersionPruned)
+	}
+	checkPruned(store, storeKey, 7)
+	require.NoError(t, store.Close())
+	store, err = NewStore(dir)
+	require.NoError(t, err)
+	checkPruned(store, storeKey, 7)
+	changes, err := store.KeyHistory(storeKey, []byte("a"), 8, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+	v = 7
+	value, err = store.GetAtVersion("other", []byte("a"), &v)
+	require.NoError(t, err)
+	require.Equal(t, []byte{7}, value)
+
+	require.NoError(t, store.PruneBefore(10, nil, false))
+	require.Equal(t, 1, countEntries(t, store, storeKey))
+	require.Equal(t, 1, countEntries(t, store, "other"))
+	checkPruned(store, "other", 9)
+}
+
+func TestIncrementalPrune(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewStore(dir)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+
+	storeKey := "test"
+	put := func(from, to int64) {
+		for i := from; i <= to; i++ {
+			changeSet := []*types.StoreKVPair{
+				{StoreKey: storeKey, Key: []byte("a"), Value: []byte{byte(i)}},
+			}
+			if i <= 3 {
+				changeSet = append(changeSet, &types.StoreKVPair{StoreKey: storeKey, Key: []byte("b"), Value: []byte{byte(i)}})
+			}
+			if i == 12 {
+				changeSet = append(changeSet, &types.StoreKVPair{StoreKey: storeKey, Key: []byte("c"), Delete: true})
+			} else if i < 12 {
+				changeSet = append(changeSet, &types.StoreKVPair{StoreKey: storeKey, Key: []byte("c"), Value: []byte{byte(i)}})
+			}
+			require.NoError(t, store.PutAtVersion(i, changeSet))
+		}
+	}
+	countChanges := func() int {
+		it := store.db.NewIterator(&util.Range{
+			Start: encodeKey([]byte(changesPrefix), math.MaxUint64),
+			Limit: encodeKey(cpIncr([]byte(changesPrefix)), math.MaxUint64),
+		}, nil)
+		defer it.Release()
+		var count int
+		for it.Next() {
+			count++
+		}
+		require.NoError(t, it.Error())
+		return count
+	}
+
+	// the changes are recorded after the first pruning
+	put(1, 10)
+	require.NoError(t, store.PruneBefore(4, nil, false))
+	require.Equal(t, 0, countChanges())
+	require.Equal(t, 7+1+7, countEntries(t, store, storeKey))
+	put(11, 15)
+	require.Equal(t, int64(11), store.pruned.changesSince)
+	require.Equal(t, 5+2, countChanges())
+
+	// the versions before the recording started are not covered, so it's still a full pruning
+	require.NoError(t, store.PruneBefore(12, nil, false))
+	require.Equal(t, 3, countChanges())
+	require.Equal(t, 4+1+0, countEntries(t, store, storeKey))
+
+	// the recording is persisted
+	require.NoError(t, store.Close())
+	store, err = NewStore(dir)
+	require.NoError(t, err)
+	require.Equal(t, int64(11), store.pruned.changesSince)
+	put(16, 20)
+
+	// a shadowed version of a key not changed since the previous pruning, it's only deleted by a full pruning
+	require.NoError(t, store.db.Put(encodeKey(prependStoreKey(storeKey, []byte("b")), 1), encodeValue([]byte{1}), nil))
+	require.NoError(t, store.PruneBefore(18, nil, false))
+	require.Equal(t, 2, countChanges())
+	require.Equal(t, 3+2, countEntries(t, store, storeKey))
+
+	v := int64(18)
+	value, err := store.GetAtVersion(storeKey, []byte("a"), &v)
+	require.NoError(t, err)
+	require.Equal(t, []byte{18}, value)
+	value, err = store.GetAtVersion(storeKey, []byte("b"), &v)
+	require.NoError(t, err)
+	require.Equal(t, []byte{3}, value)
+	ok, err := store.HasAtVersion(storeKey, []byte("c"), &v)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestStreamingServiceRetainBlocks(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	require.NoError(t, err)
+	defer store.Close()
+
+	svc := versiondb.NewStreamingService(store)
+	svc.SetRetainBlocks(10)
+	svc.SetPruneInterval(100)
+
+	ctx := context.Background()
+	for i := int64(1); i <= 100; i++ {
+		require.NoError(t, svc.ListenFinalizeBlock(ctx, abci.RequestFinalizeBlock{Height: i}, abci.ResponseFinalizeBlock{}))
+		require.NoError(t, svc.ListenCommit(ctx, abci.ResponseCommit{}, []*types.StoreKVPair{
+			{StoreKey: "test", Key: []byte("a"), Value: []byte("value")},
+		}))
+	}
+
+	require.Eventually(t, func() bool {
+		return countEntries(t, store, "test") == 11
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+// countEntries counts all the versions of all the keys in the store.
+func countEntries(t *testing.T, store Store, storeKey string) int {
+	prefix := storePrefix(storeKey)
+	it := store.db.NewIterator(&util.Range{
+		Start: encodeKey(prefix, math.MaxUint64),
+		Limit: encodeKey(cpIncr(prefix), math.MaxUint64),
+	}, nil)
+	defer it.Release()
+
+	var count int
+	for it.Next() {
+		count++
+	}
+	require.NoError(t, it.Error())
+	return count
+}
+
+type kvPair struct {
+	Key   []byte
+	Value []byte
+}
+
+func consumeIterator(it dbm.Iterator) []kvPair {
+	var result []kvPair
+	for ; it.Valid(); it.Next() {
+		result = append(result, kvPair{it.Key(), it.Value()})
+	}
+	it.Close()
+	return result
+}
@@ -3,11 +3,15 @@ package tsrocksdb
 import (
 	"encoding/binary"
 	"runtime"
+	"slices"
 
 	"github.com/linxGnu/grocksdb"
 )
 
-const VersionDBCFName = "versiondb"
+const (
+	VersionDBCFName      = "versiondb"
+	VersionDBIndexCFName = "versiondb-index"
+)
 
 // NewVersionDBOpts returns the options used for the versiondb column family.
 // FIXME: we don't enable dict compression for SSTFileWriter, because otherwise the file writer won't report correct file size.
@@ -50,18 +54,54 @@ func NewVersionDBOpts(sstFileWriter bool) *grocksdb.Options {
 // OpenVersionDB opens versiondb, the default column family is used for metadata,
 // actually key-value pairs are stored on another column family named with "versiondb",
 // which has user-defined timestamp enabled.
+// The index column family is also opened if it exists, because all the column families must be opened in read-write
+// mode, but the handle is not returned.
 func OpenVersionDB(dir string) (*grocksdb.DB, *grocksdb.ColumnFamilyHandle, error) {
+	db, cfHandles, err := openVersionDB(dir, hasIndexCF(dir))
+	if err != nil {
+		return nil, nil, err
+	}
+	return db, cfHandles[1], nil
+}
+
+// OpenVersionDBWithIndex opens versiondb similar to `OpenVersionDB`, it also returns the column family of the
+// secondary indexes named with "versiondb-index", which is created if not exists.
+func OpenVersionDBWithIndex(dir string) (*grocksdb.DB, *grocksdb.ColumnFamilyHandle, *grocksdb.ColumnFamilyHandle, error) {
+	db, cfHandles, err := openVersionDB(dir, true)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return db, cfHandles[1], cfHandles[2], nil
+}
+
+func openVersionDB(dir string, withIndex bool) (*grocksdb.DB, []*grocksdb.ColumnFamilyHandle, error) {
 	opts := grocksdb.NewDefaultOptions()
 	opts.SetCreateIfMissing(true)
 	opts.SetCreateIfMissingColumnFamilies(true)
-	db, cfHandles, err := grocksdb.OpenDbColumnFamilies(
-		opts, dir, []string{"default", VersionDBCFName},
-		[]*grocksdb.Options{opts, NewVersionDBOpts(false)},
-	)
+	names, cfOpts := versionDBColumnFamilies(opts, withIndex)
+	return grocksdb.OpenDbColumnFamilies(opts, dir, names, cfOpts)
+}
+
+// versionDBColumnFamilies returns the names and options of the column families to open.
+func versionDBColumnFamilies(opts *grocksdb.Options, withIndex bool) ([]string, []*grocksdb.Options) {
+	names := []string{"default", VersionDBCFName}
+	cfOpts := []*grocksdb.Options{opts, NewVersionDBOpts(false)}
+	if withIndex {
+		names = append(names, VersionDBIndexCFName)
+		cfOpts = append(cfOpts, NewVersionDBOpts(false))
+	}
+	return names, cfOpts
+}
+
+// hasIndexCF returns if the index column family exists, returns false if the db don't exist.
+func hasIndexCF(dir string) bool {
+	opts := grocksdb.NewDefaultOptions()
+	defer opts.Destroy()
+	names, err := grocksdb.ListColumnFamilies(opts, dir)
 	if err != nil {
-		return nil, nil, err
+		return false
 	}
-	return db, cfHandles[1], nil
+	return slices.Contains(names, VersionDBIndexCFName)
 }
 
 // OpenVersionDBForReadOnly open versiondb in readonly mode
@@ -87,11 +127,9 @@ func OpenVersionDBAndTrimHistory(dir string, version int64) (*grocksdb.DB, *groc
 	opts := grocksdb.NewDefaultOptions()
 	opts.SetCreateIfMissing(true)
 	opts.SetCreateIfMissingColumnFamilies(true)
-	db, cfHandles, err := grocksdb.OpenDbAndTrimHistory(
-		opts, dir, []string{"default", VersionDBCFName},
-		[]*grocksdb.Options{opts, NewVersionDBOpts(false)},
-		ts[:],
-	)
+	// the index column family is trimmed too if it exists
+	names, cfOpts := versionDBColumnFamilies(opts, hasIndexCF(dir))
+	db, cfHandles, err := grocksdb.OpenDbAndTrimHistory(opts, dir, names, cfOpts, ts[:])
 	if err != nil {
 		return nil, nil, err
 	}
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
+	"sync"
 
 	"cosmossdk.io/store/types"
 	"github.com/cosmos/iavl"
@@ -18,14 +20,30 @@ const (
 
 	StorePrefixTpl   = "s/k:%s/"
 	latestVersionKey = "s/latest"
+	// the pruned versions of all the stores and of the individual stores
+	prunedVersionKey       = "s/pruned"
+	prunedVersionKeyPrefix = "s/pruned:"
+
+	IndexPrefixTpl = "i/k:%s/"
+	// the first version of the index entries, the index is not available before it.
+	indexStartVersionKeyTpl = "s/index:%s"
+	// the last version written with the index, the index is rebuilt if it's behind the latest version.
+	indexedVersionKeyTpl = "s/indexed:%s"
 
 	ImportCommitBatchSize = 10000
+
+	// the size of the `packed(seq, type)` suffix of the rocksdb internal keys
+	internalKeyFooterSize = 8
+	// the `kTypeValue` of rocksdb, the other types of entries are deletions in versiondb
+	valueTypeValue byte = 0x1
 )
 
 var (
 	errKeyEmpty = errors.New("key cannot be empty")
 
-	_ versiondb.VersionStore = Store{}
+	_ versiondb.VersionStore  = Store{}
+	_ versiondb.HistoryPruner = Store{}
+	_ versiondb.IndexedStore  = Store{}
 
 	defaultWriteOpts     = grocksdb.NewDefaultWriteOptions()
 	defaultSyncWriteOpts = grocksdb.NewDefaultWriteOptions()
@@ -42,6 +60,28 @@ type Store struct {
 
 	// see: https://github.com/crypto-org-chain/cronos/issues/1683
 	skipVersionZero bool
+
+	// the secondary indexes maintained in `PutAtVersion`, stored in a separate column family.
+	indexCFHandle     *grocksdb.ColumnFamilyHandle
+	indexes           versiondb.Indexes
+	indexStartVersion map[string]int64
+
+	pruned *prunedVersions
+}
+
+// prunedVersions caches the persisted pruned versions, the queries before them are rejected.
+type prunedVersions struct {
+	mtx sync.RWMutex
+	// the pruned version of all the stores
+	all    int64
+	stores map[string]int64
+}
+
+// get returns the pruned version of the store.
+func (p *prunedVersions) get(store string) int64 {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	return max(p.all, p.stores[store])
 }
 
 func NewStore(dir string) (Store, error) {
@@ -49,17 +89,190 @@ func NewStore(dir string) (Store, error) {
 	if err != nil {
 		return Store{}, err
 	}
-	return Store{
-		db:       db,
-		cfHandle: cfHandle,
-	}, nil
+	s, err := NewStoreWithDB(db, cfHandle)
+	if err != nil {
+		db.Close()
+		return Store{}, err
+	}
+	return s, nil
 }
 
-func NewStoreWithDB(db *grocksdb.DB, cfHandle *grocksdb.ColumnFamilyHandle) Store {
-	return Store{
+// NewStoreWithDB creates the store with the opened db, it loads the pruned versions.
+func NewStoreWithDB(db *grocksdb.DB, cfHandle *grocksdb.ColumnFamilyHandle) (Store, error) {
+	s := Store{
 		db:       db,
 		cfHandle: cfHandle,
+		pruned:   &prunedVersions{stores: make(map[string]int64)},
+	}
+	if err := s.loadPrunedVersions(); err != nil {
+		return Store{}, err
+	}
+	return s, nil
+}
+
+func (s Store) loadPrunedVersions() error {
+	all, _, err := s.getVersionKey(prunedVersionKey)
+	if err != nil {
+		return err
+	}
+	s.pruned.all = all
+
+	prefix := []byte(prunedVersionKeyPrefix)
+	it := s.db.NewIterator(defaultReadOpts)
+	defer it.Close()
+	for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+		key := moveSliceToBytes(it.Key())
+		value := moveSliceToBytes(it.Value())
+		s.pruned.stores[string(key[len(prefix):])] = int64(binary.LittleEndian.Uint64(value))
+	}
+	return it.Err()
+}
+
+// checkPruned rejects the queries of the store before the pruned version.
+func (s Store) checkPruned(storeKey string, version int64) error {
+	if pruned := s.pruned.get(storeKey); version < pruned {
+		return fmt.Errorf("%w: store %s is pruned before version %d", versiondb.ErrVersionPruned, storeKey, pruned)
+	}
+	return nil
+}
+
+// NewStoreWithIndexes opens the store with the secondary indexes, the newly added indexes, or the ones which missed
+// some versions written without them, are rebuilt at the latest version, and available from it.
+func NewStoreWithIndexes(dir string, indexes versiondb.Indexes) (Store, error) {
+	if err := indexes.Validate(); err != nil {
+		return Store{}, err
+	}
+	db, cfHandle, indexCFHandle, err := OpenVersionDBWithIndex(dir)
+	if err != nil {
+		return Store{}, err
+	}
+	s, err := NewStoreWithDB(db, cfHandle)
+	if err != nil {
+		db.Close()
+		return Store{}, err
+	}
+	s.indexCFHandle = indexCFHandle
+	if err := s.initIndexes(indexes); err != nil {
+		db.Close()
+		return Store{}, err
+	}
+	return s, nil
+}
+
+// initIndexes loads the start versions of the indexes, rebuilds the new ones and the ones behind the latest version.
+func (s *Store) initIndexes(indexes versiondb.Indexes) error {
+	latest, err := s.GetLatestVersion()
+	if err != nil {
+		return err
+	}
+
+	s.indexes = indexes
+	s.indexStartVersion = make(map[string]int64, len(indexes))
+	for _, idx := range indexes {
+		start, found, err := s.getVersionKey(fmt.Sprintf(indexStartVersionKeyTpl, idx.Name))
+		if err != nil {
+			return err
+		}
+		indexed, indexedFound, err := s.getVersionKey(fmt.Sprintf(indexedVersionKeyTpl, idx.Name))
+		if err != nil {
+			return err
+		}
+		if found && indexedFound && indexed >= latest {
+			s.indexStartVersion[idx.Name] = start
+			continue
+		}
+
+		if err := s.rebuildIndex(idx, latest); err != nil {
+			return fmt.Errorf("rebuild index %s failed: %w", idx.Name, err)
+		}
+		s.indexStartVersion[idx.Name] = latest
+	}
+	return nil
+}
+
+// getVersionKey reads a version stored in the default column family, returns false if the key don't exists.
+func (s Store) getVersionKey(key string) (int64, bool, error) {
+	bz, err := s.db.GetBytes(defaultReadOpts, []byte(key))
+	if err != nil {
+		return 0, false, err
+	}
+	if len(bz) == 0 {
+		return 0, false, nil
+	}
+	return int64(binary.LittleEndian.Uint64(bz)), true, nil
+}
+
+// rebuildIndex rewrites the entries of the index at the version from the key-value pairs of the store, the stale
+// entries are deleted at the same version, the index is available from the version.
+func (s Store) rebuildIndex(idx versiondb.Index, version int64) error {
+	var ts [TimestampSize]byte
+	binary.LittleEndian.PutUint64(ts[:], uint64(version))
+
+	batch := grocksdb.NewWriteBatch()
+	defer batch.Destroy()
+	writeBatch := func(force bool) error {
+		if batch.Count() == 0 || (!force && batch.Count() < ImportCommitBatchSize) {
+			return nil
+		}
+		if err := s.db.Write(defaultWriteOpts, batch); err != nil {
+			return err
+		}
+		batch.Clear()
+		return nil
+	}
+
+	prefix := indexPrefix(idx.Name)
+	if err := s.scanAtVersion(s.indexCFHandle, prefix, version, func(key, _ []byte) error {
+		batch.DeleteCFWithTS(s.indexCFHandle, cloneAppend(prefix, key), ts[:])
+		return writeBatch(false)
+	}); err != nil {
+		return err
+	}
+
+	indexes := versiondb.Indexes{idx}
+	if err := s.scanAtVersion(s.cfHandle, storePrefix(idx.Store), version, func(key, value []byte) error {
+		for _, entry := range indexes.ImportEntries(idx.Store, key, value) {
+			batch.PutCFWithTS(s.indexCFHandle, cloneAppend(indexPrefix(entry.StoreKey), entry.Key), ts[:], entry.Value)
+		}
+		return writeBatch(false)
+	}); err != nil {
+		return err
+	}
+	if err := writeBatch(true); err != nil {
+		return err
+	}
+
+	batch.Put([]byte(fmt.Sprintf(indexStartVersionKeyTpl, idx.Name)), ts[:])
+	batch.Put([]byte(fmt.Sprintf(indexedVersionKeyTpl, idx.Name)), ts[:])
+	return s.db.Write(defaultSyncWriteOpts, batch)
+}
+
+// scanAtVersion calls fn with the key-value pairs under the prefix at the version, the prefix is stripped.
+func (s Store) scanAtVersion(cfHandle *grocksdb.ColumnFamilyHandle, prefix []byte, version int64, fn func(key, value []byte) error) error {
+	start, end := iterateWithPrefix(prefix, nil, nil)
+	it := newRocksDBIterator(s.db.NewIteratorCF(newTSReadOptions(&version), cfHandle), prefix, start, end, false, s.skipVersionZero)
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		if err := fn(it.Key(), it.Value()); err != nil {
+			return err
+		}
 	}
+	return it.Error()
+}
+
+// setIndexedVersion records the version is written with the indexes.
+func (s Store) setIndexedVersion(version int64) error {
+	if len(s.indexes) == 0 {
+		return nil
+	}
+	var ts [TimestampSize]byte
+	binary.LittleEndian.PutUint64(ts[:], uint64(version))
+	batch := grocksdb.NewWriteBatch()
+	defer batch.Destroy()
+	for _, idx := range s.indexes {
+		batch.Put([]byte(fmt.Sprintf(indexedVersionKeyTpl, idx.Name)), ts[:])
+	}
+	return s.db.Write(defaultWriteOpts, batch)
 }
 
 func (s *Store) SetSkipVersionZero(skip bool) {
@@ -90,10 +303,34 @@ func (s Store) PutAtVersion(version int64, changeSet []*types.StoreKVPair) error
 		}
 	}
 
+	// the index changes are computed against the latest values before the change set
+	entries, err := s.indexes.Changes(changeSet, func(storeKey string, key []byte) ([]byte, error) {
+		return s.GetAtVersion(storeKey, key, nil)
+	})
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		key := cloneAppend(indexPrefix(entry.StoreKey), entry.Key)
+		if entry.Delete {
+			batch.DeleteCFWithTS(s.indexCFHandle, key, ts[:])
+		} else {
+			batch.PutCFWithTS(s.indexCFHandle, key, ts[:], entry.Value)
+		}
+	}
+	for _, idx := range s.indexes {
+		batch.Put([]byte(fmt.Sprintf(indexedVersionKeyTpl, idx.Name)), ts[:])
+	}
+
 	return s.db.Write(defaultSyncWriteOpts, batch)
 }
 
 func (s Store) GetAtVersionSlice(storeKey string, key []byte, version *int64) (*grocksdb.Slice, error) {
+	if version != nil {
+		if err := s.checkPruned(storeKey, *version); err != nil {
+			return nil, err
+		}
+	}
 	value, ts, err := s.db.GetCFWithTS(
 		newTSReadOptions(version),
 		s.cfHandle,
@@ -132,6 +369,77 @@ func (s Store) HasAtVersion(storeKey string, key []byte, version *int64) (bool,
 	return slice.Exists(), nil
 }
 
+// KeyHistory implements VersionStore interface, it iterates with `iter_start_ts` set, so all the versions of the key
+// in the range are returned (including the tombstones), the keys returned by the iterator are internal keys in the
+// format `key || timestamp || packed(seq, type)`. The versions of a key are ordered descendingly, so the limit is
+// applied after the whole range is scanned.
+func (s Store) KeyHistory(storeKey string, key []byte, fromVersion, toVersion int64, limit int) ([]versiondb.KeyChange, error) {
+	if len(key) == 0 {
+		return nil, errKeyEmpty
+	}
+	if fromVersion < 0 || fromVersion > toVersion {
+		return nil, fmt.Errorf("invalid version range: [%d, %d]", fromVersion, toVersion)
+	}
+	if err := s.checkPruned(storeKey, fromVersion); err != nil {
+		return nil, err
+	}
+	userKey := prependStoreKey(storeKey, key)
+
+	var startTS [TimestampSize]byte
+	binary.LittleEndian.PutUint64(startTS[:], uint64(fromVersion))
+	readOpts := newTSReadOptions(&toVersion)
+	readOpts.SetIterStartTimestamp(startTS[:])
+	defer readOpts.Destroy()
+
+	itr := s.db.NewIteratorCF(readOpts, s.cfHandle)
+	defer itr.Close()
+
+	var changes []versiondb.KeyChange
+	for itr.Seek(userKey); itr.Valid(); itr.Next() {
+		internalKey := itr.Key()
+		foundKey, version, valueType, ok := parseInternalKey(internalKey.Data())
+		internalKey.Free()
+		if !ok {
+			return nil, fmt.Errorf("invalid internal key, store: %s, key: %X", storeKey, key)
+		}
+		if !bytes.Equal(foundKey, userKey) {
+			break
+		}
+		if version == 0 && s.skipVersionZero {
+			continue
+		}
+
+		change := versiondb.KeyChange{Version: int64(version)}
+		if valueType == valueTypeValue {
+			change.Value = moveSliceToBytes(itr.Value())
+		} else {
+			change.Deleted = true
+		}
+		changes = append(changes, change)
+	}
+	if err := itr.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.Reverse(changes)
+	if limit > 0 && len(changes) > limit {
+		changes = changes[:limit]
+	}
+	return changes, nil
+}
+
+// parseInternalKey splits the internal key into user key, timestamp and value type.
+func parseInternalKey(bz []byte) ([]byte, uint64, byte, bool) {
+	if len(bz) < TimestampSize+internalKeyFooterSize {
+		return nil, 0, 0, false
+	}
+	footer := len(bz) - internalKeyFooterSize
+	userKey := bytes.Clone(bz[:footer-TimestampSize])
+	version := binary.LittleEndian.Uint64(bz[footer-TimestampSize : footer])
+	// the footer is the little endian encoding of `seq << 8 | type`
+	return userKey, version, bz[footer], true
+}
+
 // GetLatestVersion returns the latest version stored in plain state,
 // it's committed after the changesets, so the data for this version is guaranteed to be persisted.
 // returns -1 if the key don't exists.
@@ -160,6 +468,11 @@ func (s Store) iteratorAtVersion(storeKey string, start, end []byte, version *in
 	if (start != nil && len(start) == 0) || (end != nil && len(end) == 0) {
 		return nil, errKeyEmpty
 	}
+	if version != nil {
+		if err := s.checkPruned(storeKey, *version); err != nil {
+			return nil, err
+		}
+	}
 
 	prefix := storePrefix(storeKey)
 	start, end = iterateWithPrefix(prefix, start, end)
@@ -168,7 +481,37 @@ func (s Store) iteratorAtVersion(storeKey string, start, end []byte, version *in
 	return newRocksDBIterator(itr, prefix, start, end, reverse, s.skipVersionZero), nil
 }
 
-// FeedChangeSet is used to migrate legacy change sets into versiondb
+// IndexIteratorAtVersion implements IndexedStore interface
+func (s Store) IndexIteratorAtVersion(index string, start, end []byte, version *int64) (versiondb.Iterator, error) {
+	if (start != nil && len(start) == 0) || (end != nil && len(end) == 0) {
+		return nil, errKeyEmpty
+	}
+	startVersion, ok := s.indexStartVersion[index]
+	if !ok {
+		return nil, fmt.Errorf("index not found: %s", index)
+	}
+	if version != nil && *version < startVersion {
+		return nil, fmt.Errorf("%w: index %s starts at version %d", versiondb.ErrIndexNotAvailable, index, startVersion)
+	}
+	if version != nil {
+		for _, idx := range s.indexes {
+			if idx.Name != index {
+				continue
+			}
+			if err := s.checkPruned(idx.Store, *version); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	prefix := indexPrefix(index)
+	start, end = iterateWithPrefix(prefix, start, end)
+
+	itr := s.db.NewIteratorCF(newTSReadOptions(version), s.indexCFHandle)
+	return newRocksDBIterator(itr, prefix, start, end, false, s.skipVersionZero), nil
+}
+
+// FeedChangeSet is used to migrate legacy change sets into versiondb, the secondary indexes are not maintained.
 func (s Store) FeedChangeSet(version int64, store string, changeSet *iavl.ChangeSet) error {
 	var ts [TimestampSize]byte
 	binary.LittleEndian.PutUint64(ts[:], uint64(version))
@@ -203,6 +546,9 @@ func (s Store) Import(version int64, ch <-chan versiondb.ImportEntry) error {
 	for entry := range ch {
 		key := cloneAppend(storePrefix(entry.StoreKey), entry.Key)
 		batch.PutCFWithTS(s.cfHandle, key, ts[:], entry.Value)
+		for _, indexEntry := range s.indexes.ImportEntries(entry.StoreKey, entry.Key, entry.Value) {
+			batch.PutCFWithTS(s.indexCFHandle, cloneAppend(indexPrefix(indexEntry.StoreKey), indexEntry.Key), ts[:], indexEntry.Value)
+		}
 
 		counter++
 		if counter%ImportCommitBatchSize == 0 {
@@ -219,6 +565,9 @@ func (s Store) Import(version int64, ch <-chan versiondb.ImportEntry) error {
 		}
 	}
 
+	if err := s.setIndexedVersion(version); err != nil {
+		return err
+	}
 	return s.SetLatestVersion(version)
 }
 
@@ -226,10 +575,195 @@ func (s Store) Flush() error {
 	opts := grocksdb.NewDefaultFlushOptions()
 	defer opts.Destroy()
 
-	return errors.Join(
+	errs := []error{
 		s.db.Flush(opts),
 		s.db.FlushCF(s.cfHandle, opts),
+	}
+	if s.indexCFHandle != nil {
+		errs = append(errs, s.db.FlushCF(s.indexCFHandle, opts))
+	}
+	return errors.Join(errs...)
+}
+
+// PruneBefore implements HistoryPruner interface, the pruned version is persisted first, the queries before it are
+// rejected.
+//
+// When all the stores are pruned, it increases the `full_history_ts_low` of the column families (including the index
+// one), the versions older than it are garbage-collected by the compactions, the newest one is preserved for each
+// key. The `full_history_ts_low` applies to the whole column family, so the individual stores are pruned by deleting
+// the shadowed versions under their key prefixes instead, see `pruneRange`.
+func (s Store) PruneBefore(version int64, stores []string, compact bool) error {
+	if err := s.setPrunedVersion(version, stores); err != nil {
+		return err
+	}
+
+	if len(stores) > 0 {
+		return s.pruneStores(version, stores, compact)
+	}
+
+	cfHandles := []*grocksdb.ColumnFamilyHandle{s.cfHandle}
+	if s.indexCFHandle != nil {
+		cfHandles = append(cfHandles, s.indexCFHandle)
+	}
+	for _, cfHandle := range cfHandles {
+		if err := s.increaseFullHistoryTsLow(cfHandle, version); err != nil {
+			return err
+		}
+	}
+
+	if !compact {
+		return nil
+	}
+
+	compactOpts := grocksdb.NewCompactRangeOptions()
+	defer compactOpts.Destroy()
+	for _, cfHandle := range cfHandles {
+		s.db.CompactRangeCFOpt(cfHandle, grocksdb.Range{}, compactOpts)
+	}
+	return nil
+}
+
+// pruneStores prunes the key prefixes of the stores and the indexes on them.
+func (s Store) pruneStores(version int64, stores []string, compact bool) error {
+	type prefixRange struct {
+		cfHandle *grocksdb.ColumnFamilyHandle
+		prefix   []byte
+	}
+	var ranges []prefixRange
+	for _, store := range stores {
+		ranges = append(ranges, prefixRange{s.cfHandle, storePrefix(store)})
+		for _, idx := range s.indexes {
+			if idx.Store == store {
+				ranges = append(ranges, prefixRange{s.indexCFHandle, indexPrefix(idx.Name)})
+			}
+		}
+	}
+
+	compactOpts := grocksdb.NewCompactRangeOptions()
+	defer compactOpts.Destroy()
+	for _, r := range ranges {
+		if err := s.pruneRange(r.cfHandle, r.prefix, version); err != nil {
+			return err
+		}
+		if compact {
+			s.db.CompactRangeCFOpt(r.cfHandle, grocksdb.Range{Start: r.prefix, Limit: cpIncr(r.prefix)}, compactOpts)
+		}
+	}
+	return nil
+}
+
+// pruneRange deletes the versions under the prefix shadowed by the newest one not newer than the target version, it
+// iterates with `iter_start_ts` set to see all the versions. A version is deleted by a deletion marker written at the
+// same timestamp, which covers the value, the value is dropped by the compactions, the markers are left in place.
+// The marker is iterated right before the value it covers, so the values deleted by the previous runs are skipped.
+func (s Store) pruneRange(cfHandle *grocksdb.ColumnFamilyHandle, prefix []byte, version int64) error {
+	var startTS [TimestampSize]byte
+	readOpts := newTSReadOptions(&version)
+	readOpts.SetIterStartTimestamp(startTS[:])
+	defer readOpts.Destroy()
+
+	itr := s.db.NewIteratorCF(readOpts, cfHandle)
+	defer itr.Close()
+
+	batch := grocksdb.NewWriteBatch()
+	defer batch.Destroy()
+
+	var (
+		userKey []byte
+		// whether the previous entry is a deletion marker of the same key, and its timestamp
+		prevDeleted bool
+		prevTS      uint64
 	)
+	for itr.Seek(prefix); itr.Valid(); itr.Next() {
+		internalKey := itr.Key()
+		foundKey, ts, valueType, ok := parseInternalKey(internalKey.Data())
+		internalKey.Free()
+		if !ok {
+			return fmt.Errorf("invalid internal key, prefix: %s", prefix)
+		}
+		if !bytes.HasPrefix(foundKey, prefix) {
+			break
+		}
+		newKey := !bytes.Equal(foundKey, userKey)
+		deletedAlready := !newKey && prevDeleted && prevTS == ts
+		userKey = foundKey
+		prevDeleted, prevTS = valueType != valueTypeValue, ts
+		if newKey {
+			// the newest version not newer than the target version is preserved
+			continue
+		}
+		if valueType != valueTypeValue || deletedAlready {
+			// the deletion markers are kept anyway, and the values covered by them are deleted already
+			continue
+		}
+
+		var tsBz [TimestampSize]byte
+		binary.LittleEndian.PutUint64(tsBz[:], ts)
+		batch.DeleteCFWithTS(cfHandle, foundKey, tsBz[:])
+		if batch.Count() >= ImportCommitBatchSize {
+			if err := s.db.Write(defaultWriteOpts, batch); err != nil {
+				return err
+			}
+			batch.Clear()
+		}
+	}
+	if err := itr.Err(); err != nil {
+		return err
+	}
+
+	if batch.Count() > 0 {
+		return s.db.Write(defaultSyncWriteOpts, batch)
+	}
+	return nil
+}
+
+// setPrunedVersion persists the pruned version of the stores, empty stores means all the stores, it's not decreased.
+func (s Store) setPrunedVersion(version int64, stores []string) error {
+	s.pruned.mtx.Lock()
+	defer s.pruned.mtx.Unlock()
+
+	var ts [TimestampSize]byte
+	binary.LittleEndian.PutUint64(ts[:], uint64(version))
+
+	batch := grocksdb.NewWriteBatch()
+	defer batch.Destroy()
+	if len(stores) == 0 && version > s.pruned.all {
+		batch.Put([]byte(prunedVersionKey), ts[:])
+	}
+	for _, store := range stores {
+		if version > s.pruned.stores[store] {
+			batch.Put([]byte(prunedVersionKeyPrefix+store), ts[:])
+		}
+	}
+	if err := s.db.Write(defaultSyncWriteOpts, batch); err != nil {
+		return err
+	}
+
+	if len(stores) == 0 {
+		s.pruned.all = max(s.pruned.all, version)
+	}
+	for _, store := range stores {
+		s.pruned.stores[store] = max(s.pruned.stores[store], version)
+	}
+	return nil
+}
+
+// increaseFullHistoryTsLow sets the `full_history_ts_low` of the column family, it can't be decreased.
+func (s Store) increaseFullHistoryTsLow(cfHandle *grocksdb.ColumnFamilyHandle, version int64) error {
+	var ts [TimestampSize]byte
+	binary.LittleEndian.PutUint64(ts[:], uint64(version))
+
+	current, err := s.db.GetFullHistoryTsLow(cfHandle)
+	if err != nil {
+		return err
+	}
+	increase := !current.Exists() || len(current.Data()) != TimestampSize ||
+		binary.LittleEndian.Uint64(current.Data()) < uint64(version)
+	current.Free()
+	if !increase {
+		return nil
+	}
+	return s.db.IncreaseFullHistoryTsLow(cfHandle, ts[:])
 }
 
 // FixData fixes wrong data written in versiondb due to rocksdb upgrade, the operation is idempotent.
@@ -346,6 +880,10 @@ func storePrefix(storeKey string) []byte {
 	return []byte(fmt.Sprintf(StorePrefixTpl, storeKey))
 }
 
+func indexPrefix(index string) []byte {
+	return []byte(fmt.Sprintf(IndexPrefixTpl, index))
+}
+
 // prependStoreKey prepends storeKey to the key
 func prependStoreKey(storeKey string, key []byte) []byte {
 	return append(storePrefix(storeKey), key...)
@@ -1,6 +1,7 @@
 package tsrocksdb
 
 import (
+	"bytes"
 	"encoding/binary"
 	"testing"
 
@@ -19,6 +20,14 @@ func TestTSVersionDB(t *testing.T) {
 	})
 }
 
+func TestIndexes(t *testing.T) {
+	versiondb.RunIndexes(t, func(indexes versiondb.Indexes) versiondb.VersionStore {
+		store, err := NewStoreWithIndexes(t.TempDir(), indexes)
+		require.NoError(t, err)
+		return store
+	})
+}
+
 // TestUserTimestamp tests the behaviors of user-defined timestamp feature of rocksdb
 func TestUserTimestampBasic(t *testing.T) {
 	key := []byte("hello")
@@ -156,6 +165,63 @@ func TestUserTimestampPruning(t *testing.T) {
 	bz.Free()
 }
 
+func TestPruneStoresRepeated(t *testing.T) {
+	storeKey := "test"
+	key := []byte("hello")
+
+	store, err := NewStore(t.TempDir())
+	require.NoError(t, err)
+	for i := int64(1); i <= 5; i++ {
+		require.NoError(t, store.PutAtVersion(i, []*types.StoreKVPair{
+			{StoreKey: storeKey, Key: key, Value: []byte{byte(i)}},
+		}))
+	}
+
+	// countMarkers counts the deletion markers under the store prefix, with all the versions visible.
+	countMarkers := func() int {
+		var startTS [TimestampSize]byte
+		version := int64(5)
+		readOpts := newTSReadOptions(&version)
+		readOpts.SetIterStartTimestamp(startTS[:])
+		defer readOpts.Destroy()
+
+		itr := store.db.NewIteratorCF(readOpts, store.cfHandle)
+		defer itr.Close()
+
+		prefix := storePrefix(storeKey)
+		var count int
+		for itr.Seek(prefix); itr.Valid(); itr.Next() {
+			internalKey := itr.Key()
+			foundKey, _, valueType, ok := parseInternalKey(internalKey.Data())
+			internalKey.Free()
+			require.True(t, ok)
+			if !bytes.HasPrefix(foundKey, prefix) {
+				break
+			}
+			if valueType != valueTypeValue {
+				count++
+			}
+		}
+		require.NoError(t, itr.Err())
+		return count
+	}
+
+	// the versions 1 to 3 are shadowed by version 4
+	require.NoError(t, store.PruneBefore(4, []string{storeKey}, false))
+	require.Equal(t, 3, countMarkers())
+
+	// the repeated runs don't write the markers again
+	require.NoError(t, store.PruneBefore(4, []string{storeKey}, false))
+	require.Equal(t, 3, countMarkers())
+	require.NoError(t, store.PruneBefore(5, []string{storeKey}, false))
+	require.Equal(t, 4, countMarkers())
+
+	version := int64(5)
+	bz, err := store.GetAtVersion(storeKey, key, &version)
+	require.NoError(t, err)
+	require.Equal(t, []byte{5}, bz)
+}
+
 func TestSkipVersionZero(t *testing.T) {
 	storeKey := "test"
 
@@ -1,9 +1,14 @@
 package versiondb
 
 import (
+	"errors"
+
 	"cosmossdk.io/store/types"
 )
 
+// ErrVersionPruned is returned when querying a store at a version before it's pruned by `HistoryPruner`.
+var ErrVersionPruned = errors.New("the version is pruned")
+
 type Iterator interface {
 	types.Iterator
 
@@ -20,6 +25,10 @@ type VersionStore interface {
 	ReverseIteratorAtVersion(storeKey string, start, end []byte, version *int64) (Iterator, error)
 	GetLatestVersion() (int64, error)
 
+	// KeyHistory returns the changes of the key in the version range `[fromVersion, toVersion]`,
+	// ordered by version ascendingly, at most `limit` oldest ones are returned if `limit` is positive.
+	KeyHistory(storeKey string, key []byte, fromVersion, toVersion int64, limit int) ([]KeyChange, error)
+
 	// Persist the change set of a block,
 	// the `changeSet` should be ordered by (storeKey, key),
 	// the version should be latest version plus one.
@@ -33,6 +42,22 @@ type VersionStore interface {
 	Flush() error
 }
 
+// HistoryPruner is implemented by the VersionStore backends which support dropping the old history.
+type HistoryPruner interface {
+	// PruneBefore garbage-collects the versions older than the target version in the stores, empty stores means all
+	// the stores, the newest value not newer than the target version is preserved for each key, so the queries at or
+	// after the target version are not affected, the queries before it are not supported anymore.
+	// If compact is true, the disk space is reclaimed eagerly, otherwise it's left to the background compactions.
+	PruneBefore(version int64, stores []string, compact bool) error
+}
+
+// KeyChange is a change of a key written at the version, `Value` is nil if the key is deleted.
+type KeyChange struct {
+	Version int64
+	Value   []byte
+	Deleted bool
+}
+
 type ImportEntry struct {
 	StoreKey string
 	Key      []byte
@@ -29,6 +29,8 @@ func GetQueryCmd(queryRoute string) *cobra.Command {
 		GetDenomByContractCmd(),
 		QueryParamsCmd(),
 		GetPermissions(),
+		GetBridgeStatus(),
+		GetRateLimits(),
 	)
 
 	// this line is used by starport scaffolding # 1
@@ -159,3 +161,61 @@ func GetPermissions() *cobra.Command {
 	flags.AddQueryFlagsToCmd(cmd)
 	return cmd
 }
+
+// GetBridgeStatus queries if the bridge is enabled
+func GetBridgeStatus() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bridge-status",
+		Short: "Gets if the bridge is enabled",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			res, err := queryClient.BridgeStatus(rpctypes.ContextWithHeight(clientCtx.Height), &types.QueryBridgeStatusRequest{})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetRateLimits queries the rate limits and the current usages
+func GetRateLimits() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rate-limits [denom]",
+		Short: "Gets the rate limits and the current usages, optionally of the denom",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryRateLimitsRequest{}
+			if len(args) > 0 {
+				req.Denom = args[0]
+			}
+			res, err := queryClient.RateLimits(rpctypes.ContextWithHeight(clientCtx.Height), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
@@ -31,7 +31,7 @@ var (
 
 // BankModuleMetaData contains all meta data concerning the BankModule contract.
 var BankModuleMetaData = &bind.MetaData{
-	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"balanceOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"burn\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"mint\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"transfer\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"payable\",\"type\":\"function\"}]",
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"balanceOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"burn\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"mint\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"send\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"},{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"name\":\"setDenomMetadata\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"totalSupply\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"transfer\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"payable\",\"type\":\"function\"}]",
 }
 
 // BankModuleABI is the input ABI used to generate the binding from.
@@ -211,6 +211,37 @@ func (_BankModule *BankModuleCallerSession) BalanceOf(arg0 common.Address, arg1
 	return _BankModule.Contract.BalanceOf(&_BankModule.CallOpts, arg0, arg1)
 }
 
+// TotalSupply is a free data retrieval call binding the contract method 0xe4dc2aa4.
+//
+// Solidity: function totalSupply(address ) view returns(uint256)
+func (_BankModule *BankModuleCaller) TotalSupply(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _BankModule.contract.Call(opts, &out, "totalSupply", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0xe4dc2aa4.
+//
+// Solidity: function totalSupply(address ) view returns(uint256)
+func (_BankModule *BankModuleSession) TotalSupply(arg0 common.Address) (*big.Int, error) {
+	return _BankModule.Contract.TotalSupply(&_BankModule.CallOpts, arg0)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0xe4dc2aa4.
+//
+// Solidity: function totalSupply(address ) view returns(uint256)
+func (_BankModule *BankModuleCallerSession) TotalSupply(arg0 common.Address) (*big.Int, error) {
+	return _BankModule.Contract.TotalSupply(&_BankModule.CallOpts, arg0)
+}
+
 // Burn is a paid mutator transaction binding the contract method 0x9dc29fac.
 //
 // Solidity: function burn(address , uint256 ) payable returns(bool)
@@ -253,6 +284,48 @@ func (_BankModule *BankModuleTransactorSession) Mint(arg0 common.Address, arg1 *
 	return _BankModule.Contract.Mint(&_BankModule.TransactOpts, arg0, arg1)
 }
 
+// Send is a paid mutator transaction binding the contract method 0x5c05961b.
+//
+// Solidity: function send(address , address , string , uint256 ) payable returns(bool)
+func (_BankModule *BankModuleTransactor) Send(opts *bind.TransactOpts, arg0 common.Address, arg1 common.Address, arg2 string, arg3 *big.Int) (*types.Transaction, error) {
+	return _BankModule.contract.Transact(opts, "send", arg0, arg1, arg2, arg3)
+}
+
+// Send is a paid mutator transaction binding the contract method 0x5c05961b.
+//
+// Solidity: function send(address , address , string , uint256 ) payable returns(bool)
+func (_BankModule *BankModuleSession) Send(arg0 common.Address, arg1 common.Address, arg2 string, arg3 *big.Int) (*types.Transaction, error) {
+	return _BankModule.Contract.Send(&_BankModule.TransactOpts, arg0, arg1, arg2, arg3)
+}
+
+// Send is a paid mutator transaction binding the contract method 0x5c05961b.
+//
+// Solidity: function send(address , address , string , uint256 ) payable returns(bool)
+func (_BankModule *BankModuleTransactorSession) Send(arg0 common.Address, arg1 common.Address, arg2 string, arg3 *big.Int) (*types.Transaction, error) {
+	return _BankModule.Contract.Send(&_BankModule.TransactOpts, arg0, arg1, arg2, arg3)
+}
+
+// SetDenomMetadata is a paid mutator transaction binding the contract method 0xed865be6.
+//
+// Solidity: function setDenomMetadata(string , string , uint8 ) payable returns(bool)
+func (_BankModule *BankModuleTransactor) SetDenomMetadata(opts *bind.TransactOpts, arg0 string, arg1 string, arg2 uint8) (*types.Transaction, error) {
+	return _BankModule.contract.Transact(opts, "setDenomMetadata", arg0, arg1, arg2)
+}
+
+// SetDenomMetadata is a paid mutator transaction binding the contract method 0xed865be6.
+//
+// Solidity: function setDenomMetadata(string , string , uint8 ) payable returns(bool)
+func (_BankModule *BankModuleSession) SetDenomMetadata(arg0 string, arg1 string, arg2 uint8) (*types.Transaction, error) {
+	return _BankModule.Contract.SetDenomMetadata(&_BankModule.TransactOpts, arg0, arg1, arg2)
+}
+
+// SetDenomMetadata is a paid mutator transaction binding the contract method 0xed865be6.
+//
+// Solidity: function setDenomMetadata(string , string , uint8 ) payable returns(bool)
+func (_BankModule *BankModuleTransactorSession) SetDenomMetadata(arg0 string, arg1 string, arg2 uint8) (*types.Transaction, error) {
+	return _BankModule.Contract.SetDenomMetadata(&_BankModule.TransactOpts, arg0, arg1, arg2)
+}
+
 // Transfer is a paid mutator transaction binding the contract method 0xbeabacc8.
 //
 // Solidity: function transfer(address , address , uint256 ) payable returns(bool)
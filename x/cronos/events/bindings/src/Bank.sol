@@ -6,4 +6,8 @@ interface IBankModule {
     function balanceOf(address,address) external view returns (uint256);
     function burn(address,uint256) external payable returns (bool);
     function transfer(address,address,uint256) external payable returns (bool);
+    function totalSupply(address) external view returns (uint256);
+    function setDenomMetadata(string calldata,string calldata,uint8) external payable returns (bool);
+    // send the coins of the caller, the denom must be owned by the caller
+    function send(address,address,string calldata,uint256) external payable returns (bool);
 }
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.4;
+
+import {Cosmos} from "./CosmosTypes.sol";
+
+interface IGovModule {
+    struct Proposal {
+        uint64 id;
+        int32 status;
+        address proposer;
+        string title;
+        string summary;
+        string metadata;
+        // the type urls of the proposal messages
+        string[] messages;
+        Cosmos.Coin[] totalDeposit;
+        // the unix timestamps in seconds, zero if not set
+        int64 submitTime;
+        int64 depositEndTime;
+        int64 votingStartTime;
+        int64 votingEndTime;
+    }
+    struct TallyResult {
+        uint256 yes;
+        uint256 abstain;
+        uint256 no;
+        uint256 noWithVeto;
+    }
+    struct WeightedVoteOption {
+        int32 option;
+        // the decimal weight, e.g. "0.5"
+        string weight;
+    }
+    event SubmitProposal(
+        uint64 indexed proposalId,
+        address indexed proposalProposer,
+        string proposalMessages
+    );
+    event ProposalDeposit(
+        uint64 indexed proposalId,
+        address indexed depositor,
+        Cosmos.Coin[] amount
+    );
+    event ProposalVote(
+        uint64 indexed proposalId,
+        address indexed voter,
+        string option
+    );
+    // data is the proto encoded cosmos.gov.v1.MsgSubmitProposal, the proposer must be the caller
+    function submitProposal(bytes calldata data) external payable returns (uint64 proposalId);
+    function deposit(uint64 proposalId, Cosmos.Coin[] calldata amount) external payable returns (bool);
+    function vote(uint64 proposalId, int32 option, string calldata metadata) external payable returns (bool);
+    function voteWeighted(uint64 proposalId, WeightedVoteOption[] calldata options, string calldata metadata) external payable returns (bool);
+    function proposal(uint64 proposalId) external view returns (Proposal memory);
+    // the final tally result of the finished proposal, or the current one of the proposal in voting period
+    function tally(uint64 proposalId) external view returns (TallyResult memory);
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.4;
+
+import {Cosmos} from "./CosmosTypes.sol";
+
+interface IStakingModule {
+    struct Delegation {
+        string validator;
+        // the shares with 18 decimals
+        uint256 shares;
+        uint256 balance;
+    }
+    struct Validator {
+        string operatorAddress;
+        string moniker;
+        bool jailed;
+        int32 status;
+        uint256 tokens;
+        // the shares and commission rate with 18 decimals
+        uint256 delegatorShares;
+        uint256 commissionRate;
+    }
+    // Staking
+    event Delegate(
+        address indexed delegator,
+        string indexed validator,
+        Cosmos.Coin[] amount,
+        string newShares
+    );
+    event Unbond(
+        address indexed delegator,
+        string indexed validator,
+        Cosmos.Coin[] amount,
+        string completionTime
+    );
+    event Redelegate(
+        string indexed sourceValidator,
+        string indexed destinationValidator,
+        Cosmos.Coin[] amount,
+        string completionTime
+    );
+    // Distribution
+    event WithdrawRewards(
+        address indexed delegator,
+        string indexed validator,
+        Cosmos.Coin[] amount
+    );
+    function delegate(string calldata validator, uint256 amount) external payable returns (bool);
+    function undelegate(string calldata validator, uint256 amount) external payable returns (int64 completionTime);
+    function redelegate(string calldata srcValidator, string calldata dstValidator, uint256 amount) external payable returns (int64 completionTime);
+    function claimRewards(string calldata validator) external payable returns (Cosmos.Coin[] memory);
+    function delegation(address delegator, string calldata validator) external view returns (Delegation memory);
+    function delegations(address delegator) external view returns (Delegation[] memory);
+    function validator(string calldata validator) external view returns (Validator memory);
+    // the bonded validators ordered by power
+    function validators() external view returns (Validator[] memory);
+}
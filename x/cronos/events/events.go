@@ -3,11 +3,15 @@ package events
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	ibcfeetypes "github.com/cosmos/ibc-go/v8/modules/apps/29-fee/types"
 	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
 	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	gov "github.com/crypto-org-chain/cronos/v2/x/cronos/events/bindings/cosmos/precompile/gov"
 	ica "github.com/crypto-org-chain/cronos/v2/x/cronos/events/bindings/cosmos/precompile/ica"
 	relayer "github.com/crypto-org-chain/cronos/v2/x/cronos/events/bindings/cosmos/precompile/relayer"
+	staking "github.com/crypto-org-chain/cronos/v2/x/cronos/events/bindings/cosmos/precompile/staking"
 	cronoseventstypes "github.com/crypto-org-chain/cronos/v2/x/cronos/events/types"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
@@ -16,6 +20,8 @@ import (
 var (
 	RelayerEvents        map[string]*EventDescriptor
 	IcaEvents            map[string]*EventDescriptor
+	StakingEvents        map[string]*EventDescriptor
+	GovEvents            map[string]*EventDescriptor
 	RelayerValueDecoders = ValueDecoders{
 		channeltypes.AttributeKeyDataHex:             ConvertPacketData,
 		transfertypes.AttributeKeyAmount:             ConvertAmount,
@@ -43,6 +49,25 @@ var (
 		cronoseventstypes.AttributeKeySeq:   ConvertUint64,
 		channeltypes.AttributeKeySrcChannel: ReturnStringAsIs,
 	}
+	// the distribution module uses the same attribute keys for the withdraw_rewards event
+	StakingValueDecoders = ValueDecoders{
+		sdk.AttributeKeyAmount:                  ConvertAmount,
+		stakingtypes.AttributeKeyDelegator:      ConvertAccAddressFromBech32,
+		stakingtypes.AttributeKeyValidator:      ReturnStringAsIs,
+		stakingtypes.AttributeKeySrcValidator:   ReturnStringAsIs,
+		stakingtypes.AttributeKeyDstValidator:   ReturnStringAsIs,
+		stakingtypes.AttributeKeyNewShares:      ReturnStringAsIs,
+		stakingtypes.AttributeKeyCompletionTime: ReturnStringAsIs,
+	}
+	GovValueDecoders = ValueDecoders{
+		govtypes.AttributeKeyProposalID:       ConvertUint64,
+		govtypes.AttributeKeyProposalProposer: ConvertAccAddressFromBech32,
+		govtypes.AttributeKeyProposalMessages: ReturnStringAsIs,
+		govtypes.AttributeKeyDepositor:        ConvertAccAddressFromBech32,
+		govtypes.AttributeKeyVoter:            ConvertAccAddressFromBech32,
+		govtypes.AttributeKeyOption:           ReturnStringAsIs,
+		sdk.AttributeKeyAmount:                ConvertAmount,
+	}
 )
 
 func init() {
@@ -57,6 +82,18 @@ func init() {
 		panic(err)
 	}
 	IcaEvents = NewEventDescriptors(icaABI)
+
+	var stakingABI abi.ABI
+	if err := stakingABI.UnmarshalJSON([]byte(staking.StakingModuleMetaData.ABI)); err != nil {
+		panic(err)
+	}
+	StakingEvents = NewEventDescriptors(stakingABI)
+
+	var govABI abi.ABI
+	if err := govABI.UnmarshalJSON([]byte(gov.GovModuleMetaData.ABI)); err != nil {
+		panic(err)
+	}
+	GovEvents = NewEventDescriptors(govABI)
 }
 
 func RelayerConvertEvent(event sdk.Event) (*ethtypes.Log, error) {
@@ -78,3 +115,33 @@ func IcaConvertEvent(event sdk.Event) (*ethtypes.Log, error) {
 	}
 	return desc.ConvertEvent(event.Attributes, IcaValueDecoders, map[string]string{})
 }
+
+func StakingConvertEvent(event sdk.Event) (*ethtypes.Log, error) {
+	desc, ok := StakingEvents[event.Type]
+	if !ok {
+		return nil, nil
+	}
+	return desc.ConvertEvent(event.Attributes, StakingValueDecoders, map[string]string{})
+}
+
+func GovConvertEvent(event sdk.Event) (*ethtypes.Log, error) {
+	desc, ok := GovEvents[event.Type]
+	if !ok {
+		return nil, nil
+	}
+	// the submit_proposal event is emitted again with only the voting_period_start attribute when the initial
+	// deposit starts the voting period, which is skipped.
+	if event.Type == govtypes.EventTypeSubmitProposal && !hasAttribute(event, govtypes.AttributeKeyProposalID) {
+		return nil, nil
+	}
+	return desc.ConvertEvent(event.Attributes, GovValueDecoders, map[string]string{})
+}
+
+func hasAttribute(event sdk.Event, key string) bool {
+	for _, attr := range event.Attributes {
+		if attr.Key == key {
+			return true
+		}
+	}
+	return false
+}
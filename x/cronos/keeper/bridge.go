@@ -0,0 +1,30 @@
+package keeper
+
+import (
+	errorsmod "cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/crypto-org-chain/cronos/v2/x/cronos/types"
+)
+
+// IsBridgeEnabled returns if the bridge flows are allowed, the bridge is enabled unless turned off by `MsgTurnBridge`.
+func (k Keeper) IsBridgeEnabled(ctx sdk.Context) bool {
+	return !ctx.KVStore(k.storeKey).Has(types.BridgeDisabledKey)
+}
+
+// SetBridgeEnabled turns the bridge on or off.
+func (k Keeper) SetBridgeEnabled(ctx sdk.Context, enable bool) {
+	store := ctx.KVStore(k.storeKey)
+	if enable {
+		store.Delete(types.BridgeDisabledKey)
+	} else {
+		store.Set(types.BridgeDisabledKey, []byte{1})
+	}
+}
+
+// checkBridgeEnabled returns error if the bridge is turned off.
+func (k Keeper) checkBridgeEnabled(ctx sdk.Context) error {
+	if !k.IsBridgeEnabled(ctx) {
+		return errorsmod.Wrap(types.ErrBridgeDisabled, "bridge flows are suspended")
+	}
+	return nil
+}
@@ -150,3 +150,25 @@ func (k Keeper) BlockList(goCtx context.Context, req *types.QueryBlockListReques
 		Blob: blob,
 	}, nil
 }
+
+func (k Keeper) BridgeStatus(goCtx context.Context, req *types.QueryBridgeStatusRequest) (*types.QueryBridgeStatusResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	return &types.QueryBridgeStatusResponse{
+		Enabled: k.IsBridgeEnabled(ctx),
+	}, nil
+}
+
+func (k Keeper) RateLimits(goCtx context.Context, req *types.QueryRateLimitsRequest) (*types.QueryRateLimitsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	var statuses []types.RateLimitStatus
+	k.IterateRateLimits(ctx, req.Denom, func(limit types.RateLimit) bool {
+		statuses = append(statuses, types.RateLimitStatus{
+			RateLimit: limit,
+			Usage:     k.GetRateLimitUsage(ctx, limit),
+		})
+		return false
+	})
+	return &types.QueryRateLimitsResponse{
+		RateLimits: statuses,
+	}, nil
+}
@@ -18,7 +18,19 @@ import (
 	"github.com/crypto-org-chain/cronos/v2/x/cronos/types"
 )
 
+// ConvertVouchersToEvmCoins converts the ibc vouchers to evm coins, the conversion is counted as the inflow of
+// the rate limits.
 func (k Keeper) ConvertVouchersToEvmCoins(ctx sdk.Context, from string, coins sdk.Coins) error {
+	return k.convertVouchersToEvmCoins(ctx, from, coins, "", true)
+}
+
+// convertVouchersToEvmCoins converts the ibc vouchers to evm coins, the inflow is counted in the rate limits of
+// the channel, or the one of the denom trace if empty, the refunds are not counted.
+func (k Keeper) convertVouchersToEvmCoins(ctx sdk.Context, from string, coins sdk.Coins, channelID string, inflow bool) error {
+	if err := k.checkBridgeEnabled(ctx); err != nil {
+		return err
+	}
+
 	acc, err := sdk.AccAddressFromBech32(from)
 	if err != nil {
 		return err
@@ -27,6 +39,16 @@ func (k Keeper) ConvertVouchersToEvmCoins(ctx sdk.Context, from string, coins sd
 	params := k.GetParams(ctx)
 	evmParams := k.GetEvmParams(ctx)
 	for _, c := range coins {
+		if inflow {
+			channel := channelID
+			if channel == "" {
+				channel = k.rateLimitChannel(ctx, c.Denom)
+			}
+			if err := k.consumeRateLimits(ctx, c.Denom, channel, c.Amount, true); err != nil {
+				return err
+			}
+		}
+
 		switch c.Denom {
 		case params.IbcCroDenom:
 			if params.IbcCroDenom == "" {
@@ -77,7 +99,13 @@ func (k Keeper) ConvertVouchersToEvmCoins(ctx sdk.Context, from string, coins sd
 	return nil
 }
 
+// IbcTransferCoins is also called by the evm handlers (`SendToIbc`, `SendToIbcV2`, `SendCroToIbc`),
+// the evm tx is reverted if the bridge is turned off or the outflow rate limits are exceeded.
 func (k Keeper) IbcTransferCoins(ctx sdk.Context, from, destination string, coins sdk.Coins, channelId string) error {
+	if err := k.checkBridgeEnabled(ctx); err != nil {
+		return err
+	}
+
 	acc, err := sdk.AccAddressFromBech32(from)
 	if err != nil {
 		return err
@@ -173,6 +201,10 @@ func (k Keeper) ibcSendTransfer(ctx sdk.Context, sender sdk.AccAddress, destinat
 		channelId = sourceChannelID
 	}
 
+	if err := k.consumeRateLimits(ctx, coin.Denom, channelId, coin.Amount, false); err != nil {
+		return err
+	}
+
 	// Transfer coins to receiver through IBC
 	// We use current time for timeout timestamp and zero height for timeoutHeight
 	// it means it can never fail by timeout
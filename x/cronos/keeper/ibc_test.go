@@ -58,6 +58,17 @@ func (suite *KeeperTestSuite) TestConvertVouchersToEvmCoins() {
 			errors.New("coin fake is not supported for conversion"),
 			func() {},
 		},
+		{
+			"Bridge disabled",
+			address.String(),
+			sdk.NewCoins(sdk.NewCoin(types.IbcCroDenomDefaultValue, sdkmath.NewInt(123))),
+			func() {
+				suite.MintCoins(address, sdk.NewCoins(sdk.NewCoin(types.IbcCroDenomDefaultValue, sdkmath.NewInt(123))))
+				suite.app.CronosKeeper.SetBridgeEnabled(suite.ctx, false)
+			},
+			errors.New("bridge flows are suspended: bridge is disabled"),
+			func() {},
+		},
 		{
 			"Correct address with not enough IBC CRO token",
 			address.String(),
@@ -193,6 +204,18 @@ func (suite *KeeperTestSuite) TestIbcTransferCoins() {
 			errors.New("the coin fake is neither an ibc voucher or a cronos token"),
 			func() {},
 		},
+		{
+			"Bridge disabled",
+			address.String(),
+			"to",
+			sdk.NewCoins(sdk.NewCoin(suite.evmParam.EvmDenom, sdkmath.NewInt(123))),
+			"channel-0",
+			func() {
+				suite.app.CronosKeeper.SetBridgeEnabled(suite.ctx, false)
+			},
+			errors.New("bridge flows are suspended: bridge is disabled"),
+			func() {},
+		},
 		{
 			"Correct address with too small amount EVM token",
 			address.String(),
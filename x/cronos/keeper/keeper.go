@@ -193,14 +193,34 @@ func (k Keeper) SetAutoContractForDenom(ctx sdk.Context, denom string, address c
 	store.Set(types.ContractToDenomKey(address.Bytes()), []byte(denom))
 }
 
-// OnRecvVouchers try to convert ibc voucher to evm coins, revert the state in case of failure
+// OnRecvVouchers try to convert ibc voucher received from the channel to evm coins, revert the state in case of
+// failure
 func (k Keeper) OnRecvVouchers(
 	ctx sdk.Context,
 	tokens sdk.Coins,
 	receiver string,
+	channelID string,
 ) {
+	k.onVouchers(ctx, tokens, receiver, channelID, true)
+}
+
+// OnRefundVouchers gives back the outflow quota of the rate limits of the channel, and try to convert the refunded
+// ibc voucher to evm coins, revert the state of conversion in case of failure
+func (k Keeper) OnRefundVouchers(
+	ctx sdk.Context,
+	tokens sdk.Coins,
+	sender string,
+	channelID string,
+) {
+	for _, token := range tokens {
+		k.releaseRateLimits(ctx, token.Denom, channelID, token.Amount)
+	}
+	k.onVouchers(ctx, tokens, sender, channelID, false)
+}
+
+func (k Keeper) onVouchers(ctx sdk.Context, tokens sdk.Coins, receiver, channelID string, inflow bool) {
 	cacheCtx, commit := ctx.CacheContext()
-	err := k.ConvertVouchersToEvmCoins(cacheCtx, receiver, tokens)
+	err := k.convertVouchersToEvmCoins(cacheCtx, receiver, tokens, channelID, inflow)
 	if err == nil {
 		commit()
 	} else {
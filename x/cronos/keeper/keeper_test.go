@@ -245,6 +245,37 @@ func (suite *KeeperTestSuite) TestOnRecvVouchers() {
 				suite.Require().Equal(sdkmath.NewInt(0), evmCoin.Amount)
 			},
 		},
+		{
+			"vouchers kept when bridge disabled",
+			sdk.NewCoins(sdk.NewCoin(types.IbcCroDenomDefaultValue, sdkmath.NewInt(123))),
+			func() {
+				suite.MintCoins(address, sdk.NewCoins(sdk.NewCoin(types.IbcCroDenomDefaultValue, sdkmath.NewInt(123))))
+				suite.app.CronosKeeper.SetBridgeEnabled(suite.ctx, false)
+			},
+			func() {
+				// the vouchers can be converted after the bridge is enabled again
+				ibcCroCoin := suite.GetBalance(address, types.IbcCroDenomDefaultValue)
+				suite.Require().Equal(sdkmath.NewInt(123), ibcCroCoin.Amount)
+				evmCoin := suite.GetBalance(address, suite.evmParam.EvmDenom)
+				suite.Require().Equal(sdkmath.NewInt(0), evmCoin.Amount)
+			},
+		},
+		{
+			"vouchers kept when rate limit exceeded",
+			sdk.NewCoins(sdk.NewCoin(types.IbcCroDenomDefaultValue, sdkmath.NewInt(123))),
+			func() {
+				suite.MintCoins(address, sdk.NewCoins(sdk.NewCoin(types.IbcCroDenomDefaultValue, sdkmath.NewInt(123))))
+				suite.app.CronosKeeper.SetRateLimit(suite.ctx, types.NewRateLimit(
+					types.IbcCroDenomDefaultValue, "channel-0", sdkmath.NewInt(100), sdkmath.ZeroInt(), time.Hour,
+				))
+			},
+			func() {
+				ibcCroCoin := suite.GetBalance(address, types.IbcCroDenomDefaultValue)
+				suite.Require().Equal(sdkmath.NewInt(123), ibcCroCoin.Amount)
+				evmCoin := suite.GetBalance(address, suite.evmParam.EvmDenom)
+				suite.Require().Equal(sdkmath.NewInt(0), evmCoin.Amount)
+			},
+		},
 		{
 			"state committed upon success",
 			sdk.NewCoins(sdk.NewCoin(types.IbcCroDenomDefaultValue, sdkmath.NewInt(123))),
@@ -285,7 +316,7 @@ func (suite *KeeperTestSuite) TestOnRecvVouchers() {
 			suite.app.CronosKeeper = cronosKeeper
 
 			tc.malleate()
-			suite.app.CronosKeeper.OnRecvVouchers(suite.ctx, tc.coins, address.String())
+			suite.app.CronosKeeper.OnRecvVouchers(suite.ctx, tc.coins, address.String(), "channel-0")
 			tc.postCheck()
 		})
 	}
@@ -1,6 +1,9 @@
 package keeper_test
 
 import (
+	"time"
+
+	sdkmath "cosmossdk.io/math"
 	cronosmodulekeeper "github.com/crypto-org-chain/cronos/v2/x/cronos/keeper"
 	"github.com/crypto-org-chain/cronos/v2/x/cronos/types"
 
@@ -82,3 +85,65 @@ func (suite *KeeperTestSuite) TestUpdateParams() {
 		})
 	}
 }
+
+func (suite *KeeperTestSuite) TestTurnBridge() {
+	admin := sdk.AccAddress(suite.address.Bytes())
+	params := suite.app.CronosKeeper.GetParams(suite.ctx)
+	params.CronosAdmin = admin.String()
+	suite.Require().NoError(suite.app.CronosKeeper.SetParams(suite.ctx, params))
+
+	msgServer := cronosmodulekeeper.NewMsgServerImpl(suite.app.CronosKeeper)
+	suite.Require().True(suite.app.CronosKeeper.IsBridgeEnabled(suite.ctx))
+
+	// unauthorized sender
+	other := sdk.AccAddress([]byte("other"))
+	_, err := msgServer.TurnBridge(suite.ctx, types.NewMsgTurnBridge(other.String(), false))
+	suite.Require().ErrorContains(err, "msg sender is not authorized")
+	suite.Require().True(suite.app.CronosKeeper.IsBridgeEnabled(suite.ctx))
+
+	// authorized by permission
+	suite.app.CronosKeeper.SetPermissions(suite.ctx, other, cronosmodulekeeper.CanTurnBridge)
+	for _, enable := range []bool{false, true, false} {
+		ctx := suite.ctx.WithEventManager(sdk.NewEventManager())
+		_, err = msgServer.TurnBridge(ctx, types.NewMsgTurnBridge(other.String(), enable))
+		suite.Require().NoError(err)
+		suite.Require().Equal(enable, suite.app.CronosKeeper.IsBridgeEnabled(ctx))
+		suite.Require().Contains(ctx.EventManager().Events(), types.NewTurnBridgeEvent(other.String(), enable))
+
+		rsp, err := suite.app.CronosKeeper.BridgeStatus(ctx, &types.QueryBridgeStatusRequest{})
+		suite.Require().NoError(err)
+		suite.Require().Equal(enable, rsp.Enabled)
+	}
+
+	// admin has all the permissions
+	_, err = msgServer.TurnBridge(suite.ctx, types.NewMsgTurnBridge(admin.String(), true))
+	suite.Require().NoError(err)
+	suite.Require().True(suite.app.CronosKeeper.IsBridgeEnabled(suite.ctx))
+}
+
+func (suite *KeeperTestSuite) TestSetRateLimit() {
+	authority := authtypes.NewModuleAddress(govtypes.ModuleName).String()
+	limit := types.NewRateLimit(CorrectIbcDenom, "channel-0", sdkmath.NewInt(100), sdkmath.ZeroInt(), time.Hour)
+	msgServer := cronosmodulekeeper.NewMsgServerImpl(suite.app.CronosKeeper)
+
+	_, err := msgServer.SetRateLimit(suite.ctx, types.NewMsgSetRateLimit(sdk.AccAddress(suite.address.Bytes()).String(), limit))
+	suite.Require().ErrorContains(err, "invalid authority")
+
+	invalid := limit
+	invalid.Window = 0
+	_, err = msgServer.SetRateLimit(suite.ctx, types.NewMsgSetRateLimit(authority, invalid))
+	suite.Require().ErrorContains(err, "invalid window")
+
+	_, err = msgServer.SetRateLimit(suite.ctx, types.NewMsgSetRateLimit(authority, limit))
+	suite.Require().NoError(err)
+	stored, found := suite.app.CronosKeeper.GetRateLimit(suite.ctx, CorrectIbcDenom, "channel-0")
+	suite.Require().True(found)
+	suite.Require().Equal(limit, stored)
+
+	// empty limit removes it
+	_, err = msgServer.SetRateLimit(suite.ctx, types.NewMsgSetRateLimit(authority,
+		types.NewRateLimit(CorrectIbcDenom, "channel-0", sdkmath.ZeroInt(), sdkmath.ZeroInt(), 0)))
+	suite.Require().NoError(err)
+	_, found = suite.app.CronosKeeper.GetRateLimit(suite.ctx, CorrectIbcDenom, "channel-0")
+	suite.Require().False(found)
+}
@@ -3,11 +3,13 @@ package precompiles
 import (
 	"errors"
 	"math/big"
+	"strings"
 
 	storetypes "cosmossdk.io/store/types"
 
 	sdkmath "cosmossdk.io/math"
 	"github.com/cosmos/cosmos-sdk/codec"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -16,15 +18,19 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	errortypes "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/crypto-org-chain/cronos/v2/x/cronos/events/bindings/cosmos/precompile/bank"
+	cronostypes "github.com/crypto-org-chain/cronos/v2/x/cronos/types"
 	"github.com/evmos/ethermint/x/evm/types"
 )
 
 const (
-	EVMDenomPrefix      = "evm/"
-	MintMethodName      = "mint"
-	BurnMethodName      = "burn"
-	BalanceOfMethodName = "balanceOf"
-	TransferMethodName  = "transfer"
+	EVMDenomPrefix             = "evm/"
+	MintMethodName             = "mint"
+	BurnMethodName             = "burn"
+	BalanceOfMethodName        = "balanceOf"
+	TransferMethodName         = "transfer"
+	TotalSupplyMethodName      = "totalSupply"
+	SetDenomMetadataMethodName = "setDenomMetadata"
+	SendMethodName             = "send"
 )
 
 var (
@@ -43,10 +49,12 @@ func init() {
 		switch methodName {
 		case MintMethodName, BurnMethodName:
 			bankGasRequiredByMethod[methodID] = 200000
-		case BalanceOfMethodName:
+		case BalanceOfMethodName, TotalSupplyMethodName:
 			bankGasRequiredByMethod[methodID] = 10000
-		case TransferMethodName:
+		case TransferMethodName, SendMethodName:
 			bankGasRequiredByMethod[methodID] = 150000
+		case SetDenomMetadataMethodName:
+			bankGasRequiredByMethod[methodID] = 100000
 		default:
 			bankGasRequiredByMethod[methodID] = 0
 		}
@@ -58,14 +66,21 @@ func EVMDenom(token common.Address) string {
 }
 
 type BankContract struct {
-	bankKeeper  types.BankKeeper
-	cdc         codec.Codec
-	kvGasConfig storetypes.GasConfig
+	bankKeeper   BankKeeper
+	cronosKeeper cronostypes.CronosKeeper
+	cdc          codec.Codec
+	kvGasConfig  storetypes.GasConfig
 }
 
-// NewBankContract creates the precompiled contract to manage native tokens
-func NewBankContract(bankKeeper types.BankKeeper, cdc codec.Codec, kvGasConfig storetypes.GasConfig) vm.PrecompiledContract {
-	return &BankContract{bankKeeper, cdc, kvGasConfig}
+// NewBankContract creates the precompiled contract to manage native tokens,
+// it only works when enabled by the `enable_bank_precompile` param.
+func NewBankContract(
+	bankKeeper BankKeeper,
+	cronosKeeper cronostypes.CronosKeeper,
+	cdc codec.Codec,
+	kvGasConfig storetypes.GasConfig,
+) vm.PrecompiledContract {
+	return &BankContract{bankKeeper, cronosKeeper, cdc, kvGasConfig}
 }
 
 func (bc *BankContract) Address() common.Address {
@@ -96,6 +111,42 @@ func (bc *BankContract) checkBlockedAddr(addr sdk.AccAddress) error {
 	return nil
 }
 
+// checkDenomOwner checks if the caller contract can move the native tokens of the denom, which is either the
+// `evm/` denom of the caller, or the denom mapped to the caller in the token mapping.
+func (bc *BankContract) checkDenomOwner(ctx sdk.Context, caller common.Address, denom string) error {
+	if denom == EVMDenom(caller) {
+		return nil
+	}
+	if contract, found := bc.cronosKeeper.GetContractByDenom(ctx, denom); found && contract == caller {
+		return nil
+	}
+	return errorsmod.Wrapf(errortypes.ErrUnauthorized, "%s is not allowed to move %s", caller.Hex(), denom)
+}
+
+// denomMetadata builds the metadata of the denom, the same as the ones of the token mapping.
+func denomMetadata(denom, name, symbol string, decimals uint8) banktypes.Metadata {
+	metadata := banktypes.Metadata{
+		Base:    denom,
+		Display: denom,
+		Name:    name,
+		Symbol:  symbol,
+		DenomUnits: []*banktypes.DenomUnit{
+			{
+				Denom:    denom,
+				Exponent: 0,
+			},
+		},
+	}
+	if decimals != 0 {
+		metadata.Display = strings.ToLower(symbol)
+		metadata.DenomUnits = append(metadata.DenomUnits, &banktypes.DenomUnit{
+			Denom:    metadata.Display,
+			Exponent: uint32(decimals),
+		})
+	}
+	return metadata
+}
+
 func (bc *BankContract) Run(evm *vm.EVM, contract *vm.Contract, readonly bool) ([]byte, error) {
 	// parse input
 	methodID := contract.Input[:4]
@@ -104,6 +155,9 @@ func (bc *BankContract) Run(evm *vm.EVM, contract *vm.Contract, readonly bool) (
 		return nil, err
 	}
 	stateDB := evm.StateDB.(ExtStateDB)
+	if !bc.cronosKeeper.GetParams(stateDB.Context()).EnableBankPrecompile {
+		return nil, errors.New("bank precompile is disabled")
+	}
 	precompileAddr := bc.Address()
 	switch method.Name {
 	case MintMethodName, BurnMethodName:
@@ -194,6 +248,81 @@ func (bc *BankContract) Run(evm *vm.EVM, contract *vm.Contract, readonly bool) (
 			return nil, err
 		}
 		return method.Outputs.Pack(true)
+	case TotalSupplyMethodName:
+		args, err := method.Inputs.Unpack(contract.Input[4:])
+		if err != nil {
+			return nil, errors.New("fail to unpack input arguments")
+		}
+		token := args[0].(common.Address)
+		supply := bc.bankKeeper.GetSupply(stateDB.Context(), EVMDenom(token)).Amount.BigInt()
+		return method.Outputs.Pack(supply)
+	case SetDenomMetadataMethodName:
+		if readonly {
+			return nil, errors.New("the method is not readonly")
+		}
+		args, err := method.Inputs.Unpack(contract.Input[4:])
+		if err != nil {
+			return nil, errors.New("fail to unpack input arguments")
+		}
+		name := args[0].(string)
+		symbol := args[1].(string)
+		decimals := args[2].(uint8)
+		metadata := denomMetadata(EVMDenom(contract.CallerAddress), name, symbol, decimals)
+		if err := metadata.Validate(); err != nil {
+			return nil, errorsmod.Wrap(err, "invalid denom metadata")
+		}
+		err = stateDB.ExecuteNativeAction(precompileAddr, nil, func(ctx sdk.Context) error {
+			bc.bankKeeper.SetDenomMetaData(ctx, metadata)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return method.Outputs.Pack(true)
+	case SendMethodName:
+		if readonly {
+			return nil, errors.New("the method is not readonly")
+		}
+		args, err := method.Inputs.Unpack(contract.Input[4:])
+		if err != nil {
+			return nil, errors.New("fail to unpack input arguments")
+		}
+		sender := args[0].(common.Address)
+		recipient := args[1].(common.Address)
+		denom := args[2].(string)
+		amount := args[3].(*big.Int)
+		// the caller can only send its own coins, the owner of the denom is not the owner of the balances
+		if sender != contract.CallerAddress {
+			return nil, errorsmod.Wrapf(errortypes.ErrUnauthorized, "%s is not allowed to send the coins of %s", contract.CallerAddress.Hex(), sender.Hex())
+		}
+		if amount.Sign() <= 0 {
+			return nil, errors.New("invalid amount")
+		}
+		from := sdk.AccAddress(sender.Bytes())
+		to := sdk.AccAddress(recipient.Bytes())
+		if err := bc.checkBlockedAddr(to); err != nil {
+			return nil, err
+		}
+		if err := sdk.ValidateDenom(denom); err != nil {
+			return nil, err
+		}
+		amt := sdk.NewCoin(denom, sdkmath.NewIntFromBigInt(amount))
+		err = stateDB.ExecuteNativeAction(precompileAddr, nil, func(ctx sdk.Context) error {
+			if err := bc.checkDenomOwner(ctx, contract.CallerAddress, denom); err != nil {
+				return err
+			}
+			if err := bc.bankKeeper.IsSendEnabledCoins(ctx, amt); err != nil {
+				return err
+			}
+			if err := bc.bankKeeper.SendCoins(ctx, from, to, sdk.NewCoins(amt)); err != nil {
+				return errorsmod.Wrap(err, "fail to send coins in precompiled contract")
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return method.Outputs.Pack(true)
 	default:
 		return nil, errors.New("unknown method")
 	}
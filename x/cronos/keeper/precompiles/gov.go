@@ -0,0 +1,291 @@
+package precompiles
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govkeeper "github.com/cosmos/cosmos-sdk/x/gov/keeper"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	cronosevents "github.com/crypto-org-chain/cronos/v2/x/cronos/events"
+	"github.com/crypto-org-chain/cronos/v2/x/cronos/events/bindings/cosmos/precompile/gov"
+	cronostypes "github.com/crypto-org-chain/cronos/v2/x/cronos/types"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+const (
+	SubmitProposalMethodName = "submitProposal"
+	DepositMethodName        = "deposit"
+	VoteMethodName           = "vote"
+	VoteWeightedMethodName   = "voteWeighted"
+	ProposalMethodName       = "proposal"
+	TallyMethodName          = "tally"
+)
+
+var (
+	govABI                 abi.ABI
+	govContractAddress     = common.BytesToAddress([]byte{104})
+	govGasRequiredByMethod = map[[4]byte]uint64{}
+)
+
+func init() {
+	if err := govABI.UnmarshalJSON([]byte(gov.GovModuleMetaData.ABI)); err != nil {
+		panic(err)
+	}
+	for methodName := range govABI.Methods {
+		var methodID [4]byte
+		copy(methodID[:], govABI.Methods[methodName].ID[:4])
+		switch methodName {
+		case SubmitProposalMethodName:
+			govGasRequiredByMethod[methodID] = 300000
+		case DepositMethodName:
+			govGasRequiredByMethod[methodID] = 200000
+		case VoteMethodName, VoteWeightedMethodName:
+			govGasRequiredByMethod[methodID] = 100000
+		case ProposalMethodName:
+			govGasRequiredByMethod[methodID] = 10000
+		case TallyMethodName:
+			govGasRequiredByMethod[methodID] = 100000
+		default:
+			govGasRequiredByMethod[methodID] = 0
+		}
+	}
+}
+
+type GovContract struct {
+	BaseContract
+
+	cdc          codec.Codec
+	govKeeper    *govkeeper.Keeper
+	cronosKeeper cronostypes.CronosKeeper
+	kvGasConfig  storetypes.GasConfig
+}
+
+// NewGovContract creates the precompiled contract for the evm contracts to take part in the governance with their
+// own accounts, the native events are converted to the evm logs,
+// it only works when enabled by the `enable_gov_precompile` param.
+func NewGovContract(
+	govKeeper *govkeeper.Keeper,
+	cronosKeeper cronostypes.CronosKeeper,
+	cdc codec.Codec,
+	kvGasConfig storetypes.GasConfig,
+) vm.PrecompiledContract {
+	return &GovContract{
+		BaseContract: NewBaseContract(govContractAddress),
+		cdc:          cdc,
+		govKeeper:    govKeeper,
+		cronosKeeper: cronosKeeper,
+		kvGasConfig:  kvGasConfig,
+	}
+}
+
+func (gc *GovContract) Address() common.Address {
+	return govContractAddress
+}
+
+// RequiredGas calculates the contract gas use
+func (gc *GovContract) RequiredGas(input []byte) uint64 {
+	// base cost to prevent large input size
+	baseCost := uint64(len(input)) * gc.kvGasConfig.WriteCostPerByte
+	var methodID [4]byte
+	copy(methodID[:], input[:4])
+	requiredGas, ok := govGasRequiredByMethod[methodID]
+	if ok {
+		return requiredGas + baseCost
+	}
+	return baseCost
+}
+
+func (gc *GovContract) Run(evm *vm.EVM, contract *vm.Contract, readonly bool) ([]byte, error) {
+	// parse input
+	methodID := contract.Input[:4]
+	method, err := govABI.MethodById(methodID)
+	if err != nil {
+		return nil, err
+	}
+	stateDB := evm.StateDB.(ExtStateDB)
+	if !gc.cronosKeeper.GetParams(stateDB.Context()).EnableGovPrecompile {
+		return nil, errors.New("gov precompile is disabled")
+	}
+	if !method.IsConstant() && readonly {
+		return nil, errors.New("the method is not readonly")
+	}
+	args, err := method.Inputs.Unpack(contract.Input[4:])
+	if err != nil {
+		return nil, errors.New("fail to unpack input arguments")
+	}
+	precompileAddr := gc.Address()
+	caller := sdk.AccAddress(contract.CallerAddress.Bytes())
+	converter := cronosevents.GovConvertEvent
+	switch method.Name {
+	case SubmitProposalMethodName:
+		e := &Executor{
+			cdc:       gc.cdc,
+			stateDB:   stateDB,
+			caller:    contract.CallerAddress,
+			contract:  precompileAddr,
+			input:     args[0].([]byte),
+			converter: converter,
+		}
+		res, err := execute(e, govkeeper.NewMsgServerImpl(gc.govKeeper).SubmitProposal)
+		if err != nil {
+			return nil, err
+		}
+		return method.Outputs.Pack(res.ProposalId)
+	case DepositMethodName:
+		proposalID := args[0].(uint64)
+		amount := *abi.ConvertType(args[1], new([]gov.CosmosCoin)).(*[]gov.CosmosCoin)
+		coins := make(sdk.Coins, len(amount))
+		for i, coin := range amount {
+			coins[i] = sdk.Coin{Denom: coin.Denom, Amount: sdkmath.NewIntFromBigInt(coin.Amount)}
+		}
+		if err := stateDB.ExecuteNativeAction(precompileAddr, converter, func(ctx sdk.Context) error {
+			_, err := govkeeper.NewMsgServerImpl(gc.govKeeper).Deposit(ctx, govv1.NewMsgDeposit(caller, proposalID, coins))
+			return err
+		}); err != nil {
+			return nil, err
+		}
+		return method.Outputs.Pack(true)
+	case VoteMethodName:
+		proposalID := args[0].(uint64)
+		option := govv1.VoteOption(args[1].(int32))
+		metadata := args[2].(string)
+		if err := stateDB.ExecuteNativeAction(precompileAddr, converter, func(ctx sdk.Context) error {
+			_, err := govkeeper.NewMsgServerImpl(gc.govKeeper).Vote(ctx, govv1.NewMsgVote(caller, proposalID, option, metadata))
+			return err
+		}); err != nil {
+			return nil, err
+		}
+		return method.Outputs.Pack(true)
+	case VoteWeightedMethodName:
+		proposalID := args[0].(uint64)
+		input := *abi.ConvertType(args[1], new([]gov.IGovModuleWeightedVoteOption)).(*[]gov.IGovModuleWeightedVoteOption)
+		metadata := args[2].(string)
+		options := make(govv1.WeightedVoteOptions, len(input))
+		for i, option := range input {
+			options[i] = &govv1.WeightedVoteOption{Option: govv1.VoteOption(option.Option), Weight: option.Weight}
+		}
+		if err := stateDB.ExecuteNativeAction(precompileAddr, converter, func(ctx sdk.Context) error {
+			msg := govv1.NewMsgVoteWeighted(caller, proposalID, options, metadata)
+			_, err := govkeeper.NewMsgServerImpl(gc.govKeeper).VoteWeighted(ctx, msg)
+			return err
+		}); err != nil {
+			return nil, err
+		}
+		return method.Outputs.Pack(true)
+	case ProposalMethodName:
+		proposalID := args[0].(uint64)
+		rsp, err := govkeeper.NewQueryServer(gc.govKeeper).Proposal(stateDB.Context(), &govv1.QueryProposalRequest{
+			ProposalId: proposalID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		proposal, err := toGovProposal(rsp.Proposal)
+		if err != nil {
+			return nil, err
+		}
+		return method.Outputs.Pack(proposal)
+	case TallyMethodName:
+		proposalID := args[0].(uint64)
+		tally, err := gc.tally(stateDB.Context(), contract, proposalID)
+		if err != nil {
+			return nil, err
+		}
+		return method.Outputs.Pack(gov.IGovModuleTallyResult{
+			Yes:        parseTallyCount(tally.YesCount),
+			Abstain:    parseTallyCount(tally.AbstainCount),
+			No:         parseTallyCount(tally.NoCount),
+			NoWithVeto: parseTallyCount(tally.NoWithVetoCount),
+		})
+	default:
+		return nil, errors.New("unknown method")
+	}
+}
+
+// tally returns the stored final tally result of the finished proposal, the proposal in voting period is tallied
+// on the fly, which visits all the votes and the delegations of the voters, so the store accesses are charged to the
+// contract on top of the fixed method gas.
+func (gc *GovContract) tally(ctx sdk.Context, contract *vm.Contract, proposalID uint64) (tally *govv1.TallyResult, err error) {
+	// the tally of the proposal in voting period removes the votes, run it in a branched context
+	ctx, _ = ctx.CacheContext()
+	gasMeter := storetypes.NewGasMeter(contract.Gas)
+	ctx = ctx.WithGasMeter(gasMeter).WithKVGasConfig(gc.kvGasConfig)
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(storetypes.ErrorOutOfGas); !ok {
+				panic(r)
+			}
+			contract.UseGas(contract.Gas)
+			tally, err = nil, vm.ErrOutOfGas
+		}
+	}()
+	rsp, err := govkeeper.NewQueryServer(gc.govKeeper).TallyResult(ctx, &govv1.QueryTallyResultRequest{
+		ProposalId: proposalID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !contract.UseGas(gasMeter.GasConsumed()) {
+		return nil, vm.ErrOutOfGas
+	}
+	return rsp.Tally, nil
+}
+
+func toGovProposal(proposal *govv1.Proposal) (gov.IGovModuleProposal, error) {
+	proposer, err := sdk.AccAddressFromBech32(proposal.Proposer)
+	if err != nil {
+		return gov.IGovModuleProposal{}, fmt.Errorf("invalid proposer: %w", err)
+	}
+	messages := make([]string, len(proposal.Messages))
+	for i, msg := range proposal.Messages {
+		messages[i] = msg.TypeUrl
+	}
+	totalDeposit := make([]gov.CosmosCoin, len(proposal.TotalDeposit))
+	for i, coin := range proposal.TotalDeposit {
+		totalDeposit[i] = gov.CosmosCoin{
+			Amount: coin.Amount.BigInt(),
+			Denom:  coin.Denom,
+		}
+	}
+	return gov.IGovModuleProposal{
+		Id:              proposal.Id,
+		Status:          int32(proposal.Status),
+		Proposer:        common.BytesToAddress(proposer),
+		Title:           proposal.Title,
+		Summary:         proposal.Summary,
+		Metadata:        proposal.Metadata,
+		Messages:        messages,
+		TotalDeposit:    totalDeposit,
+		SubmitTime:      unixTime(proposal.SubmitTime),
+		DepositEndTime:  unixTime(proposal.DepositEndTime),
+		VotingStartTime: unixTime(proposal.VotingStartTime),
+		VotingEndTime:   unixTime(proposal.VotingEndTime),
+	}, nil
+}
+
+// unixTime returns the unix timestamp in seconds, or zero if not set.
+func unixTime(t *time.Time) int64 {
+	if t == nil {
+		return 0
+	}
+	return t.Unix()
+}
+
+// parseTallyCount parses the integer count of the tally result, the empty one is zero.
+func parseTallyCount(count string) *big.Int {
+	result, ok := new(big.Int).SetString(count, 10)
+	if !ok {
+		return big.NewInt(0)
+	}
+	return result
+}
@@ -1,10 +1,14 @@
 package precompiles
 
 import (
+	"context"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/evmos/ethermint/x/evm/statedb"
+	evmtypes "github.com/evmos/ethermint/x/evm/types"
 )
 
 // ExtStateDB defines extra methods of statedb to support stateful precompiled contracts
@@ -13,3 +17,10 @@ type ExtStateDB interface {
 	ExecuteNativeAction(contract common.Address, converter statedb.EventConverter, action func(ctx sdk.Context) error) error
 	Context() sdk.Context
 }
+
+// BankKeeper defines the bank methods used by the bank precompiled contract
+type BankKeeper interface {
+	evmtypes.BankKeeper
+	GetSupply(ctx context.Context, denom string) sdk.Coin
+	SetDenomMetaData(ctx context.Context, denomMetaData banktypes.Metadata)
+}
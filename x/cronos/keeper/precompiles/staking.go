@@ -0,0 +1,319 @@
+package precompiles
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	sdkmath "cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	distrkeeper "github.com/cosmos/cosmos-sdk/x/distribution/keeper"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	cronosevents "github.com/crypto-org-chain/cronos/v2/x/cronos/events"
+	"github.com/crypto-org-chain/cronos/v2/x/cronos/events/bindings/cosmos/precompile/staking"
+	cronostypes "github.com/crypto-org-chain/cronos/v2/x/cronos/types"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+const (
+	DelegateMethodName     = "delegate"
+	UndelegateMethodName   = "undelegate"
+	RedelegateMethodName   = "redelegate"
+	ClaimRewardsMethodName = "claimRewards"
+	DelegationMethodName   = "delegation"
+	DelegationsMethodName  = "delegations"
+	ValidatorMethodName    = "validator"
+	ValidatorsMethodName   = "validators"
+)
+
+var (
+	stakingABI                 abi.ABI
+	stakingContractAddress     = common.BytesToAddress([]byte{103})
+	stakingGasRequiredByMethod = map[[4]byte]uint64{}
+)
+
+func init() {
+	if err := stakingABI.UnmarshalJSON([]byte(staking.StakingModuleMetaData.ABI)); err != nil {
+		panic(err)
+	}
+	for methodName := range stakingABI.Methods {
+		var methodID [4]byte
+		copy(methodID[:], stakingABI.Methods[methodName].ID[:4])
+		switch methodName {
+		case DelegateMethodName, UndelegateMethodName, ClaimRewardsMethodName:
+			stakingGasRequiredByMethod[methodID] = 300000
+		case RedelegateMethodName:
+			stakingGasRequiredByMethod[methodID] = 400000
+		case DelegationMethodName, ValidatorMethodName:
+			stakingGasRequiredByMethod[methodID] = 10000
+		case DelegationsMethodName, ValidatorsMethodName:
+			stakingGasRequiredByMethod[methodID] = 100000
+		default:
+			stakingGasRequiredByMethod[methodID] = 0
+		}
+	}
+}
+
+type StakingContract struct {
+	BaseContract
+
+	cdc           codec.Codec
+	stakingKeeper *stakingkeeper.Keeper
+	distrKeeper   distrkeeper.Keeper
+	cronosKeeper  cronostypes.CronosKeeper
+	kvGasConfig   storetypes.GasConfig
+}
+
+// NewStakingContract creates the precompiled contract for the evm contracts to stake the bond denom of their own
+// accounts and withdraw the rewards, the native events are converted to the evm logs,
+// it only works when enabled by the `enable_staking_precompile` param.
+func NewStakingContract(
+	stakingKeeper *stakingkeeper.Keeper,
+	distrKeeper distrkeeper.Keeper,
+	cronosKeeper cronostypes.CronosKeeper,
+	cdc codec.Codec,
+	kvGasConfig storetypes.GasConfig,
+) vm.PrecompiledContract {
+	return &StakingContract{
+		BaseContract:  NewBaseContract(stakingContractAddress),
+		cdc:           cdc,
+		stakingKeeper: stakingKeeper,
+		distrKeeper:   distrKeeper,
+		cronosKeeper:  cronosKeeper,
+		kvGasConfig:   kvGasConfig,
+	}
+}
+
+func (sc *StakingContract) Address() common.Address {
+	return stakingContractAddress
+}
+
+// RequiredGas calculates the contract gas use
+func (sc *StakingContract) RequiredGas(input []byte) uint64 {
+	// base cost to prevent large input size
+	baseCost := uint64(len(input)) * sc.kvGasConfig.WriteCostPerByte
+	var methodID [4]byte
+	copy(methodID[:], input[:4])
+	requiredGas, ok := stakingGasRequiredByMethod[methodID]
+	if ok {
+		return requiredGas + baseCost
+	}
+	return baseCost
+}
+
+func (sc *StakingContract) Run(evm *vm.EVM, contract *vm.Contract, readonly bool) ([]byte, error) {
+	// parse input
+	methodID := contract.Input[:4]
+	method, err := stakingABI.MethodById(methodID)
+	if err != nil {
+		return nil, err
+	}
+	stateDB := evm.StateDB.(ExtStateDB)
+	if !sc.cronosKeeper.GetParams(stateDB.Context()).EnableStakingPrecompile {
+		return nil, errors.New("staking precompile is disabled")
+	}
+	if !method.IsConstant() && readonly {
+		return nil, errors.New("the method is not readonly")
+	}
+	args, err := method.Inputs.Unpack(contract.Input[4:])
+	if err != nil {
+		return nil, errors.New("fail to unpack input arguments")
+	}
+	precompileAddr := sc.Address()
+	delegator := sdk.AccAddress(contract.CallerAddress.Bytes()).String()
+	converter := cronosevents.StakingConvertEvent
+	switch method.Name {
+	case DelegateMethodName:
+		validator := args[0].(string)
+		amount := args[1].(*big.Int)
+		if err := stateDB.ExecuteNativeAction(precompileAddr, converter, func(ctx sdk.Context) error {
+			coin, err := sc.bondCoin(ctx, amount)
+			if err != nil {
+				return err
+			}
+			msgServer := stakingkeeper.NewMsgServerImpl(sc.stakingKeeper)
+			_, err = msgServer.Delegate(ctx, stakingtypes.NewMsgDelegate(delegator, validator, coin))
+			return err
+		}); err != nil {
+			return nil, err
+		}
+		return method.Outputs.Pack(true)
+	case UndelegateMethodName:
+		validator := args[0].(string)
+		amount := args[1].(*big.Int)
+		var completionTime int64
+		if err := stateDB.ExecuteNativeAction(precompileAddr, converter, func(ctx sdk.Context) error {
+			coin, err := sc.bondCoin(ctx, amount)
+			if err != nil {
+				return err
+			}
+			msgServer := stakingkeeper.NewMsgServerImpl(sc.stakingKeeper)
+			rsp, err := msgServer.Undelegate(ctx, stakingtypes.NewMsgUndelegate(delegator, validator, coin))
+			if err != nil {
+				return err
+			}
+			completionTime = rsp.CompletionTime.Unix()
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		return method.Outputs.Pack(completionTime)
+	case RedelegateMethodName:
+		srcValidator := args[0].(string)
+		dstValidator := args[1].(string)
+		amount := args[2].(*big.Int)
+		var completionTime int64
+		if err := stateDB.ExecuteNativeAction(precompileAddr, converter, func(ctx sdk.Context) error {
+			coin, err := sc.bondCoin(ctx, amount)
+			if err != nil {
+				return err
+			}
+			msgServer := stakingkeeper.NewMsgServerImpl(sc.stakingKeeper)
+			rsp, err := msgServer.BeginRedelegate(ctx, stakingtypes.NewMsgBeginRedelegate(delegator, srcValidator, dstValidator, coin))
+			if err != nil {
+				return err
+			}
+			completionTime = rsp.CompletionTime.Unix()
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		return method.Outputs.Pack(completionTime)
+	case ClaimRewardsMethodName:
+		validator := args[0].(string)
+		var rewards sdk.Coins
+		if err := stateDB.ExecuteNativeAction(precompileAddr, converter, func(ctx sdk.Context) error {
+			msgServer := distrkeeper.NewMsgServerImpl(sc.distrKeeper)
+			rsp, err := msgServer.WithdrawDelegatorReward(ctx, distrtypes.NewMsgWithdrawDelegatorReward(delegator, validator))
+			if err != nil {
+				return err
+			}
+			rewards = rsp.Amount
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		return method.Outputs.Pack(toStakingCoins(rewards))
+	case DelegationMethodName:
+		owner := sdk.AccAddress(args[0].(common.Address).Bytes())
+		valAddr, err := sdk.ValAddressFromBech32(args[1].(string))
+		if err != nil {
+			return nil, err
+		}
+		ctx := stateDB.Context()
+		result := staking.IStakingModuleDelegation{
+			Validator: valAddr.String(),
+			Shares:    big.NewInt(0),
+			Balance:   big.NewInt(0),
+		}
+		delegation, err := sc.stakingKeeper.GetDelegation(ctx, owner, valAddr)
+		if err == nil {
+			if result, err = sc.toDelegation(ctx, delegation); err != nil {
+				return nil, err
+			}
+		} else if !errors.Is(err, stakingtypes.ErrNoDelegation) {
+			return nil, err
+		}
+		return method.Outputs.Pack(result)
+	case DelegationsMethodName:
+		owner := sdk.AccAddress(args[0].(common.Address).Bytes())
+		ctx := stateDB.Context()
+		maxValidators, err := sc.stakingKeeper.MaxValidators(ctx)
+		if err != nil {
+			return nil, err
+		}
+		delegations, err := sc.stakingKeeper.GetDelegatorDelegations(ctx, owner, uint16(maxValidators))
+		if err != nil {
+			return nil, err
+		}
+		result := make([]staking.IStakingModuleDelegation, len(delegations))
+		for i, delegation := range delegations {
+			if result[i], err = sc.toDelegation(ctx, delegation); err != nil {
+				return nil, err
+			}
+		}
+		return method.Outputs.Pack(result)
+	case ValidatorMethodName:
+		valAddr, err := sdk.ValAddressFromBech32(args[0].(string))
+		if err != nil {
+			return nil, err
+		}
+		validator, err := sc.stakingKeeper.GetValidator(stateDB.Context(), valAddr)
+		if err != nil {
+			return nil, err
+		}
+		return method.Outputs.Pack(toStakingValidator(validator))
+	case ValidatorsMethodName:
+		validators, err := sc.stakingKeeper.GetBondedValidatorsByPower(stateDB.Context())
+		if err != nil {
+			return nil, err
+		}
+		result := make([]staking.IStakingModuleValidator, len(validators))
+		for i, validator := range validators {
+			result[i] = toStakingValidator(validator)
+		}
+		return method.Outputs.Pack(result)
+	default:
+		return nil, errors.New("unknown method")
+	}
+}
+
+// bondCoin returns the coin of the bond denom.
+func (sc *StakingContract) bondCoin(ctx sdk.Context, amount *big.Int) (sdk.Coin, error) {
+	if amount.Sign() <= 0 {
+		return sdk.Coin{}, fmt.Errorf("invalid amount: %s", amount)
+	}
+	denom, err := sc.stakingKeeper.BondDenom(ctx)
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+	return sdk.NewCoin(denom, sdkmath.NewIntFromBigInt(amount)), nil
+}
+
+// toDelegation converts the delegation, the balance is the tokens of the delegation shares.
+func (sc *StakingContract) toDelegation(ctx sdk.Context, delegation stakingtypes.Delegation) (staking.IStakingModuleDelegation, error) {
+	valAddr, err := sdk.ValAddressFromBech32(delegation.ValidatorAddress)
+	if err != nil {
+		return staking.IStakingModuleDelegation{}, err
+	}
+	validator, err := sc.stakingKeeper.GetValidator(ctx, valAddr)
+	if err != nil {
+		return staking.IStakingModuleDelegation{}, err
+	}
+	return staking.IStakingModuleDelegation{
+		Validator: delegation.ValidatorAddress,
+		Shares:    delegation.Shares.BigInt(),
+		Balance:   validator.TokensFromShares(delegation.Shares).TruncateInt().BigInt(),
+	}, nil
+}
+
+func toStakingValidator(validator stakingtypes.Validator) staking.IStakingModuleValidator {
+	return staking.IStakingModuleValidator{
+		OperatorAddress: validator.OperatorAddress,
+		Moniker:         validator.GetMoniker(),
+		Jailed:          validator.IsJailed(),
+		Status:          int32(validator.Status),
+		Tokens:          validator.Tokens.BigInt(),
+		DelegatorShares: validator.DelegatorShares.BigInt(),
+		CommissionRate:  validator.Commission.Rate.BigInt(),
+	}
+}
+
+func toStakingCoins(coins sdk.Coins) []staking.CosmosCoin {
+	result := make([]staking.CosmosCoin, len(coins))
+	for i, coin := range coins {
+		result[i] = staking.CosmosCoin{
+			Amount: coin.Amount.BigInt(),
+			Denom:  coin.Denom,
+		}
+	}
+	return result
+}
@@ -29,37 +29,74 @@ type Executor struct {
 // exec is a generic function that executes the given action in statedb, and marshal/unmarshal the input/output
 func exec[Req any, PReq interface {
 	*Req
-	NativeMessage
+	proto.Message
 }, Resp proto.Message](
 	e *Executor,
 	action func(context.Context, PReq) (Resp, error),
 ) ([]byte, error) {
+	res, err := execute(e, action)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := e.cdc.Marshal(res)
+	if err != nil {
+		return nil, fmt.Errorf("fail to Marshal %T %w", res, err)
+	}
+	return output, nil
+}
+
+// execute unmarshal the input and executes the given action in statedb after the caller is authenticated as the
+// signer of the message, returns the response as is.
+func execute[Req any, PReq interface {
+	*Req
+	proto.Message
+}, Resp proto.Message](
+	e *Executor,
+	action func(context.Context, PReq) (Resp, error),
+) (Resp, error) {
+	var res Resp
 	msg := PReq(new(Req))
 	if err := e.cdc.Unmarshal(e.input, msg); err != nil {
-		return nil, fmt.Errorf("fail to Unmarshal %T %w", msg, err)
+		return res, fmt.Errorf("fail to Unmarshal %T %w", msg, err)
 	}
 
-	signers := msg.GetSigners()
+	signers, err := e.signers(msg)
+	if err != nil {
+		return res, err
+	}
 	if len(signers) != 1 {
-		return nil, errors.New("don't support multi-signers message")
+		return res, errors.New("don't support multi-signers message")
 	}
-	caller := common.BytesToAddress(signers[0].Bytes())
+	caller := common.BytesToAddress(signers[0])
 	if caller != e.caller {
-		return nil, fmt.Errorf("caller is not authenticated: expected %s, got %s", e.caller.Hex(), caller.Hex())
+		return res, fmt.Errorf("caller is not authenticated: expected %s, got %s", e.caller.Hex(), caller.Hex())
 	}
 
-	var res Resp
 	if err := e.stateDB.ExecuteNativeAction(e.contract, e.converter, func(ctx sdk.Context) error {
 		var err error
 		res, err = action(ctx, msg)
 		return err
 	}); err != nil {
-		return nil, err
+		return res, err
 	}
+	return res, nil
+}
 
-	output, err := e.cdc.Marshal(res)
+// signers returns the signers of the message, falls back to the signer annotations of the message
+// if it don't implement the legacy GetSigners.
+func (e *Executor) signers(msg proto.Message) ([][]byte, error) {
+	if msg, ok := msg.(NativeMessage); ok {
+		signers := msg.GetSigners()
+		result := make([][]byte, len(signers))
+		for i, signer := range signers {
+			result[i] = signer
+		}
+		return result, nil
+	}
+	signers, _, err := e.cdc.GetMsgV1Signers(msg)
 	if err != nil {
-		return nil, fmt.Errorf("fail to Marshal %T %w", res, err)
+		return nil, fmt.Errorf("fail to get signers of %T %w", msg, err)
 	}
-	return output, nil
+	return signers, nil
 }
@@ -0,0 +1,417 @@
+package keeper_test
+
+import (
+	"math/big"
+	"time"
+
+	"cosmossdk.io/collections"
+	sdkmath "cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govkeeper "github.com/cosmos/cosmos-sdk/x/gov/keeper"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
+	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/evmos/ethermint/crypto/ethsecp256k1"
+	"github.com/evmos/ethermint/x/evm/statedb"
+
+	"github.com/crypto-org-chain/cronos/v2/x/cronos/events/bindings/cosmos/precompile/gov"
+	"github.com/crypto-org-chain/cronos/v2/x/cronos/events/bindings/cosmos/precompile/staking"
+	"github.com/crypto-org-chain/cronos/v2/x/cronos/keeper/precompiles"
+)
+
+// precompileGasLimit is the gas supplied to the precompiled contract calls in the tests.
+const precompileGasLimit = 10000000
+
+// runPrecompile calls the precompiled contract from the caller in a new statedb, the tx origin is always
+// `suite.address`, the native changes are committed into `suite.ctx` if succeeded.
+func (suite *KeeperTestSuite) runPrecompile(
+	contract vm.PrecompiledContract, caller common.Address, input []byte, readonly bool,
+) ([]byte, []*ethtypes.Log, error) {
+	output, logs, _, err := suite.runPrecompileWithGas(contract, caller, input, readonly, precompileGasLimit)
+	return output, logs, err
+}
+
+// runPrecompileWithGas is like runPrecompile but supplies the gas to the call the same way as the evm does, it
+// returns the gas left.
+func (suite *KeeperTestSuite) runPrecompileWithGas(
+	contract vm.PrecompiledContract, caller common.Address, input []byte, readonly bool, gas uint64,
+) ([]byte, []*ethtypes.Log, uint64, error) {
+	stateDB := statedb.New(suite.ctx, suite.app.EvmKeeper, statedb.NewEmptyTxConfig(common.BytesToHash(suite.ctx.HeaderHash())))
+	evm := &vm.EVM{
+		StateDB:   stateDB,
+		TxContext: vm.TxContext{Origin: suite.address},
+	}
+	c := vm.NewContract(vm.AccountRef(caller), vm.AccountRef(contract.Address()), big.NewInt(0), gas)
+	c.Input = input
+	if !c.UseGas(contract.RequiredGas(input)) {
+		return nil, nil, c.Gas, vm.ErrOutOfGas
+	}
+	output, err := contract.Run(evm, c, readonly)
+	if err != nil {
+		return nil, nil, c.Gas, err
+	}
+	suite.Require().NoError(stateDB.Commit())
+	return output, stateDB.Logs(), c.Gas, nil
+}
+
+// requireLog checks the logs contain the event emitted by the contract, the arguments are in the abi order.
+func (suite *KeeperTestSuite) requireLog(logs []*ethtypes.Log, contract common.Address, event abi.Event, args ...any) {
+	var indexed, nonIndexed []any
+	for i, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, args[i])
+		} else {
+			nonIndexed = append(nonIndexed, args[i])
+		}
+	}
+	topics, err := abi.MakeTopics(append([]any{event.ID}, indexed...))
+	suite.Require().NoError(err)
+	data, err := event.Inputs.NonIndexed().Pack(nonIndexed...)
+	suite.Require().NoError(err)
+
+	for _, log := range logs {
+		if log.Address == contract && len(log.Topics) > 0 && log.Topics[0] == event.ID {
+			suite.Require().Equal(topics[0], log.Topics)
+			suite.Require().Equal(data, log.Data)
+			return
+		}
+	}
+	suite.Require().Failf("log not found", "event %s", event.Name)
+}
+
+func (suite *KeeperTestSuite) TestStakingPrecompile() {
+	suite.SetupTest()
+	contract := precompiles.NewStakingContract(
+		suite.app.StakingKeeper, suite.app.DistrKeeper, &suite.app.CronosKeeper, suite.app.AppCodec(), storetypes.KVGasConfig(),
+	)
+	stakingABI, err := staking.StakingModuleMetaData.GetAbi()
+	suite.Require().NoError(err)
+	pack := func(method string, args ...any) []byte {
+		input, err := stakingABI.Pack(method, args...)
+		suite.Require().NoError(err)
+		return input
+	}
+
+	privKey, err := ethsecp256k1.GenerateKey()
+	suite.Require().NoError(err)
+	caller := common.BytesToAddress(privKey.PubKey().Address())
+	delegator := sdk.AccAddress(caller.Bytes())
+	origin := sdk.AccAddress(suite.address.Bytes())
+
+	bondDenom, err := suite.app.StakingKeeper.BondDenom(suite.ctx)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.MintCoins(delegator, sdk.NewCoins(sdk.NewInt64Coin(bondDenom, 1000000))))
+	validators, err := suite.app.StakingKeeper.GetBondedValidatorsByPower(suite.ctx)
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(validators)
+	validator := validators[0].OperatorAddress
+	valAddr, err := sdk.ValAddressFromBech32(validator)
+	suite.Require().NoError(err)
+	bondCoins := func(amount int64) []staking.CosmosCoin {
+		return []staking.CosmosCoin{{Amount: big.NewInt(amount), Denom: bondDenom}}
+	}
+
+	// the contract is disabled by default, the calls fail
+	input := pack(precompiles.DelegateMethodName, validator, big.NewInt(1000000))
+	_, _, err = suite.runPrecompile(contract, caller, input, false)
+	suite.Require().ErrorContains(err, "staking precompile is disabled")
+	_, _, err = suite.runPrecompile(contract, caller, pack(precompiles.ValidatorMethodName, validator), true)
+	suite.Require().ErrorContains(err, "staking precompile is disabled")
+	params := suite.app.CronosKeeper.GetParams(suite.ctx)
+	params.EnableStakingPrecompile = true
+	suite.Require().NoError(suite.app.CronosKeeper.SetParams(suite.ctx, params))
+
+	// the inputs which can't be decoded are rejected
+	_, _, err = suite.runPrecompile(contract, caller, []byte{1, 2, 3, 4}, false)
+	suite.Require().Error(err)
+	_, _, err = suite.runPrecompile(contract, caller, input[:4+32], false)
+	suite.Require().ErrorContains(err, "fail to unpack input arguments")
+	_, _, err = suite.runPrecompile(contract, caller, pack(precompiles.DelegateMethodName, validator, big.NewInt(0)), false)
+	suite.Require().ErrorContains(err, "invalid amount")
+
+	// the state changing methods are rejected in the readonly calls
+	_, _, err = suite.runPrecompile(contract, caller, input, true)
+	suite.Require().ErrorContains(err, "the method is not readonly")
+	_, err = suite.app.StakingKeeper.GetDelegation(suite.ctx, delegator, valAddr)
+	suite.Require().ErrorIs(err, stakingtypes.ErrNoDelegation)
+
+	// delegate the coins of the caller, not the tx origin
+	output, logs, err := suite.runPrecompile(contract, caller, input, false)
+	suite.Require().NoError(err)
+	result, err := stakingABI.Unpack(precompiles.DelegateMethodName, output)
+	suite.Require().NoError(err)
+	suite.Require().Equal([]any{true}, result)
+	delegation, err := suite.app.StakingKeeper.GetDelegation(suite.ctx, delegator, valAddr)
+	suite.Require().NoError(err)
+	_, err = suite.app.StakingKeeper.GetDelegation(suite.ctx, origin, valAddr)
+	suite.Require().ErrorIs(err, stakingtypes.ErrNoDelegation)
+	suite.Require().True(suite.GetBalance(delegator, bondDenom).IsZero())
+	suite.requireLog(logs, contract.Address(), stakingABI.Events["Delegate"], caller, validator, bondCoins(1000000), delegation.Shares.String())
+
+	// the queries are allowed in the readonly calls
+	output, _, err = suite.runPrecompile(contract, caller, pack(precompiles.DelegationMethodName, caller, validator), true)
+	suite.Require().NoError(err)
+	result, err = stakingABI.Unpack(precompiles.DelegationMethodName, output)
+	suite.Require().NoError(err)
+	suite.Require().Equal(staking.IStakingModuleDelegation{
+		Validator: validator,
+		Shares:    delegation.Shares.BigInt(),
+		Balance:   big.NewInt(1000000),
+	}, *abi.ConvertType(result[0], new(staking.IStakingModuleDelegation)).(*staking.IStakingModuleDelegation))
+	output, _, err = suite.runPrecompile(contract, caller, pack(precompiles.ValidatorMethodName, validator), true)
+	suite.Require().NoError(err)
+	result, err = stakingABI.Unpack(precompiles.ValidatorMethodName, output)
+	suite.Require().NoError(err)
+	suite.Require().Equal(validator, abi.ConvertType(result[0], new(staking.IStakingModuleValidator)).(*staking.IStakingModuleValidator).OperatorAddress)
+
+	// undelegate half of it
+	output, logs, err = suite.runPrecompile(contract, caller, pack(precompiles.UndelegateMethodName, validator, big.NewInt(500000)), false)
+	suite.Require().NoError(err)
+	result, err = stakingABI.Unpack(precompiles.UndelegateMethodName, output)
+	suite.Require().NoError(err)
+	completionTime := time.Unix(result[0].(int64), 0).UTC()
+	suite.Require().True(completionTime.After(suite.ctx.BlockTime()))
+	suite.requireLog(logs, contract.Address(), stakingABI.Events["Unbond"], caller, validator, bondCoins(500000), completionTime.Format(time.RFC3339))
+	ubd, err := suite.app.StakingKeeper.GetUnbondingDelegation(suite.ctx, delegator, valAddr)
+	suite.Require().NoError(err)
+	suite.Require().Len(ubd.Entries, 1)
+
+	// claim the rewards allocated to the validator
+	rewards := sdk.NewCoins(sdk.NewInt64Coin(bondDenom, 150))
+	suite.Require().NoError(suite.app.BankKeeper.MintCoins(suite.ctx, minttypes.ModuleName, rewards))
+	suite.Require().NoError(suite.app.BankKeeper.SendCoinsFromModuleToModule(suite.ctx, minttypes.ModuleName, distrtypes.ModuleName, rewards))
+	val, err := suite.app.StakingKeeper.GetValidator(suite.ctx, valAddr)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.app.DistrKeeper.AllocateTokensToValidator(suite.ctx, val, sdk.NewDecCoinsFromCoins(rewards...)))
+	output, logs, err = suite.runPrecompile(contract, caller, pack(precompiles.ClaimRewardsMethodName, validator), false)
+	suite.Require().NoError(err)
+	result, err = stakingABI.Unpack(precompiles.ClaimRewardsMethodName, output)
+	suite.Require().NoError(err)
+	claimed := *abi.ConvertType(result[0], new([]staking.CosmosCoin)).(*[]staking.CosmosCoin)
+	suite.Require().Len(claimed, 1)
+	suite.Require().Equal(bondDenom, claimed[0].Denom)
+	suite.Require().Positive(claimed[0].Amount.Sign())
+	suite.Require().Equal(claimed[0].Amount, suite.GetBalance(delegator, bondDenom).Amount.BigInt())
+	suite.requireLog(logs, contract.Address(), stakingABI.Events["WithdrawRewards"], caller, validator, claimed)
+}
+
+func (suite *KeeperTestSuite) TestGovPrecompile() {
+	suite.SetupTest()
+	contract := precompiles.NewGovContract(
+		&suite.app.GovKeeper, &suite.app.CronosKeeper, suite.app.AppCodec(), storetypes.KVGasConfig(),
+	)
+	govABI, err := gov.GovModuleMetaData.GetAbi()
+	suite.Require().NoError(err)
+	pack := func(method string, args ...any) []byte {
+		input, err := govABI.Pack(method, args...)
+		suite.Require().NoError(err)
+		return input
+	}
+
+	privKey, err := ethsecp256k1.GenerateKey()
+	suite.Require().NoError(err)
+	caller := common.BytesToAddress(privKey.PubKey().Address())
+	voter := sdk.AccAddress(caller.Bytes())
+	origin := sdk.AccAddress(suite.address.Bytes())
+
+	// the voting power of the caller
+	bondDenom, err := suite.app.StakingKeeper.BondDenom(suite.ctx)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.MintCoins(voter, sdk.NewCoins(sdk.NewInt64Coin(bondDenom, 1000000))))
+	validators, err := suite.app.StakingKeeper.GetBondedValidatorsByPower(suite.ctx)
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(validators)
+	_, err = stakingkeeper.NewMsgServerImpl(suite.app.StakingKeeper).Delegate(suite.ctx, stakingtypes.NewMsgDelegate(
+		voter.String(), validators[0].OperatorAddress, sdk.NewInt64Coin(bondDenom, 1000000),
+	))
+	suite.Require().NoError(err)
+
+	params, err := suite.app.GovKeeper.Params.Get(suite.ctx)
+	suite.Require().NoError(err)
+	minDeposit := sdk.NewCoins(params.MinDeposit...)
+	suite.Require().NoError(suite.MintCoins(voter, minDeposit))
+
+	// the contract is disabled by default, the calls fail
+	_, _, err = suite.runPrecompile(contract, caller, pack(precompiles.ProposalMethodName, uint64(1)), true)
+	suite.Require().ErrorContains(err, "gov precompile is disabled")
+	cronosParams := suite.app.CronosKeeper.GetParams(suite.ctx)
+	cronosParams.EnableGovPrecompile = true
+	suite.Require().NoError(suite.app.CronosKeeper.SetParams(suite.ctx, cronosParams))
+
+	// the proposer must be the caller
+	submitInput := func(proposer sdk.AccAddress) []byte {
+		msg, err := govv1.NewMsgSubmitProposal(nil, nil, proposer.String(), "metadata", "title", "summary", false)
+		suite.Require().NoError(err)
+		data, err := suite.app.AppCodec().Marshal(msg)
+		suite.Require().NoError(err)
+		return pack(precompiles.SubmitProposalMethodName, data)
+	}
+	_, _, err = suite.runPrecompile(contract, caller, submitInput(origin), false)
+	suite.Require().ErrorContains(err, "caller is not authenticated")
+	_, _, err = suite.runPrecompile(contract, caller, submitInput(voter), true)
+	suite.Require().ErrorContains(err, "the method is not readonly")
+	_, _, err = suite.runPrecompile(contract, caller, pack(precompiles.SubmitProposalMethodName, []byte{1, 2, 3}), false)
+	suite.Require().Error(err)
+
+	output, logs, err := suite.runPrecompile(contract, caller, submitInput(voter), false)
+	suite.Require().NoError(err)
+	result, err := govABI.Unpack(precompiles.SubmitProposalMethodName, output)
+	suite.Require().NoError(err)
+	proposalID := result[0].(uint64)
+	suite.requireLog(logs, contract.Address(), govABI.Events["SubmitProposal"], proposalID, caller, "")
+
+	// deposit the coins of the caller to start the voting period
+	amount := make([]gov.CosmosCoin, len(minDeposit))
+	for i, coin := range minDeposit {
+		amount[i] = gov.CosmosCoin{Amount: coin.Amount.BigInt(), Denom: coin.Denom}
+	}
+	depositInput := pack(precompiles.DepositMethodName, proposalID, amount)
+	_, _, err = suite.runPrecompile(contract, caller, depositInput[:4+32], false)
+	suite.Require().ErrorContains(err, "fail to unpack input arguments")
+	_, _, err = suite.runPrecompile(contract, caller, depositInput, true)
+	suite.Require().ErrorContains(err, "the method is not readonly")
+	_, logs, err = suite.runPrecompile(contract, caller, depositInput, false)
+	suite.Require().NoError(err)
+	suite.requireLog(logs, contract.Address(), govABI.Events["ProposalDeposit"], proposalID, caller, amount)
+	deposit, err := suite.app.GovKeeper.Deposits.Get(suite.ctx, collections.Join(proposalID, voter))
+	suite.Require().NoError(err)
+	suite.Require().Equal(minDeposit, sdk.NewCoins(deposit.Amount...))
+	proposal, err := suite.app.GovKeeper.Proposals.Get(suite.ctx, proposalID)
+	suite.Require().NoError(err)
+	suite.Require().Equal(govv1.StatusVotingPeriod, proposal.Status)
+
+	// vote as the caller, not the tx origin
+	voteInput := pack(precompiles.VoteMethodName, proposalID, int32(govv1.OptionYes), "")
+	_, _, err = suite.runPrecompile(contract, caller, voteInput, true)
+	suite.Require().ErrorContains(err, "the method is not readonly")
+	_, logs, err = suite.runPrecompile(contract, caller, voteInput, false)
+	suite.Require().NoError(err)
+	suite.requireLog(logs, contract.Address(), govABI.Events["ProposalVote"], proposalID, caller, govv1.NewNonSplitVoteOption(govv1.OptionYes).String())
+	vote, err := suite.app.GovKeeper.Votes.Get(suite.ctx, collections.Join(proposalID, voter))
+	suite.Require().NoError(err)
+	suite.Require().Equal(govv1.NewNonSplitVoteOption(govv1.OptionYes), govv1.WeightedVoteOptions(vote.Options))
+	has, err := suite.app.GovKeeper.Votes.Has(suite.ctx, collections.Join(proposalID, origin))
+	suite.Require().NoError(err)
+	suite.Require().False(has)
+
+	// the queries are allowed in the readonly calls
+	output, _, err = suite.runPrecompile(contract, caller, pack(precompiles.ProposalMethodName, proposalID), true)
+	suite.Require().NoError(err)
+	result, err = govABI.Unpack(precompiles.ProposalMethodName, output)
+	suite.Require().NoError(err)
+	queried := *abi.ConvertType(result[0], new(gov.IGovModuleProposal)).(*gov.IGovModuleProposal)
+	suite.Require().Equal(proposalID, queried.Id)
+	suite.Require().Equal(int32(govv1.StatusVotingPeriod), queried.Status)
+	suite.Require().Equal(caller, queried.Proposer)
+	suite.Require().Equal("title", queried.Title)
+
+	// the tally doesn't remove the votes, the result is the same when queried again
+	tallyInput := pack(precompiles.TallyMethodName, proposalID)
+	output, _, err = suite.runPrecompile(contract, caller, tallyInput, true)
+	suite.Require().NoError(err)
+	result, err = govABI.Unpack(precompiles.TallyMethodName, output)
+	suite.Require().NoError(err)
+	tally := *abi.ConvertType(result[0], new(gov.IGovModuleTallyResult)).(*gov.IGovModuleTallyResult)
+	suite.Require().Equal(big.NewInt(1000000), tally.Yes)
+	suite.Require().Zero(tally.No.Sign())
+	has, err = suite.app.GovKeeper.Votes.Has(suite.ctx, collections.Join(proposalID, voter))
+	suite.Require().NoError(err)
+	suite.Require().True(has)
+	again, _, err := suite.runPrecompile(contract, caller, tallyInput, true)
+	suite.Require().NoError(err)
+	suite.Require().Equal(output, again)
+}
+
+func (suite *KeeperTestSuite) TestGovPrecompileTally() {
+	suite.SetupTest()
+	contract := precompiles.NewGovContract(
+		&suite.app.GovKeeper, &suite.app.CronosKeeper, suite.app.AppCodec(), storetypes.KVGasConfig(),
+	)
+	govABI, err := gov.GovModuleMetaData.GetAbi()
+	suite.Require().NoError(err)
+	cronosParams := suite.app.CronosKeeper.GetParams(suite.ctx)
+	cronosParams.EnableGovPrecompile = true
+	suite.Require().NoError(suite.app.CronosKeeper.SetParams(suite.ctx, cronosParams))
+
+	bondDenom, err := suite.app.StakingKeeper.BondDenom(suite.ctx)
+	suite.Require().NoError(err)
+	validators, err := suite.app.StakingKeeper.GetBondedValidatorsByPower(suite.ctx)
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(validators)
+	stakingMsgServer := stakingkeeper.NewMsgServerImpl(suite.app.StakingKeeper)
+	govMsgServer := govkeeper.NewMsgServerImpl(&suite.app.GovKeeper)
+
+	// start the voting period with the initial deposit of the proposer
+	params, err := suite.app.GovKeeper.Params.Get(suite.ctx)
+	suite.Require().NoError(err)
+	proposer := sdk.AccAddress(suite.address.Bytes())
+	minDeposit := sdk.NewCoins(params.MinDeposit...)
+	suite.Require().NoError(suite.MintCoins(proposer, minDeposit))
+	msg, err := govv1.NewMsgSubmitProposal(nil, minDeposit, proposer.String(), "metadata", "title", "summary", false)
+	suite.Require().NoError(err)
+	rsp, err := govMsgServer.SubmitProposal(suite.ctx, msg)
+	suite.Require().NoError(err)
+	proposalID := rsp.ProposalId
+
+	vote := func(n int) {
+		for i := 0; i < n; i++ {
+			privKey, err := ethsecp256k1.GenerateKey()
+			suite.Require().NoError(err)
+			voter := sdk.AccAddress(privKey.PubKey().Address())
+			suite.Require().NoError(suite.MintCoins(voter, sdk.NewCoins(sdk.NewInt64Coin(bondDenom, 1000))))
+			_, err = stakingMsgServer.Delegate(suite.ctx, stakingtypes.NewMsgDelegate(
+				voter.String(), validators[0].OperatorAddress, sdk.NewInt64Coin(bondDenom, 1000),
+			))
+			suite.Require().NoError(err)
+			_, err = govMsgServer.Vote(suite.ctx, govv1.NewMsgVote(voter, proposalID, govv1.OptionYes, ""))
+			suite.Require().NoError(err)
+		}
+	}
+	tallyInput, err := govABI.Pack(precompiles.TallyMethodName, proposalID)
+	suite.Require().NoError(err)
+	tally := func(gas uint64) (*big.Int, uint64, error) {
+		output, _, left, err := suite.runPrecompileWithGas(contract, suite.address, tallyInput, true, gas)
+		if err != nil {
+			return nil, gas - left, err
+		}
+		result, err := govABI.Unpack(precompiles.TallyMethodName, output)
+		suite.Require().NoError(err)
+		return (*abi.ConvertType(result[0], new(gov.IGovModuleTallyResult)).(*gov.IGovModuleTallyResult)).Yes, gas - left, nil
+	}
+
+	// the gas used grows with the votes and the delegations visited
+	vote(10)
+	yes, gasUsed10, err := tally(precompileGasLimit)
+	suite.Require().NoError(err)
+	suite.Require().Equal(big.NewInt(10*1000), yes)
+	vote(90)
+	yes, gasUsed100, err := tally(precompileGasLimit)
+	suite.Require().NoError(err)
+	suite.Require().Equal(big.NewInt(100*1000), yes)
+	requiredGas := contract.RequiredGas(tallyInput)
+	suite.Require().Greater(gasUsed100-requiredGas, 5*(gasUsed10-requiredGas))
+
+	// the call fails when the gas can't cover all the votes, all the gas supplied is consumed
+	_, gasUsed, err := tally(gasUsed100 - 1)
+	suite.Require().ErrorIs(err, vm.ErrOutOfGas)
+	suite.Require().Equal(gasUsed100-1, gasUsed)
+
+	// the finished proposal returns the stored final tally without visiting the votes
+	proposal, err := suite.app.GovKeeper.Proposals.Get(suite.ctx, proposalID)
+	suite.Require().NoError(err)
+	finalTally := govv1.NewTallyResult(sdkmath.NewInt(100*1000), sdkmath.ZeroInt(), sdkmath.ZeroInt(), sdkmath.ZeroInt())
+	proposal.Status = govv1.StatusPassed
+	proposal.FinalTallyResult = &finalTally
+	suite.Require().NoError(suite.app.GovKeeper.Proposals.Set(suite.ctx, proposalID, proposal))
+	yes, gasUsed, err = tally(precompileGasLimit)
+	suite.Require().NoError(err)
+	suite.Require().Equal(big.NewInt(100*1000), yes)
+	suite.Require().Less(gasUsed-requiredGas, gasUsed10-requiredGas)
+}
@@ -0,0 +1,148 @@
+package keeper
+
+import (
+	errorsmod "cosmossdk.io/errors"
+	sdkmath "cosmossdk.io/math"
+	"cosmossdk.io/store/prefix"
+	storetypes "cosmossdk.io/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/crypto-org-chain/cronos/v2/x/cronos/types"
+)
+
+// GetRateLimit returns the rate limit of the denom and channel, the empty channel id is the per-denom limit.
+func (k Keeper) GetRateLimit(ctx sdk.Context, denom, channelID string) (types.RateLimit, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.RateLimitKey(denom, channelID))
+	if bz == nil {
+		return types.RateLimit{}, false
+	}
+	var limit types.RateLimit
+	k.cdc.MustUnmarshal(bz, &limit)
+	return limit, true
+}
+
+// SetRateLimit sets the rate limit, an empty one removes the rate limit together with its usage.
+func (k Keeper) SetRateLimit(ctx sdk.Context, limit types.RateLimit) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.RateLimitKey(limit.Denom, limit.ChannelId)
+	if limit.IsEmpty() {
+		store.Delete(key)
+		store.Delete(types.RateLimitUsageKey(limit.Denom, limit.ChannelId))
+		return
+	}
+	store.Set(key, k.cdc.MustMarshal(&limit))
+}
+
+// IterateRateLimits iterates the rate limits of the denom, or all the rate limits if the denom is empty.
+func (k Keeper) IterateRateLimits(ctx sdk.Context, denom string, cb func(limit types.RateLimit) (stop bool)) {
+	keyPrefix := types.KeyPrefixRateLimit
+	if denom != "" {
+		keyPrefix = types.RateLimitKey(denom, "")
+	}
+	iterator := storetypes.KVStorePrefixIterator(prefix.NewStore(ctx.KVStore(k.storeKey), keyPrefix), nil)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var limit types.RateLimit
+		k.cdc.MustUnmarshal(iterator.Value(), &limit)
+		if cb(limit) {
+			break
+		}
+	}
+}
+
+// GetRateLimitUsage returns the usage of the rate limit at the current block time, the recorded amounts decay
+// linearly to zero in a window.
+func (k Keeper) GetRateLimitUsage(ctx sdk.Context, limit types.RateLimit) types.RateLimitUsage {
+	bz := ctx.KVStore(k.storeKey).Get(types.RateLimitUsageKey(limit.Denom, limit.ChannelId))
+	if bz == nil {
+		return types.NewRateLimitUsage(ctx.BlockTime())
+	}
+	var usage types.RateLimitUsage
+	k.cdc.MustUnmarshal(bz, &usage)
+	return usage.DecayTo(ctx.BlockTime(), limit.Window)
+}
+
+func (k Keeper) setRateLimitUsage(ctx sdk.Context, limit types.RateLimit, usage types.RateLimitUsage) {
+	ctx.KVStore(k.storeKey).Set(types.RateLimitUsageKey(limit.Denom, limit.ChannelId), k.cdc.MustMarshal(&usage))
+}
+
+// rateLimitsOf returns the per-denom limit and the per-channel limit which apply to the flow.
+func (k Keeper) rateLimitsOf(ctx sdk.Context, denom, channelID string) []types.RateLimit {
+	var limits []types.RateLimit
+	if limit, found := k.GetRateLimit(ctx, denom, ""); found {
+		limits = append(limits, limit)
+	}
+	if channelID != "" {
+		if limit, found := k.GetRateLimit(ctx, denom, channelID); found {
+			limits = append(limits, limit)
+		}
+	}
+	return limits
+}
+
+// CheckRateLimits returns error if the flow of the amount exceeds any of the rate limits of the denom and channel,
+// without consuming the quota.
+func (k Keeper) CheckRateLimits(ctx sdk.Context, denom, channelID string, amount sdkmath.Int, inflow bool) error {
+	for _, limit := range k.rateLimitsOf(ctx, denom, channelID) {
+		if _, err := k.addRateLimitUsage(ctx, limit, amount, inflow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// consumeRateLimits records the flow of the amount in the rate limits of the denom and channel, returns error
+// if any of them is exceeded.
+func (k Keeper) consumeRateLimits(ctx sdk.Context, denom, channelID string, amount sdkmath.Int, inflow bool) error {
+	for _, limit := range k.rateLimitsOf(ctx, denom, channelID) {
+		usage, err := k.addRateLimitUsage(ctx, limit, amount, inflow)
+		if err != nil {
+			return err
+		}
+		k.setRateLimitUsage(ctx, limit, usage)
+	}
+	return nil
+}
+
+// addRateLimitUsage returns the usage with the amount added, emits the event and returns error if the quota is
+// exceeded.
+func (k Keeper) addRateLimitUsage(ctx sdk.Context, limit types.RateLimit, amount sdkmath.Int, inflow bool) (types.RateLimitUsage, error) {
+	usage := k.GetRateLimitUsage(ctx, limit)
+	flow, quota, used := types.AttributeValueOutflow, limit.MaxOutflow, &usage.Outflow
+	if inflow {
+		flow, quota, used = types.AttributeValueInflow, limit.MaxInflow, &usage.Inflow
+	}
+	total := used.Add(amount)
+	if quota.IsPositive() && total.GT(quota) {
+		ctx.EventManager().EmitEvent(types.NewRateLimitExceededEvent(limit, flow, amount, quota, *used))
+		return usage, errorsmod.Wrapf(
+			types.ErrRateLimitExceeded, "%s of %s on channel %q, quota %s, used %s, amount %s",
+			flow, limit.Denom, limit.ChannelId, quota, used, amount,
+		)
+	}
+	*used = total
+	return usage, nil
+}
+
+// releaseRateLimits gives back the outflow quota of the refunded amount, the usage is never decreased below zero,
+// for the flows already decayed.
+func (k Keeper) releaseRateLimits(ctx sdk.Context, denom, channelID string, amount sdkmath.Int) {
+	for _, limit := range k.rateLimitsOf(ctx, denom, channelID) {
+		usage := k.GetRateLimitUsage(ctx, limit)
+		usage.Outflow = sdkmath.MaxInt(usage.Outflow.Sub(amount), sdkmath.ZeroInt())
+		k.setRateLimitUsage(ctx, limit, usage)
+	}
+}
+
+// rateLimitChannel returns the channel id of the flow of the ibc voucher, which is the first hop of the denom
+// trace, returns empty for the cronos source tokens or the unknown vouchers, so only the per-denom limit applies.
+func (k Keeper) rateLimitChannel(ctx sdk.Context, denom string) string {
+	if types.IsSourceCoin(denom) {
+		return ""
+	}
+	channelID, err := k.GetSourceChannelID(ctx, denom)
+	if err != nil {
+		return ""
+	}
+	return channelID
+}
@@ -0,0 +1,121 @@
+package keeper_test
+
+import (
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	cronosmodulekeeper "github.com/crypto-org-chain/cronos/v2/x/cronos/keeper"
+	keepertest "github.com/crypto-org-chain/cronos/v2/x/cronos/keeper/mock"
+	"github.com/crypto-org-chain/cronos/v2/x/cronos/types"
+	"github.com/evmos/ethermint/crypto/ethsecp256k1"
+)
+
+func (suite *KeeperTestSuite) queryRateLimitUsages(ctx sdk.Context, denom string) map[string]types.RateLimitUsage {
+	rsp, err := suite.app.CronosKeeper.RateLimits(ctx, &types.QueryRateLimitsRequest{Denom: denom})
+	suite.Require().NoError(err)
+	usages := make(map[string]types.RateLimitUsage)
+	for _, status := range rsp.RateLimits {
+		usages[status.RateLimit.ChannelId] = status.Usage
+	}
+	return usages
+}
+
+func (suite *KeeperTestSuite) TestRateLimits() {
+	suite.SetupTest()
+	privKey, err := ethsecp256k1.GenerateKey()
+	suite.Require().NoError(err)
+	address := sdk.AccAddress(privKey.PubKey().Address())
+
+	// Create Cronos Keeper with mock transfer keeper
+	suite.app.CronosKeeper = *cronosmodulekeeper.NewKeeper(
+		suite.app.EncodingConfig().Codec,
+		suite.app.GetKey(types.StoreKey),
+		suite.app.GetKey(types.MemStoreKey),
+		suite.app.BankKeeper,
+		keepertest.IbcKeeperMock{},
+		suite.app.EvmKeeper,
+		suite.app.AccountKeeper,
+		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
+	)
+	keeper := suite.app.CronosKeeper
+	denom := types.IbcCroDenomDefaultValue
+	perChannel := types.NewRateLimit(denom, "channel-0", sdkmath.NewInt(100), sdkmath.NewInt(50), time.Hour)
+	perDenom := types.NewRateLimit(denom, "", sdkmath.NewInt(150), sdkmath.ZeroInt(), time.Hour)
+	keeper.SetRateLimit(suite.ctx, perChannel)
+	keeper.SetRateLimit(suite.ctx, perDenom)
+	// a limit of other denom
+	keeper.SetRateLimit(suite.ctx, types.NewRateLimit(CorrectIbcDenom, "", sdkmath.NewInt(1), sdkmath.NewInt(1), time.Hour))
+
+	suite.Require().NoError(suite.MintCoins(address, sdk.NewCoins(sdk.NewCoin(denom, sdkmath.NewInt(300)))))
+	evmDenom := suite.evmParam.EvmDenom
+	tenPowTen := sdkmath.NewIntFromBigInt(types.TenPowTen)
+
+	// inflow counted in both the per-denom and the per-channel limits
+	suite.Require().NoError(keeper.ConvertVouchersToEvmCoins(suite.ctx, address.String(), sdk.NewCoins(sdk.NewCoin(denom, sdkmath.NewInt(60)))))
+	usages := suite.queryRateLimitUsages(suite.ctx, denom)
+	suite.Require().Len(usages, 2)
+	suite.Require().Equal(sdkmath.NewInt(60), usages["channel-0"].Inflow)
+	suite.Require().Equal(sdkmath.NewInt(60), usages[""].Inflow)
+	suite.Require().Len(suite.queryRateLimitUsages(suite.ctx, ""), 3)
+
+	// check doesn't consume the quota
+	suite.Require().NoError(keeper.CheckRateLimits(suite.ctx, denom, "channel-0", sdkmath.NewInt(40), true))
+	suite.Require().Equal(sdkmath.NewInt(60), suite.queryRateLimitUsages(suite.ctx, denom)["channel-0"].Inflow)
+
+	// the per-channel limit trips, the state is reverted like a failed tx
+	ctx, _ := suite.ctx.CacheContext()
+	err = keeper.ConvertVouchersToEvmCoins(ctx, address.String(), sdk.NewCoins(sdk.NewCoin(denom, sdkmath.NewInt(50))))
+	suite.Require().ErrorIs(err, types.ErrRateLimitExceeded)
+	suite.Require().Contains(ctx.EventManager().Events(), types.NewRateLimitExceededEvent(
+		perChannel, types.AttributeValueInflow, sdkmath.NewInt(50), sdkmath.NewInt(100), sdkmath.NewInt(60),
+	))
+
+	// outflow of the evm denom is accounted as the ibc cro denom
+	suite.Require().NoError(keeper.IbcTransferCoins(suite.ctx, address.String(), "to", sdk.NewCoins(sdk.NewCoin(evmDenom, sdkmath.NewInt(40).Mul(tenPowTen))), ""))
+	suite.Require().Equal(sdkmath.NewInt(40), suite.queryRateLimitUsages(suite.ctx, denom)["channel-0"].Outflow)
+	ctx, _ = suite.ctx.CacheContext()
+	err = keeper.IbcTransferCoins(ctx, address.String(), "to", sdk.NewCoins(sdk.NewCoin(evmDenom, sdkmath.NewInt(20).Mul(tenPowTen))), "")
+	suite.Require().ErrorIs(err, types.ErrRateLimitExceeded)
+
+	// refund gives back the outflow quota, and is not counted as inflow
+	keeper.OnRefundVouchers(suite.ctx, sdk.NewCoins(sdk.NewCoin(denom, sdkmath.NewInt(30))), address.String(), "channel-0")
+	usages = suite.queryRateLimitUsages(suite.ctx, denom)
+	suite.Require().Equal(sdkmath.NewInt(10), usages["channel-0"].Outflow)
+	suite.Require().Equal(sdkmath.NewInt(60), usages["channel-0"].Inflow)
+	suite.Require().Equal(sdkmath.NewInt(50).Mul(tenPowTen), suite.GetBalance(address, evmDenom).Amount)
+
+	// the usage decays linearly in the window, the quota is recovered gradually
+	ctx = suite.ctx.WithBlockTime(suite.ctx.BlockTime().Add(30 * time.Minute))
+	usages = suite.queryRateLimitUsages(ctx, denom)
+	suite.Require().Equal(sdkmath.NewInt(30), usages["channel-0"].Inflow)
+	suite.Require().Equal(sdkmath.NewInt(5), usages["channel-0"].Outflow)
+	suite.Require().NoError(keeper.ConvertVouchersToEvmCoins(ctx, address.String(), sdk.NewCoins(sdk.NewCoin(denom, sdkmath.NewInt(70)))))
+	usages = suite.queryRateLimitUsages(ctx, denom)
+	suite.Require().Equal(sdkmath.NewInt(100), usages["channel-0"].Inflow)
+	suite.Require().Equal(sdkmath.NewInt(100), usages[""].Inflow)
+	suite.Require().True(ctx.BlockTime().Equal(usages["channel-0"].UpdatedAt))
+
+	// the quota is not reset at once when the window of the first flow is passed
+	ctx = suite.ctx.WithBlockTime(suite.ctx.BlockTime().Add(time.Hour))
+	suite.Require().Equal(sdkmath.NewInt(50), suite.queryRateLimitUsages(ctx, denom)["channel-0"].Inflow)
+	cacheCtx, _ := ctx.CacheContext()
+	err = keeper.ConvertVouchersToEvmCoins(cacheCtx, address.String(), sdk.NewCoins(sdk.NewCoin(denom, sdkmath.NewInt(51))))
+	suite.Require().ErrorIs(err, types.ErrRateLimitExceeded)
+
+	// the usage is cleared after a window without flows
+	ctx = suite.ctx.WithBlockTime(suite.ctx.BlockTime().Add(90 * time.Minute))
+	usages = suite.queryRateLimitUsages(ctx, denom)
+	suite.Require().True(usages["channel-0"].Inflow.IsZero())
+	suite.Require().True(usages["channel-0"].Outflow.IsZero())
+
+	// the empty rate limit removes it
+	perChannel.MaxInflow = sdkmath.ZeroInt()
+	perChannel.MaxOutflow = sdkmath.ZeroInt()
+	keeper.SetRateLimit(ctx, perChannel)
+	_, found := keeper.GetRateLimit(ctx, denom, "channel-0")
+	suite.Require().False(found)
+	suite.Require().Len(suite.queryRateLimitUsages(ctx, denom), 1)
+}
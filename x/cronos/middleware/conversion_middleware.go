@@ -101,6 +101,17 @@ func (im IBCConversionModule) OnRecvPacket(
 	packet channeltypes.Packet,
 	relayer sdk.AccAddress,
 ) exported.Acknowledgement {
+	// reject the packet before it's received if the conversion exceeds the inflow rate limits,
+	// so the tokens are refunded on the source chain.
+	if data, err := im.getFungibleTokenPacketData(packet); err == nil {
+		denom := im.getIbcDenomFromPacketAndData(packet, data)
+		if amount, ok := sdkmath.NewIntFromString(data.Amount); ok && im.canBeConverted(ctx, denom) {
+			if err := im.cronoskeeper.CheckRateLimits(ctx, denom, packet.GetDestChannel(), amount, true); err != nil {
+				return channeltypes.NewErrorAcknowledgement(err)
+			}
+		}
+	}
+
 	ack := im.app.OnRecvPacket(ctx, packet, relayer)
 	if ack.Success() {
 		data, err := im.getFungibleTokenPacketData(packet)
@@ -111,7 +122,7 @@ func (im IBCConversionModule) OnRecvPacket(
 		denom := im.getIbcDenomFromPacketAndData(packet, data)
 		// Check if it can be converted
 		if im.canBeConverted(ctx, denom) {
-			err = im.convertVouchers(ctx, data, denom, false)
+			err = im.convertVouchers(ctx, data, denom, packet.GetDestChannel(), false)
 			if err != nil {
 				return channeltypes.NewErrorAcknowledgement(err)
 			}
@@ -143,7 +154,7 @@ func (im IBCConversionModule) OnAcknowledgementPacket(
 			}
 			denom := im.getIbcDenomFromDataForRefund(data)
 			if im.canBeConverted(ctx, denom) {
-				return im.convertVouchers(ctx, data, denom, true)
+				return im.convertVouchers(ctx, data, denom, packet.GetSourceChannel(), true)
 			}
 		}
 	}
@@ -166,7 +177,7 @@ func (im IBCConversionModule) OnTimeoutPacket(
 		}
 		denom := im.getIbcDenomFromDataForRefund(data)
 		if im.canBeConverted(ctx, denom) {
-			return im.convertVouchers(ctx, data, denom, true)
+			return im.convertVouchers(ctx, data, denom, packet.GetSourceChannel(), true)
 		}
 	}
 	return err
@@ -181,7 +192,7 @@ func (im IBCConversionModule) getFungibleTokenPacketData(packet channeltypes.Pac
 	return data, nil
 }
 
-func (im IBCConversionModule) convertVouchers(ctx sdk.Context, data transferTypes.FungibleTokenPacketData, denom string, isSender bool) error {
+func (im IBCConversionModule) convertVouchers(ctx sdk.Context, data transferTypes.FungibleTokenPacketData, denom, channelID string, isSender bool) error {
 	// parse the transfer amount
 	transferAmount, ok := sdkmath.NewIntFromString(data.Amount)
 	if !ok {
@@ -190,9 +201,9 @@ func (im IBCConversionModule) convertVouchers(ctx sdk.Context, data transferType
 	}
 	token := sdk.NewCoin(denom, transferAmount)
 	if isSender {
-		im.cronoskeeper.OnRecvVouchers(ctx, sdk.NewCoins(token), data.Sender)
+		im.cronoskeeper.OnRefundVouchers(ctx, sdk.NewCoins(token), data.Sender, channelID)
 	} else {
-		im.cronoskeeper.OnRecvVouchers(ctx, sdk.NewCoins(token), data.Receiver)
+		im.cronoskeeper.OnRecvVouchers(ctx, sdk.NewCoins(token), data.Receiver, channelID)
 	}
 	return nil
 }
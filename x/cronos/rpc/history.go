@@ -0,0 +1,155 @@
+package rpc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types/address"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	rpctypes "github.com/evmos/ethermint/rpc/types"
+	evmtypes "github.com/evmos/ethermint/x/evm/types"
+
+	"github.com/crypto-org-chain/cronos/versiondb"
+)
+
+// MaxHistoryEntries is the max number of changes returned by the history apis in a single call, the callers should
+// narrow the block range if it's exceeded.
+const MaxHistoryEntries = 10000
+
+// StorageChange is a change of an evm storage slot, the value is zero if the slot is cleared.
+type StorageChange struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	Value       common.Hash    `json:"value"`
+}
+
+// BalanceChange is a change of the evm denom balance of an account.
+type BalanceChange struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	Balance     *hexutil.Big   `json:"balance"`
+}
+
+// GetStorageHistory returns the block numbers at which the storage slot of the contract changed in the block range,
+// together with the new values, it requires versiondb to be enabled on the node.
+func (api *CronosAPI) GetStorageHistory(
+	address common.Address,
+	slot common.Hash,
+	fromBlock, toBlock rpctypes.BlockNumber,
+) ([]StorageChange, error) {
+	api.logger.Debug("cronos_getStorageHistory", "address", address, "slot", slot, "from", fromBlock, "to", toBlock)
+	entries, err := api.keyHistory(evmtypes.StoreKey, evmtypes.StateKey(address, slot.Bytes()), fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]StorageChange, len(entries))
+	for i, entry := range entries {
+		// the cleared slots are deleted from the store
+		changes[i] = StorageChange{
+			BlockNumber: hexutil.Uint64(entry.Height),
+			Value:       common.BytesToHash(entry.Value),
+		}
+	}
+	return changes, nil
+}
+
+// GetBalanceHistory returns the block numbers at which the evm denom balance of the account changed in the block
+// range, together with the new balances, it requires versiondb to be enabled on the node.
+func (api *CronosAPI) GetBalanceHistory(
+	address common.Address,
+	fromBlock, toBlock rpctypes.BlockNumber,
+) ([]BalanceChange, error) {
+	api.logger.Debug("cronos_getBalanceHistory", "address", address, "from", fromBlock, "to", toBlock)
+	params, err := api.queryClient.Params(api.ctx, &evmtypes.QueryParamsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	entries, err := api.keyHistory(banktypes.StoreKey, balanceKey(address, params.Params.EvmDenom), fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]BalanceChange, len(entries))
+	for i, entry := range entries {
+		change := BalanceChange{
+			BlockNumber: hexutil.Uint64(entry.Height),
+			Balance:     (*hexutil.Big)(common.Big0),
+		}
+		// the zero balances are deleted from the store
+		if !entry.Deleted {
+			amount, err := banktypes.BalanceValueCodec.Decode(entry.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid balance at block %d: %w", entry.Height, err)
+			}
+			change.Balance = (*hexutil.Big)(amount.BigInt())
+		}
+		changes[i] = change
+	}
+	return changes, nil
+}
+
+// keyHistory queries the changes of the key in the block range through the versiondb grpc service, following the
+// pagination until the end of the range.
+func (api *CronosAPI) keyHistory(
+	store string,
+	key []byte,
+	fromBlock, toBlock rpctypes.BlockNumber,
+) ([]versiondb.KeyHistoryEntry, error) {
+	fromHeight, err := api.historyHeight(fromBlock)
+	if err != nil {
+		return nil, err
+	}
+	toHeight, err := api.historyHeight(toBlock)
+	if err != nil {
+		return nil, err
+	}
+	if fromHeight > toHeight {
+		return nil, fmt.Errorf("invalid block range: [%d, %d]", fromHeight, toHeight)
+	}
+
+	queryClient := versiondb.NewQueryClient(api.clientCtx)
+	req := &versiondb.QueryKeyHistoryRequest{
+		Store:      store,
+		Key:        key,
+		FromHeight: fromHeight,
+		ToHeight:   toHeight,
+		Limit:      versiondb.MaxKeyHistoryLimit,
+	}
+	var entries []versiondb.KeyHistoryEntry
+	for {
+		res, err := queryClient.KeyHistory(api.ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, res.Entries...)
+		if res.NextHeight == 0 {
+			return entries, nil
+		}
+		if len(entries) >= MaxHistoryEntries {
+			return nil, errors.New("too many changes in the block range, please narrow it")
+		}
+		req.FromHeight = res.NextHeight
+	}
+}
+
+// historyHeight resolves the block number tags to the block height.
+func (api *CronosAPI) historyHeight(blockNum rpctypes.BlockNumber) (int64, error) {
+	if blockNum >= 0 {
+		return blockNum.Int64(), nil
+	}
+	// latest or pending
+	height, err := api.backend.BlockNumber()
+	if err != nil {
+		return 0, err
+	}
+	return int64(height), nil
+}
+
+// balanceKey returns the key of the account balance in bank store, it's `prefix || len(addr) || addr || denom`.
+func balanceKey(addr common.Address, denom string) []byte {
+	key := bytes.Clone(banktypes.BalancesPrefix.Bytes())
+	key = append(key, address.MustLengthPrefix(addr.Bytes())...)
+	return append(key, denom...)
+}
@@ -13,11 +13,14 @@ import (
 )
 
 const (
-	ibcCroDenomKey          = "ibc_cro_denom"
-	ibcTimeoutKey           = "ibc_timeout"
-	cronosAdminKey          = "cronos_admin"
-	enableAutoDeploymentKey = "enable_auto_deployment"
-	maxCallbackGasKey       = "max_callback_gas"
+	ibcCroDenomKey             = "ibc_cro_denom"
+	ibcTimeoutKey              = "ibc_timeout"
+	cronosAdminKey             = "cronos_admin"
+	enableAutoDeploymentKey    = "enable_auto_deployment"
+	maxCallbackGasKey          = "max_callback_gas"
+	enableBankPrecompileKey    = "enable_bank_precompile"
+	enableStakingPrecompileKey = "enable_staking_precompile"
+	enableGovPrecompileKey     = "enable_gov_precompile"
 )
 
 func GenIbcCroDenom(r *rand.Rand) string {
@@ -45,15 +48,30 @@ func GenMaxCallbackGas(r *rand.Rand) uint64 {
 	return maxCallbackGas
 }
 
+func GenEnableBankPrecompile(r *rand.Rand) bool {
+	return r.Intn(2) > 0
+}
+
+func GenEnableStakingPrecompile(r *rand.Rand) bool {
+	return r.Intn(2) > 0
+}
+
+func GenEnableGovPrecompile(r *rand.Rand) bool {
+	return r.Intn(2) > 0
+}
+
 // RandomizedGenState generates a random GenesisState for the cronos module
 func RandomizedGenState(simState *module.SimulationState) {
 	// cronos params
 	var (
-		ibcCroDenom          string
-		ibcTimeout           uint64
-		cronosAdmin          string
-		enableAutoDeployment bool
-		maxCallbackGas       uint64
+		ibcCroDenom             string
+		ibcTimeout              uint64
+		cronosAdmin             string
+		enableAutoDeployment    bool
+		maxCallbackGas          uint64
+		enableBankPrecompile    bool
+		enableStakingPrecompile bool
+		enableGovPrecompile     bool
 	)
 
 	simState.AppParams.GetOrGenerate(
@@ -81,7 +99,25 @@ func RandomizedGenState(simState *module.SimulationState) {
 		func(r *rand.Rand) { maxCallbackGas = GenIbcTimeout(r) },
 	)
 
-	params := types.NewParams(ibcCroDenom, ibcTimeout, cronosAdmin, enableAutoDeployment, maxCallbackGas)
+	simState.AppParams.GetOrGenerate(
+		enableBankPrecompileKey, &enableBankPrecompile, simState.Rand,
+		func(r *rand.Rand) { enableBankPrecompile = GenEnableBankPrecompile(r) },
+	)
+
+	simState.AppParams.GetOrGenerate(
+		enableStakingPrecompileKey, &enableStakingPrecompile, simState.Rand,
+		func(r *rand.Rand) { enableStakingPrecompile = GenEnableStakingPrecompile(r) },
+	)
+
+	simState.AppParams.GetOrGenerate(
+		enableGovPrecompileKey, &enableGovPrecompile, simState.Rand,
+		func(r *rand.Rand) { enableGovPrecompile = GenEnableGovPrecompile(r) },
+	)
+
+	params := types.NewParams(
+		ibcCroDenom, ibcTimeout, cronosAdmin, enableAutoDeployment, maxCallbackGas, enableBankPrecompile, enableStakingPrecompile,
+		enableGovPrecompile,
+	)
 	cronosGenesis := &types.GenesisState{
 		Params:            params,
 		ExternalContracts: nil,
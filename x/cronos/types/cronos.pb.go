@@ -4,18 +4,24 @@
 package types
 
 import (
+	cosmossdk_io_math "cosmossdk.io/math"
 	fmt "fmt"
 	_ "github.com/cosmos/gogoproto/gogoproto"
 	proto "github.com/cosmos/gogoproto/proto"
+	github_com_cosmos_gogoproto_types "github.com/cosmos/gogoproto/types"
+	_ "google.golang.org/protobuf/types/known/durationpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
 	io "io"
 	math "math"
 	math_bits "math/bits"
+	time "time"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
+var _ = time.Kitchen
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the proto package it is being compiled against.
@@ -31,6 +37,12 @@ type Params struct {
 	CronosAdmin          string `protobuf:"bytes,3,opt,name=cronos_admin,json=cronosAdmin,proto3" json:"cronos_admin,omitempty"`
 	EnableAutoDeployment bool   `protobuf:"varint,4,opt,name=enable_auto_deployment,json=enableAutoDeployment,proto3" json:"enable_auto_deployment,omitempty"`
 	MaxCallbackGas       uint64 `protobuf:"varint,5,opt,name=max_callback_gas,json=maxCallbackGas,proto3" json:"max_callback_gas,omitempty"`
+	// enable the bank precompiled contract to manage native tokens
+	EnableBankPrecompile bool `protobuf:"varint,6,opt,name=enable_bank_precompile,json=enableBankPrecompile,proto3" json:"enable_bank_precompile,omitempty"`
+	// enable the staking precompiled contract to stake and claim rewards
+	EnableStakingPrecompile bool `protobuf:"varint,7,opt,name=enable_staking_precompile,json=enableStakingPrecompile,proto3" json:"enable_staking_precompile,omitempty"`
+	// enable the gov precompiled contract to take part in the governance
+	EnableGovPrecompile bool `protobuf:"varint,8,opt,name=enable_gov_precompile,json=enableGovPrecompile,proto3" json:"enable_gov_precompile,omitempty"`
 }
 
 func (m *Params) Reset()      { *m = Params{} }
@@ -100,6 +112,27 @@ func (m *Params) GetMaxCallbackGas() uint64 {
 	return 0
 }
 
+func (m *Params) GetEnableBankPrecompile() bool {
+	if m != nil {
+		return m.EnableBankPrecompile
+	}
+	return false
+}
+
+func (m *Params) GetEnableStakingPrecompile() bool {
+	if m != nil {
+		return m.EnableStakingPrecompile
+	}
+	return false
+}
+
+func (m *Params) GetEnableGovPrecompile() bool {
+	if m != nil {
+		return m.EnableGovPrecompile
+	}
+	return false
+}
+
 // TokenMappingChangeProposal defines a proposal to change one token mapping.
 type TokenMappingChangeProposal struct {
 	Title       string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
@@ -196,45 +229,185 @@ func (m *TokenMapping) GetContract() string {
 	return ""
 }
 
+// RateLimit defines the quota of the bridge flows of a denom in a window, the
+// evm denom is accounted as the ibc cro denom.
+type RateLimit struct {
+	// the ibc voucher or cronos source token denom
+	Denom string `protobuf:"bytes,1,opt,name=denom,proto3" json:"denom,omitempty"`
+	// the limit applies to the flows of the channel, or all the channels if empty
+	ChannelId string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// the maximum amount converted from ibc vouchers to evm coins in a window,
+	// zero means unlimited
+	MaxInflow cosmossdk_io_math.Int `protobuf:"bytes,3,opt,name=max_inflow,json=maxInflow,proto3,customtype=cosmossdk.io/math.Int" json:"max_inflow"`
+	// the maximum amount transferred out through ibc in a window, zero means
+	// unlimited
+	MaxOutflow cosmossdk_io_math.Int `protobuf:"bytes,4,opt,name=max_outflow,json=maxOutflow,proto3,customtype=cosmossdk.io/math.Int" json:"max_outflow"`
+	// the flows in a window are limited, the usage decays linearly to zero in a
+	// window, so the quota is recovered gradually instead of reset at once
+	Window time.Duration `protobuf:"bytes,5,opt,name=window,proto3,stdduration" json:"window"`
+}
+
+func (m *RateLimit) Reset()         { *m = RateLimit{} }
+func (m *RateLimit) String() string { return proto.CompactTextString(m) }
+func (*RateLimit) ProtoMessage()    {}
+func (*RateLimit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8bc54992a93db2d2, []int{3}
+}
+func (m *RateLimit) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RateLimit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RateLimit.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RateLimit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RateLimit.Merge(m, src)
+}
+func (m *RateLimit) XXX_Size() int {
+	return m.Size()
+}
+func (m *RateLimit) XXX_DiscardUnknown() {
+	xxx_messageInfo_RateLimit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RateLimit proto.InternalMessageInfo
+
+func (m *RateLimit) GetDenom() string {
+	if m != nil {
+		return m.Denom
+	}
+	return ""
+}
+
+func (m *RateLimit) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *RateLimit) GetWindow() time.Duration {
+	if m != nil {
+		return m.Window
+	}
+	return 0
+}
+
+// RateLimitUsage defines the amounts consumed recently in a rate limit, the
+// amounts decay linearly to zero in a window.
+type RateLimitUsage struct {
+	Inflow  cosmossdk_io_math.Int `protobuf:"bytes,1,opt,name=inflow,proto3,customtype=cosmossdk.io/math.Int" json:"inflow"`
+	Outflow cosmossdk_io_math.Int `protobuf:"bytes,2,opt,name=outflow,proto3,customtype=cosmossdk.io/math.Int" json:"outflow"`
+	// the block time when the usage was last updated
+	UpdatedAt time.Time `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3,stdtime" json:"updated_at"`
+}
+
+func (m *RateLimitUsage) Reset()         { *m = RateLimitUsage{} }
+func (m *RateLimitUsage) String() string { return proto.CompactTextString(m) }
+func (*RateLimitUsage) ProtoMessage()    {}
+func (*RateLimitUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8bc54992a93db2d2, []int{4}
+}
+func (m *RateLimitUsage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RateLimitUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RateLimitUsage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RateLimitUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RateLimitUsage.Merge(m, src)
+}
+func (m *RateLimitUsage) XXX_Size() int {
+	return m.Size()
+}
+func (m *RateLimitUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_RateLimitUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RateLimitUsage proto.InternalMessageInfo
+
+func (m *RateLimitUsage) GetUpdatedAt() time.Time {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return time.Time{}
+}
+
 func init() {
 	proto.RegisterType((*Params)(nil), "cronos.Params")
 	proto.RegisterType((*TokenMappingChangeProposal)(nil), "cronos.TokenMappingChangeProposal")
 	proto.RegisterType((*TokenMapping)(nil), "cronos.TokenMapping")
+	proto.RegisterType((*RateLimit)(nil), "cronos.RateLimit")
+	proto.RegisterType((*RateLimitUsage)(nil), "cronos.RateLimitUsage")
 }
 
 func init() { proto.RegisterFile("cronos/cronos.proto", fileDescriptor_8bc54992a93db2d2) }
 
 var fileDescriptor_8bc54992a93db2d2 = []byte{
-	// 451 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xb1, 0x6f, 0xd3, 0x40,
-	0x14, 0xc6, 0x7d, 0x25, 0x35, 0xc9, 0xa5, 0x45, 0xe8, 0x88, 0x2a, 0x2b, 0x83, 0x63, 0x3c, 0x79,
-	0xa0, 0xb5, 0x04, 0x9d, 0x32, 0xd1, 0xa6, 0x82, 0x09, 0x54, 0x59, 0x9d, 0x58, 0xac, 0xf3, 0xe5,
-	0xe4, 0x9c, 0xea, 0xbb, 0x77, 0x3a, 0x5f, 0x50, 0xfc, 0x1f, 0x30, 0x32, 0x32, 0xf6, 0x6f, 0x61,
-	0x62, 0xec, 0xc8, 0x84, 0x50, 0xf2, 0x1f, 0x30, 0x32, 0x21, 0xfb, 0xd2, 0xd2, 0x0c, 0x9d, 0x7c,
-	0xdf, 0xef, 0x93, 0xf5, 0xbd, 0xef, 0xe9, 0xe1, 0x17, 0xcc, 0x80, 0x82, 0x3a, 0x75, 0x9f, 0x13,
-	0x6d, 0xc0, 0x02, 0xf1, 0x9d, 0x1a, 0x8f, 0x4a, 0x28, 0xa1, 0x43, 0x69, 0xfb, 0x72, 0x6e, 0xfc,
-	0x17, 0x61, 0xff, 0x92, 0x1a, 0x2a, 0x6b, 0xf2, 0x0e, 0x1f, 0x8a, 0x82, 0xe5, 0xcc, 0x40, 0x3e,
-	0xe7, 0x0a, 0x64, 0x80, 0x22, 0x94, 0x0c, 0xce, 0xe3, 0x3f, 0xbf, 0x26, 0x61, 0x43, 0x65, 0x35,
-	0x8d, 0x77, 0xec, 0x57, 0x20, 0x85, 0xe5, 0x52, 0xdb, 0x26, 0xce, 0x86, 0xa2, 0x60, 0x33, 0x03,
-	0x17, 0x2d, 0x27, 0x13, 0xdc, 0xca, 0xdc, 0x0a, 0xc9, 0x61, 0x69, 0x83, 0xbd, 0x08, 0x25, 0xbd,
-	0x0c, 0x8b, 0x82, 0x5d, 0x39, 0x42, 0x5e, 0xe2, 0x03, 0x37, 0x53, 0x4e, 0xe7, 0x52, 0xa8, 0xe0,
-	0x49, 0x9b, 0x93, 0x0d, 0x1d, 0x3b, 0x6b, 0x11, 0x39, 0xc5, 0x47, 0x5c, 0xd1, 0xa2, 0xe2, 0x39,
-	0x5d, 0xda, 0x36, 0x50, 0x57, 0xd0, 0x48, 0xae, 0x6c, 0xd0, 0x8b, 0x50, 0xd2, 0xcf, 0x46, 0xce,
-	0x3d, 0x5b, 0x5a, 0xb8, 0xb8, 0xf7, 0x48, 0x82, 0x9f, 0x4b, 0xba, 0xca, 0x19, 0xad, 0xaa, 0x82,
-	0xb2, 0xeb, 0xbc, 0xa4, 0x75, 0xb0, 0xdf, 0xc5, 0x3f, 0x93, 0x74, 0x35, 0xdb, 0xe2, 0xf7, 0xb4,
-	0x9e, 0xf6, 0xbe, 0xdd, 0x4c, 0xbc, 0xf8, 0x3b, 0xc2, 0xe3, 0x2b, 0xb8, 0xe6, 0xea, 0x03, 0xd5,
-	0x5a, 0xa8, 0x72, 0xb6, 0xa0, 0xaa, 0xe4, 0x97, 0x06, 0x34, 0xd4, 0xb4, 0x22, 0x23, 0xbc, 0x6f,
-	0x85, 0xad, 0xb8, 0x5b, 0x44, 0xe6, 0x04, 0x89, 0xf0, 0x70, 0xce, 0x6b, 0x66, 0x84, 0xb6, 0x02,
-	0x54, 0x57, 0x6f, 0x90, 0x3d, 0x44, 0xed, 0x7f, 0x6e, 0x81, 0xae, 0x98, 0x13, 0x64, 0x8c, 0xfb,
-	0x0c, 0x94, 0x35, 0x94, 0xb9, 0x12, 0x83, 0xec, 0x5e, 0x93, 0x23, 0xec, 0xd7, 0x8d, 0x2c, 0xa0,
-	0xea, 0xc6, 0x1d, 0x64, 0x5b, 0x45, 0x02, 0xfc, 0x74, 0xce, 0x99, 0x90, 0xb4, 0x0a, 0xfc, 0x08,
-	0x25, 0x87, 0xd9, 0x9d, 0x9c, 0xf6, 0xbf, 0xdc, 0x4c, 0xbc, 0xae, 0xc4, 0x5b, 0x7c, 0xf0, 0xb0,
-	0xc3, 0xff, 0x74, 0xf4, 0x58, 0xfa, 0xde, 0x6e, 0xfa, 0xf9, 0xc7, 0x1f, 0xeb, 0x10, 0xdd, 0xae,
-	0x43, 0xf4, 0x7b, 0x1d, 0xa2, 0xaf, 0x9b, 0xd0, 0xbb, 0xdd, 0x84, 0xde, 0xcf, 0x4d, 0xe8, 0x7d,
-	0x3a, 0x2d, 0x85, 0x5d, 0x2c, 0x8b, 0x13, 0x06, 0x32, 0x65, 0xa6, 0xd1, 0x16, 0x8e, 0xc1, 0x94,
-	0xc7, 0x6c, 0x41, 0x85, 0xda, 0x5e, 0x59, 0xfa, 0xf9, 0x75, 0xba, 0xba, 0x7b, 0xdb, 0x46, 0xf3,
-	0xba, 0xf0, 0xbb, 0xd3, 0x7a, 0xf3, 0x2f, 0x00, 0x00, 0xff, 0xff, 0xad, 0x72, 0x13, 0x5e, 0x8f,
-	0x02, 0x00, 0x00,
+	// 732 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0xcd, 0x4e, 0xfb, 0x46,
+	0x14, 0xc5, 0x63, 0x08, 0x21, 0x9e, 0x00, 0xaa, 0x86, 0x8f, 0x86, 0x48, 0xd8, 0x69, 0x56, 0x59,
+	0x94, 0x58, 0x4a, 0xa9, 0x2a, 0xa5, 0x55, 0x55, 0x12, 0x54, 0x84, 0xd4, 0x0f, 0xe4, 0xd2, 0x4d,
+	0x37, 0xd6, 0x78, 0x3c, 0x38, 0xa3, 0x78, 0xe6, 0x5a, 0xf6, 0x18, 0x92, 0x37, 0xe8, 0x92, 0x25,
+	0x4b, 0x9e, 0xa5, 0x52, 0x25, 0x96, 0x2c, 0xab, 0x2e, 0x68, 0x05, 0x6f, 0xd0, 0x6d, 0x37, 0x95,
+	0x3d, 0x4e, 0x08, 0xfd, 0x0b, 0x89, 0x55, 0x7c, 0xef, 0x99, 0xdf, 0x9c, 0x9c, 0x3b, 0x1e, 0xa3,
+	0x6d, 0x9a, 0x80, 0x84, 0xd4, 0xd1, 0x3f, 0xbd, 0x38, 0x01, 0x05, 0xb8, 0xa6, 0xab, 0xd6, 0x4e,
+	0x08, 0x21, 0x14, 0x2d, 0x27, 0x7f, 0xd2, 0x6a, 0xcb, 0x0a, 0x01, 0xc2, 0x88, 0x39, 0x45, 0xe5,
+	0x67, 0x97, 0x4e, 0x90, 0x25, 0x44, 0x71, 0x90, 0xa5, 0x6e, 0xff, 0x5f, 0x57, 0x5c, 0xb0, 0x54,
+	0x11, 0x11, 0xeb, 0x05, 0x9d, 0xbb, 0x55, 0x54, 0x3b, 0x27, 0x09, 0x11, 0x29, 0xfe, 0x16, 0x6d,
+	0x72, 0x9f, 0x7a, 0x34, 0x01, 0x2f, 0x60, 0x12, 0x44, 0xd3, 0x68, 0x1b, 0x5d, 0x73, 0xd8, 0xf9,
+	0xe7, 0xd1, 0xb6, 0x66, 0x44, 0x44, 0x83, 0xce, 0x2b, 0xf9, 0x53, 0x10, 0x5c, 0x31, 0x11, 0xab,
+	0x59, 0xc7, 0x6d, 0x70, 0x9f, 0x8e, 0x12, 0x38, 0xc9, 0xfb, 0xd8, 0x46, 0x79, 0xe9, 0xe5, 0x4e,
+	0x90, 0xa9, 0xe6, 0x4a, 0xdb, 0xe8, 0x56, 0x5d, 0xc4, 0x7d, 0x7a, 0xa1, 0x3b, 0xf8, 0x13, 0xb4,
+	0xa1, 0x43, 0x79, 0x24, 0x10, 0x5c, 0x36, 0x57, 0x73, 0x1f, 0xb7, 0xa1, 0x7b, 0xc7, 0x79, 0x0b,
+	0x1f, 0xa1, 0x3d, 0x26, 0x89, 0x1f, 0x31, 0x8f, 0x64, 0x2a, 0x37, 0x8c, 0x23, 0x98, 0x09, 0x26,
+	0x55, 0xb3, 0xda, 0x36, 0xba, 0x75, 0x77, 0x47, 0xab, 0xc7, 0x99, 0x82, 0x93, 0x85, 0x86, 0xbb,
+	0xe8, 0x23, 0x41, 0xa6, 0x1e, 0x25, 0x51, 0xe4, 0x13, 0x3a, 0xf1, 0x42, 0x92, 0x36, 0xd7, 0x0a,
+	0xfb, 0x2d, 0x41, 0xa6, 0xa3, 0xb2, 0x7d, 0x4a, 0xd2, 0xa5, 0xfd, 0x7d, 0x22, 0x27, 0x5e, 0x9c,
+	0x30, 0x0a, 0x22, 0xe6, 0x11, 0x6b, 0xd6, 0x96, 0xf7, 0x1f, 0x12, 0x39, 0x39, 0x5f, 0x68, 0x78,
+	0x80, 0xf6, 0x4b, 0x2a, 0x55, 0x64, 0xc2, 0x65, 0xb8, 0x0c, 0xae, 0x17, 0xe0, 0xc7, 0x7a, 0xc1,
+	0x4f, 0x5a, 0x5f, 0x62, 0xfb, 0x68, 0xb7, 0x64, 0x43, 0xb8, 0x5a, 0xe6, 0xea, 0x05, 0xb7, 0xad,
+	0xc5, 0x53, 0xb8, 0x7a, 0x61, 0x06, 0xd5, 0xdb, 0x3b, 0xbb, 0xd2, 0xf9, 0xcd, 0x40, 0xad, 0x0b,
+	0x98, 0x30, 0xf9, 0x3d, 0x89, 0x63, 0x2e, 0xc3, 0xd1, 0x98, 0xc8, 0x90, 0x9d, 0x27, 0x10, 0x43,
+	0x4a, 0x22, 0xbc, 0x83, 0xd6, 0x14, 0x57, 0x11, 0xd3, 0xc7, 0xe5, 0xea, 0x02, 0xb7, 0x51, 0x23,
+	0x60, 0x29, 0x4d, 0x78, 0x9c, 0xbf, 0x0d, 0xc5, 0x21, 0x98, 0xee, 0x72, 0x2b, 0xe7, 0xf4, 0x31,
+	0xeb, 0xf1, 0xeb, 0x02, 0xb7, 0x50, 0x9d, 0x82, 0x54, 0x09, 0xa1, 0x7a, 0xd4, 0xa6, 0xbb, 0xa8,
+	0xf1, 0x1e, 0xaa, 0xa5, 0x33, 0xe1, 0x43, 0x54, 0x0c, 0xd5, 0x74, 0xcb, 0x0a, 0x37, 0xd1, 0x7a,
+	0xc0, 0x28, 0x17, 0x24, 0x2a, 0xa6, 0xb7, 0xe9, 0xce, 0xcb, 0x41, 0xfd, 0xd7, 0x3b, 0xbb, 0x52,
+	0x84, 0xf8, 0x06, 0x6d, 0x2c, 0x67, 0x78, 0x71, 0x37, 0xde, 0x72, 0x5f, 0x79, 0xed, 0xde, 0xf9,
+	0xd7, 0x40, 0xa6, 0x4b, 0x14, 0xfb, 0x8e, 0x0b, 0xae, 0xde, 0xe0, 0x0f, 0x10, 0xa2, 0x63, 0x22,
+	0x25, 0x8b, 0x3c, 0x1e, 0x94, 0x3b, 0x98, 0x65, 0xe7, 0x2c, 0xc0, 0x5f, 0x21, 0x94, 0xbf, 0x1f,
+	0x5c, 0x5e, 0x46, 0x70, 0xad, 0x73, 0x0f, 0x0f, 0xee, 0x1f, 0xed, 0xca, 0x9f, 0x8f, 0xf6, 0x2e,
+	0x85, 0x54, 0x40, 0x9a, 0x06, 0x93, 0x1e, 0x07, 0x47, 0x10, 0x35, 0xee, 0x9d, 0x49, 0xe5, 0x9a,
+	0x82, 0x4c, 0xcf, 0x8a, 0xf5, 0xf8, 0x6b, 0xd4, 0xc8, 0x69, 0xc8, 0x54, 0x81, 0x57, 0xdf, 0x83,
+	0xe7, 0x7e, 0x3f, 0x6a, 0x00, 0x7f, 0x89, 0x6a, 0xd7, 0x5c, 0x06, 0x70, 0x5d, 0x8c, 0xaf, 0xd1,
+	0xdf, 0xef, 0xe9, 0xcb, 0xd9, 0x9b, 0x5f, 0xce, 0xde, 0x49, 0x79, 0x79, 0x87, 0xf5, 0x7c, 0xd7,
+	0xdb, 0xbf, 0x6c, 0xc3, 0x2d, 0x91, 0xce, 0xef, 0x06, 0xda, 0x5a, 0xa4, 0xff, 0x39, 0x25, 0x21,
+	0xc3, 0x9f, 0xa3, 0x5a, 0x99, 0xc4, 0x78, 0xcf, 0x5f, 0x29, 0x17, 0xe3, 0x2f, 0xd0, 0xfa, 0x3c,
+	0xc2, 0xca, 0x7b, 0xb8, 0xf9, 0x6a, 0x3c, 0x42, 0x28, 0x8b, 0x03, 0xa2, 0x58, 0xe0, 0x11, 0x55,
+	0x4c, 0xaf, 0xd1, 0x6f, 0x7d, 0x90, 0xe1, 0x62, 0xfe, 0x81, 0xd1, 0x21, 0x6e, 0xf2, 0x10, 0x66,
+	0xc9, 0x1d, 0xab, 0xe1, 0x0f, 0xf7, 0x4f, 0x96, 0xf1, 0xf0, 0x64, 0x19, 0x7f, 0x3f, 0x59, 0xc6,
+	0xcd, 0xb3, 0x55, 0x79, 0x78, 0xb6, 0x2a, 0x7f, 0x3c, 0x5b, 0x95, 0x5f, 0x8e, 0x42, 0xae, 0xc6,
+	0x99, 0xdf, 0xa3, 0x20, 0x1c, 0x9a, 0xcc, 0x62, 0x05, 0x87, 0x90, 0x84, 0x87, 0x74, 0x4c, 0xb8,
+	0x2c, 0x3f, 0x89, 0xce, 0x55, 0xdf, 0x99, 0xce, 0x9f, 0xd5, 0x2c, 0x66, 0xa9, 0x5f, 0x2b, 0x8c,
+	0x3f, 0xfb, 0x6f, 0x00, 0x70, 0xda, 0x75, 0x62, 0x3c, 0x05, 0x00, 0x00,
 }
 
 func (m *Params) Marshal() (dAtA []byte, err error) {
@@ -257,6 +430,36 @@ func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.EnableGovPrecompile {
+		i--
+		if m.EnableGovPrecompile {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.EnableStakingPrecompile {
+		i--
+		if m.EnableStakingPrecompile {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.EnableBankPrecompile {
+		i--
+		if m.EnableBankPrecompile {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
 	if m.MaxCallbackGas != 0 {
 		i = encodeVarintCronos(dAtA, i, uint64(m.MaxCallbackGas))
 		i--
@@ -394,6 +597,122 @@ func (m *TokenMapping) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *RateLimit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RateLimit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RateLimit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n1, err1 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.Window, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Window):])
+	if err1 != nil {
+		return 0, err1
+	}
+	i -= n1
+	i = encodeVarintCronos(dAtA, i, uint64(n1))
+	i--
+	dAtA[i] = 0x2a
+	{
+		size := m.MaxOutflow.Size()
+		i -= size
+		if _, err := m.MaxOutflow.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintCronos(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x22
+	{
+		size := m.MaxInflow.Size()
+		i -= size
+		if _, err := m.MaxInflow.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintCronos(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if len(m.ChannelId) > 0 {
+		i -= len(m.ChannelId)
+		copy(dAtA[i:], m.ChannelId)
+		i = encodeVarintCronos(dAtA, i, uint64(len(m.ChannelId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Denom) > 0 {
+		i -= len(m.Denom)
+		copy(dAtA[i:], m.Denom)
+		i = encodeVarintCronos(dAtA, i, uint64(len(m.Denom)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RateLimitUsage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RateLimitUsage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RateLimitUsage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n2, err2 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.UpdatedAt, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.UpdatedAt):])
+	if err2 != nil {
+		return 0, err2
+	}
+	i -= n2
+	i = encodeVarintCronos(dAtA, i, uint64(n2))
+	i--
+	dAtA[i] = 0x1a
+	{
+		size := m.Outflow.Size()
+		i -= size
+		if _, err := m.Outflow.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintCronos(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	{
+		size := m.Inflow.Size()
+		i -= size
+		if _, err := m.Inflow.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintCronos(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintCronos(dAtA []byte, offset int, v uint64) int {
 	offset -= sovCronos(v)
 	base := offset
@@ -428,6 +747,15 @@ func (m *Params) Size() (n int) {
 	if m.MaxCallbackGas != 0 {
 		n += 1 + sovCronos(uint64(m.MaxCallbackGas))
 	}
+	if m.EnableBankPrecompile {
+		n += 2
+	}
+	if m.EnableStakingPrecompile {
+		n += 2
+	}
+	if m.EnableGovPrecompile {
+		n += 2
+	}
 	return n
 }
 
@@ -480,6 +808,44 @@ func (m *TokenMapping) Size() (n int) {
 	return n
 }
 
+func (m *RateLimit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Denom)
+	if l > 0 {
+		n += 1 + l + sovCronos(uint64(l))
+	}
+	l = len(m.ChannelId)
+	if l > 0 {
+		n += 1 + l + sovCronos(uint64(l))
+	}
+	l = m.MaxInflow.Size()
+	n += 1 + l + sovCronos(uint64(l))
+	l = m.MaxOutflow.Size()
+	n += 1 + l + sovCronos(uint64(l))
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Window)
+	n += 1 + l + sovCronos(uint64(l))
+	return n
+}
+
+func (m *RateLimitUsage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Inflow.Size()
+	n += 1 + l + sovCronos(uint64(l))
+	l = m.Outflow.Size()
+	n += 1 + l + sovCronos(uint64(l))
+	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.UpdatedAt)
+	n += 1 + l + sovCronos(uint64(l))
+	return n
+}
+
 func sovCronos(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -637,6 +1003,66 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EnableBankPrecompile", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCronos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.EnableBankPrecompile = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EnableStakingPrecompile", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCronos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.EnableStakingPrecompile = bool(v != 0)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EnableGovPrecompile", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCronos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.EnableGovPrecompile = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCronos(dAtA[iNdEx:])
@@ -1001,6 +1427,372 @@ func (m *TokenMapping) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *RateLimit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCronos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RateLimit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RateLimit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Denom", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCronos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCronos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCronos
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Denom = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCronos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCronos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCronos
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChannelId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxInflow", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCronos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCronos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCronos
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.MaxInflow.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxOutflow", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCronos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCronos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCronos
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.MaxOutflow.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Window", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCronos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCronos
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCronos
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.Window, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCronos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCronos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RateLimitUsage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCronos
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RateLimitUsage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RateLimitUsage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Inflow", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCronos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCronos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCronos
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Inflow.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Outflow", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCronos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCronos
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCronos
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Outflow.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCronos
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCronos
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCronos
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.UpdatedAt, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCronos(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCronos
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipCronos(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -9,11 +9,15 @@ import (
 const (
 	codeErrIbcCroDenomEmpty = uint32(iota) + 2 // NOTE: code 1 is reserved for internal errors
 	codeErrIbcCroDenomInvalid
+	codeErrBridgeDisabled
+	codeErrRateLimitExceeded
 )
 
 // x/cronos module sentinel errors
 var (
 	ErrIbcCroDenomEmpty   = errors.Register(ModuleName, codeErrIbcCroDenomEmpty, "ibc cro denom is not set")
 	ErrIbcCroDenomInvalid = errors.Register(ModuleName, codeErrIbcCroDenomInvalid, "ibc cro denom is invalid")
+	ErrBridgeDisabled     = errors.Register(ModuleName, codeErrBridgeDisabled, "bridge is disabled")
+	ErrRateLimitExceeded  = errors.Register(ModuleName, codeErrRateLimitExceeded, "rate limit exceeded")
 	// this line is used by starport scaffolding # ibc/errors
 )
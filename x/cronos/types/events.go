@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"strconv"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -14,11 +15,22 @@ const (
 	AttributeKeyAmount                = "amount"
 	AttributeKeyReceiver              = "receiver"
 	AttributeKeyEthereumTokenContract = "ethereum_token_contract"
+	AttributeKeyEnable                = "enable"
+	AttributeKeyDenom                 = "denom"
+	AttributeKeyChannelID             = "channel_id"
+	AttributeKeyFlow                  = "flow"
+	AttributeKeyQuota                 = "quota"
+	AttributeKeyUsage                 = "usage"
+
+	AttributeValueInflow  = "inflow"
+	AttributeValueOutflow = "outflow"
 
 	// events
 	EventTypeConvertVouchers             = "convert_vouchers"
 	EventTypeTransferTokens              = "transfer_tokens"
 	EventTypeEthereumSendToCosmosHandled = "ethereum_send_to_cosmos_handled"
+	EventTypeTurnBridge                  = "turn_bridge"
+	EventTypeRateLimitExceeded           = "rate_limit_exceeded"
 )
 
 // NewConvertVouchersEvent constructs a new voucher convert sdk.Event
@@ -40,3 +52,25 @@ func NewTransferTokensEvent(sender string, recipient string, amount fmt.Stringer
 		sdk.NewAttribute(sdk.AttributeKeyAmount, amount.String()),
 	)
 }
+
+// NewTurnBridgeEvent constructs a new bridge turned on or off sdk.Event
+func NewTurnBridgeEvent(sender string, enable bool) sdk.Event {
+	return sdk.NewEvent(
+		EventTypeTurnBridge,
+		sdk.NewAttribute(AttributeKeySender, sender),
+		sdk.NewAttribute(AttributeKeyEnable, strconv.FormatBool(enable)),
+	)
+}
+
+// NewRateLimitExceededEvent constructs a new rate limit tripped sdk.Event
+func NewRateLimitExceededEvent(limit RateLimit, flow string, amount, quota, usage fmt.Stringer) sdk.Event {
+	return sdk.NewEvent(
+		EventTypeRateLimitExceeded,
+		sdk.NewAttribute(AttributeKeyDenom, limit.Denom),
+		sdk.NewAttribute(AttributeKeyChannelID, limit.ChannelId),
+		sdk.NewAttribute(AttributeKeyFlow, flow),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, amount.String()),
+		sdk.NewAttribute(AttributeKeyQuota, quota.String()),
+		sdk.NewAttribute(AttributeKeyUsage, usage.String()),
+	)
+}
@@ -73,6 +73,7 @@ type EvmKeeper interface {
 // CronosKeeper defines the interface for cronos keeper
 type CronosKeeper interface {
 	GetParams(ctx sdk.Context) (params Params)
+	GetContractByDenom(ctx sdk.Context, denom string) (contract common.Address, found bool)
 }
 
 // IbcKeeper defines the interface for ibc keeper
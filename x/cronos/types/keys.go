@@ -2,6 +2,7 @@ package types
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
 )
 
 const (
@@ -28,6 +29,9 @@ const (
 	paramsKey
 	prefixAdminToPermissions
 	prefixBlockList
+	bridgeDisabledKey
+	prefixRateLimit
+	prefixRateLimitUsage
 )
 
 // KVStore key prefixes
@@ -39,6 +43,10 @@ var (
 	ParamsKey                   = []byte{paramsKey}
 	KeyPrefixAdminToPermissions = []byte{prefixAdminToPermissions}
 	KeyPrefixBlockList          = []byte{prefixBlockList}
+	// BridgeDisabledKey is set when the bridge is turned off, the bridge is enabled by default.
+	BridgeDisabledKey       = []byte{bridgeDisabledKey}
+	KeyPrefixRateLimit      = []byte{prefixRateLimit}
+	KeyPrefixRateLimitUsage = []byte{prefixRateLimitUsage}
 )
 
 // this line is used by starport scaffolding # ibc/keys/port
@@ -62,3 +70,14 @@ func ContractToDenomKey(contract []byte) []byte {
 func AdminToPermissionsKey(address sdk.AccAddress) []byte {
 	return append(KeyPrefixAdminToPermissions, address.Bytes()...)
 }
+
+// RateLimitKey defines the store key for the rate limit of denom and channel,
+// the key of the per-denom limit is the prefix of the per-channel ones.
+func RateLimitKey(denom, channelID string) []byte {
+	return append(append(KeyPrefixRateLimit, address.MustLengthPrefix([]byte(denom))...), channelID...)
+}
+
+// RateLimitUsageKey defines the store key for the usage of the rate limit of denom and channel
+func RateLimitUsageKey(denom, channelID string) []byte {
+	return append(append(KeyPrefixRateLimitUsage, address.MustLengthPrefix([]byte(denom))...), channelID...)
+}
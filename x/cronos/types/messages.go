@@ -22,6 +22,7 @@ var (
 	_ sdk.Msg = &MsgTurnBridge{}
 	_ sdk.Msg = &MsgUpdatePermissions{}
 	_ sdk.Msg = &MsgStoreBlockList{}
+	_ sdk.Msg = &MsgSetRateLimit{}
 )
 
 func NewMsgConvertVouchers(address string, coins sdk.Coins) *MsgConvertVouchers {
@@ -129,6 +130,15 @@ func NewMsgTurnBridge(admin string, enable bool) *MsgTurnBridge {
 	}
 }
 
+// GetSigners ...
+func (msg *MsgTurnBridge) GetSigners() []sdk.AccAddress {
+	sender, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{sender}
+}
+
 // ValidateBasic ...
 func (msg *MsgTurnBridge) ValidateBasic() error {
 	_, err := sdk.AccAddressFromBech32(msg.Sender)
@@ -159,6 +169,23 @@ func (msg *MsgUpdateParams) ValidateBasic() error {
 	return nil
 }
 
+// NewMsgSetRateLimit ...
+func NewMsgSetRateLimit(authority string, rateLimit RateLimit) *MsgSetRateLimit {
+	return &MsgSetRateLimit{
+		Authority: authority,
+		RateLimit: rateLimit,
+	}
+}
+
+// ValidateBasic does a sanity check on the provided data.
+func (msg *MsgSetRateLimit) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errors.Wrap(err, "invalid authority address")
+	}
+
+	return msg.RateLimit.Validate()
+}
+
 // NewMsgUpdatePermissions ...
 func NewMsgUpdatePermissions(from string, address string, permissions uint64) *MsgUpdatePermissions {
 	return &MsgUpdatePermissions{
@@ -20,6 +20,12 @@ var (
 	KeyEnableAutoDeployment = []byte("EnableAutoDeployment")
 	// KeyMaxCallbackGas is store's key for the MaxCallbackGas
 	KeyMaxCallbackGas = []byte("MaxCallbackGas")
+	// KeyEnableBankPrecompile is store's key for the EnableBankPrecompile
+	KeyEnableBankPrecompile = []byte("EnableBankPrecompile")
+	// KeyEnableStakingPrecompile is store's key for the EnableStakingPrecompile
+	KeyEnableStakingPrecompile = []byte("EnableStakingPrecompile")
+	// KeyEnableGovPrecompile is store's key for the EnableGovPrecompile
+	KeyEnableGovPrecompile = []byte("EnableGovPrecompile")
 )
 
 const (
@@ -34,24 +40,33 @@ func ParamKeyTable() paramtypes.KeyTable {
 }
 
 // NewParams creates a new parameter configuration for the cronos module
-func NewParams(ibcCroDenom string, ibcTimeout uint64, cronosAdmin string, enableAutoDeployment bool, maxCallbackGas uint64) Params {
+func NewParams(
+	ibcCroDenom string, ibcTimeout uint64, cronosAdmin string, enableAutoDeployment bool, maxCallbackGas uint64,
+	enableBankPrecompile, enableStakingPrecompile, enableGovPrecompile bool,
+) Params {
 	return Params{
-		IbcCroDenom:          ibcCroDenom,
-		IbcTimeout:           ibcTimeout,
-		CronosAdmin:          cronosAdmin,
-		EnableAutoDeployment: enableAutoDeployment,
-		MaxCallbackGas:       maxCallbackGas,
+		IbcCroDenom:             ibcCroDenom,
+		IbcTimeout:              ibcTimeout,
+		CronosAdmin:             cronosAdmin,
+		EnableAutoDeployment:    enableAutoDeployment,
+		MaxCallbackGas:          maxCallbackGas,
+		EnableBankPrecompile:    enableBankPrecompile,
+		EnableStakingPrecompile: enableStakingPrecompile,
+		EnableGovPrecompile:     enableGovPrecompile,
 	}
 }
 
 // DefaultParams is the default parameter configuration for the cronos module
 func DefaultParams() Params {
 	return Params{
-		IbcCroDenom:          IbcCroDenomDefaultValue,
-		IbcTimeout:           IbcTimeoutDefaultValue,
-		CronosAdmin:          "",
-		EnableAutoDeployment: false,
-		MaxCallbackGas:       MaxCallbackGasDefaultValue,
+		IbcCroDenom:             IbcCroDenomDefaultValue,
+		IbcTimeout:              IbcTimeoutDefaultValue,
+		CronosAdmin:             "",
+		EnableAutoDeployment:    false,
+		MaxCallbackGas:          MaxCallbackGasDefaultValue,
+		EnableBankPrecompile:    false,
+		EnableStakingPrecompile: false,
+		EnableGovPrecompile:     false,
 	}
 }
 
@@ -88,6 +103,9 @@ func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
 		paramtypes.NewParamSetPair(KeyCronosAdmin, &p.CronosAdmin, validateIsAddress),
 		paramtypes.NewParamSetPair(KeyEnableAutoDeployment, &p.EnableAutoDeployment, validateIsBool),
 		paramtypes.NewParamSetPair(KeyMaxCallbackGas, &p.MaxCallbackGas, validateIsUint64),
+		paramtypes.NewParamSetPair(KeyEnableBankPrecompile, &p.EnableBankPrecompile, validateIsBool),
+		paramtypes.NewParamSetPair(KeyEnableStakingPrecompile, &p.EnableStakingPrecompile, validateIsBool),
+		paramtypes.NewParamSetPair(KeyEnableGovPrecompile, &p.EnableGovPrecompile, validateIsBool),
 	}
 }
 
@@ -560,6 +560,236 @@ func (m *QueryBlockListResponse) GetBlob() []byte {
 	return nil
 }
 
+// QueryBridgeStatusRequest is the request type for the Query/BridgeStatus RPC
+// method.
+type QueryBridgeStatusRequest struct {
+}
+
+func (m *QueryBridgeStatusRequest) Reset()         { *m = QueryBridgeStatusRequest{} }
+func (m *QueryBridgeStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryBridgeStatusRequest) ProtoMessage()    {}
+func (*QueryBridgeStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d4ed0fd688c48372, []int{12}
+}
+func (m *QueryBridgeStatusRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryBridgeStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryBridgeStatusRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryBridgeStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryBridgeStatusRequest.Merge(m, src)
+}
+func (m *QueryBridgeStatusRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryBridgeStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryBridgeStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryBridgeStatusRequest proto.InternalMessageInfo
+
+// QueryBridgeStatusResponse is the response type for the Query/BridgeStatus RPC
+// method.
+type QueryBridgeStatusResponse struct {
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+}
+
+func (m *QueryBridgeStatusResponse) Reset()         { *m = QueryBridgeStatusResponse{} }
+func (m *QueryBridgeStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryBridgeStatusResponse) ProtoMessage()    {}
+func (*QueryBridgeStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d4ed0fd688c48372, []int{13}
+}
+func (m *QueryBridgeStatusResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryBridgeStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryBridgeStatusResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryBridgeStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryBridgeStatusResponse.Merge(m, src)
+}
+func (m *QueryBridgeStatusResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryBridgeStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryBridgeStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryBridgeStatusResponse proto.InternalMessageInfo
+
+func (m *QueryBridgeStatusResponse) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+// QueryRateLimitsRequest is the request type for the Query/RateLimits RPC
+// method.
+type QueryRateLimitsRequest struct {
+	// only query the rate limits of the denom if not empty
+	Denom string `protobuf:"bytes,1,opt,name=denom,proto3" json:"denom,omitempty"`
+}
+
+func (m *QueryRateLimitsRequest) Reset()         { *m = QueryRateLimitsRequest{} }
+func (m *QueryRateLimitsRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryRateLimitsRequest) ProtoMessage()    {}
+func (*QueryRateLimitsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d4ed0fd688c48372, []int{14}
+}
+func (m *QueryRateLimitsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryRateLimitsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryRateLimitsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryRateLimitsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryRateLimitsRequest.Merge(m, src)
+}
+func (m *QueryRateLimitsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryRateLimitsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryRateLimitsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryRateLimitsRequest proto.InternalMessageInfo
+
+func (m *QueryRateLimitsRequest) GetDenom() string {
+	if m != nil {
+		return m.Denom
+	}
+	return ""
+}
+
+// RateLimitStatus defines a rate limit and its current usage.
+type RateLimitStatus struct {
+	RateLimit RateLimit      `protobuf:"bytes,1,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit"`
+	Usage     RateLimitUsage `protobuf:"bytes,2,opt,name=usage,proto3" json:"usage"`
+}
+
+func (m *RateLimitStatus) Reset()         { *m = RateLimitStatus{} }
+func (m *RateLimitStatus) String() string { return proto.CompactTextString(m) }
+func (*RateLimitStatus) ProtoMessage()    {}
+func (*RateLimitStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d4ed0fd688c48372, []int{15}
+}
+func (m *RateLimitStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RateLimitStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RateLimitStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RateLimitStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RateLimitStatus.Merge(m, src)
+}
+func (m *RateLimitStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *RateLimitStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_RateLimitStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RateLimitStatus proto.InternalMessageInfo
+
+func (m *RateLimitStatus) GetRateLimit() RateLimit {
+	if m != nil {
+		return m.RateLimit
+	}
+	return RateLimit{}
+}
+
+func (m *RateLimitStatus) GetUsage() RateLimitUsage {
+	if m != nil {
+		return m.Usage
+	}
+	return RateLimitUsage{}
+}
+
+// QueryRateLimitsResponse is the response type for the Query/RateLimits RPC
+// method.
+type QueryRateLimitsResponse struct {
+	RateLimits []RateLimitStatus `protobuf:"bytes,1,rep,name=rate_limits,json=rateLimits,proto3" json:"rate_limits"`
+}
+
+func (m *QueryRateLimitsResponse) Reset()         { *m = QueryRateLimitsResponse{} }
+func (m *QueryRateLimitsResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryRateLimitsResponse) ProtoMessage()    {}
+func (*QueryRateLimitsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d4ed0fd688c48372, []int{16}
+}
+func (m *QueryRateLimitsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryRateLimitsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryRateLimitsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryRateLimitsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryRateLimitsResponse.Merge(m, src)
+}
+func (m *QueryRateLimitsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryRateLimitsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryRateLimitsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryRateLimitsResponse proto.InternalMessageInfo
+
+func (m *QueryRateLimitsResponse) GetRateLimits() []RateLimitStatus {
+	if m != nil {
+		return m.RateLimits
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ContractByDenomRequest)(nil), "cronos.ContractByDenomRequest")
 	proto.RegisterType((*ContractByDenomResponse)(nil), "cronos.ContractByDenomResponse")
@@ -573,64 +803,79 @@ func init() {
 	proto.RegisterType((*QueryPermissionsResponse)(nil), "cronos.QueryPermissionsResponse")
 	proto.RegisterType((*QueryBlockListRequest)(nil), "cronos.QueryBlockListRequest")
 	proto.RegisterType((*QueryBlockListResponse)(nil), "cronos.QueryBlockListResponse")
+	proto.RegisterType((*QueryBridgeStatusRequest)(nil), "cronos.QueryBridgeStatusRequest")
+	proto.RegisterType((*QueryBridgeStatusResponse)(nil), "cronos.QueryBridgeStatusResponse")
+	proto.RegisterType((*QueryRateLimitsRequest)(nil), "cronos.QueryRateLimitsRequest")
+	proto.RegisterType((*RateLimitStatus)(nil), "cronos.RateLimitStatus")
+	proto.RegisterType((*QueryRateLimitsResponse)(nil), "cronos.QueryRateLimitsResponse")
 }
 
 func init() { proto.RegisterFile("cronos/query.proto", fileDescriptor_d4ed0fd688c48372) }
 
 var fileDescriptor_d4ed0fd688c48372 = []byte{
-	// 834 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0x4f, 0x6f, 0xdc, 0x44,
-	0x14, 0x5f, 0x37, 0x7f, 0xd8, 0xbc, 0x4d, 0x89, 0x98, 0xa4, 0x1b, 0xd7, 0xa4, 0x76, 0x30, 0x88,
-	0x06, 0xa9, 0xb5, 0xd5, 0x0d, 0x12, 0x88, 0x03, 0x87, 0x0d, 0x95, 0x7a, 0xa0, 0x15, 0x58, 0x39,
-	0x55, 0x95, 0xac, 0xb1, 0x33, 0xd8, 0x56, 0xd7, 0x33, 0xae, 0x67, 0x1c, 0xed, 0xaa, 0xe2, 0x02,
-	0x17, 0x24, 0x2e, 0x95, 0xf8, 0x02, 0xfd, 0x38, 0x3d, 0x56, 0xe2, 0x82, 0x38, 0x00, 0x4a, 0x38,
-	0xf0, 0x31, 0x90, 0xc7, 0x33, 0xbb, 0xde, 0x7f, 0x70, 0xf2, 0xcc, 0xfb, 0xbd, 0x79, 0xbf, 0xf7,
-	0xe7, 0xf7, 0x0c, 0x28, 0x2e, 0x19, 0x65, 0xdc, 0x7f, 0x51, 0x91, 0x72, 0xe2, 0x15, 0x25, 0x13,
-	0x0c, 0x6d, 0x37, 0x36, 0xeb, 0x20, 0x61, 0x09, 0x93, 0x26, 0xbf, 0x3e, 0x35, 0xa8, 0x75, 0x94,
-	0x30, 0x96, 0x8c, 0x88, 0x8f, 0x8b, 0xcc, 0xc7, 0x94, 0x32, 0x81, 0x45, 0xc6, 0x28, 0x57, 0xa8,
-	0xa3, 0x50, 0x79, 0x8b, 0xaa, 0xef, 0x7c, 0x91, 0xe5, 0x84, 0x0b, 0x9c, 0x17, 0xca, 0xe1, 0x36,
-	0x11, 0x29, 0x29, 0xf3, 0x8c, 0x0a, 0x9f, 0x5c, 0xe6, 0xfe, 0xe5, 0x03, 0x5f, 0x8c, 0x15, 0xb4,
-	0xaf, 0x72, 0x69, 0x3e, 0x8d, 0xd1, 0xfd, 0x1c, 0xfa, 0x67, 0x8c, 0x8a, 0x12, 0xc7, 0x62, 0x38,
-	0xf9, 0x8a, 0x50, 0x96, 0x07, 0xe4, 0x45, 0x45, 0xb8, 0x40, 0x07, 0xb0, 0x75, 0x51, 0xdf, 0x4d,
-	0xe3, 0xd8, 0x38, 0xd9, 0x09, 0x9a, 0xcb, 0x17, 0xdd, 0x9f, 0x5e, 0x3b, 0x9d, 0x7f, 0x5e, 0x3b,
-	0x1d, 0xf7, 0x29, 0x1c, 0x2e, 0xbd, 0xe4, 0x05, 0xa3, 0x9c, 0x20, 0x0b, 0xba, 0xb1, 0x82, 0xd4,
-	0xeb, 0xe9, 0x1d, 0x7d, 0x08, 0x37, 0x71, 0x25, 0x58, 0x38, 0x75, 0xb8, 0x21, 0x1d, 0x76, 0x6b,
-	0xa3, 0x8e, 0xe7, 0x7e, 0x09, 0x7d, 0x19, 0x71, 0x38, 0xd1, 0x26, 0x9d, 0xd5, 0x7f, 0x84, 0x6e,
-	0xe5, 0xe6, 0xc3, 0xe1, 0xd2, 0x7b, 0x95, 0xdb, 0xca, 0xb2, 0xdc, 0xdf, 0x0d, 0x40, 0x01, 0x29,
-	0x46, 0x78, 0x32, 0x1c, 0xb1, 0xf8, 0xb9, 0x66, 0x3b, 0x85, 0xcd, 0x9c, 0x27, 0xdc, 0x34, 0x8e,
-	0x37, 0x4e, 0x7a, 0x03, 0xc7, 0x9b, 0x36, 0xd7, 0x23, 0x97, 0xb9, 0x77, 0xf9, 0xc0, 0x7b, 0xcc,
-	0x93, 0x87, 0xb5, 0x8d, 0x54, 0xf9, 0xf9, 0x38, 0x90, 0xce, 0xe8, 0x03, 0xd8, 0x8d, 0xea, 0x20,
-	0x21, 0xad, 0xf2, 0x88, 0x94, 0xb2, 0xc0, 0x8d, 0xa0, 0x27, 0x6d, 0x4f, 0xa4, 0x09, 0xdd, 0x01,
-	0x68, 0x5c, 0x52, 0xcc, 0x53, 0x73, 0x43, 0x66, 0xb2, 0x23, 0x2d, 0x8f, 0x30, 0x4f, 0xd1, 0x99,
-	0x86, 0xeb, 0xe9, 0x9a, 0x9b, 0xc7, 0xc6, 0x49, 0x6f, 0x60, 0x79, 0xcd, 0xe8, 0x3d, 0x3d, 0x7a,
-	0xef, 0x5c, 0x8f, 0x7e, 0xd8, 0x7d, 0xf3, 0x87, 0xd3, 0x79, 0xf5, 0xa7, 0x63, 0xa8, 0x20, 0x35,
-	0xd2, 0xea, 0xc6, 0x33, 0xd8, 0x9f, 0xab, 0x4d, 0x75, 0xe2, 0x21, 0xec, 0x94, 0xea, 0xac, 0x2b,
-	0xbc, 0xfb, 0x7f, 0x15, 0x2a, 0xff, 0x60, 0xf6, 0xd2, 0x3d, 0x00, 0xf4, 0x6d, 0xad, 0xee, 0x6f,
-	0x70, 0x89, 0x73, 0xae, 0x3a, 0xe7, 0x9e, 0xc1, 0xfe, 0x9c, 0x55, 0x71, 0xde, 0x83, 0xed, 0x42,
-	0x5a, 0x64, 0xfb, 0x7b, 0x83, 0x77, 0x3d, 0xa5, 0xc6, 0xc6, 0x6f, 0xb8, 0x59, 0x57, 0x12, 0x28,
-	0x1f, 0xf7, 0x14, 0x0e, 0x9b, 0x20, 0x75, 0x4a, 0x9c, 0xd7, 0x7b, 0xa0, 0x27, 0x63, 0xc2, 0x3b,
-	0xf8, 0xe2, 0xa2, 0x24, 0x9c, 0xab, 0x41, 0xea, 0xab, 0xfb, 0x12, 0xcc, 0xe5, 0x47, 0x8a, 0xfe,
-	0x33, 0x30, 0x63, 0x4c, 0xc3, 0x38, 0xc5, 0x34, 0x21, 0xa1, 0x60, 0xcf, 0x09, 0x0d, 0x73, 0x5c,
-	0x14, 0x19, 0x4d, 0x64, 0x98, 0x6e, 0x70, 0x2b, 0xc6, 0xf4, 0x4c, 0xc2, 0xe7, 0x35, 0xfa, 0xb8,
-	0x01, 0xd1, 0xc7, 0xb0, 0x57, 0x3f, 0x14, 0x55, 0x49, 0xc3, 0xa8, 0xcc, 0x2e, 0x12, 0x22, 0xc7,
-	0xda, 0x0d, 0x6e, 0xc6, 0x98, 0x9e, 0x57, 0x25, 0x1d, 0x4a, 0xa3, 0x7b, 0x08, 0xb7, 0x24, 0xb9,
-	0xec, 0xf4, 0xd7, 0x19, 0xd7, 0xba, 0x75, 0xef, 0x41, 0x7f, 0x11, 0x50, 0x39, 0x21, 0xd8, 0x8c,
-	0x46, 0x2c, 0x92, 0xfc, 0xbb, 0x81, 0x3c, 0x0f, 0x7e, 0xde, 0x82, 0x2d, 0xe9, 0x8e, 0xc6, 0xb0,
-	0xb7, 0xb0, 0x65, 0xc8, 0xd6, 0x3d, 0x5b, 0xbd, 0xb8, 0x96, 0xb3, 0x16, 0x6f, 0x18, 0xdd, 0x8f,
-	0x7e, 0xf8, 0xf5, 0xef, 0x5f, 0x6e, 0xd8, 0xe8, 0x48, 0xfd, 0x0a, 0xea, 0xbf, 0x84, 0x5e, 0xa2,
-	0x30, 0x9a, 0x84, 0x72, 0x25, 0xd0, 0x8f, 0x06, 0xec, 0x2d, 0x2c, 0xd1, 0x8c, 0x7a, 0xf5, 0x76,
-	0xce, 0xa8, 0xd7, 0x6c, 0x9f, 0xeb, 0x4b, 0xea, 0x4f, 0xd0, 0xdd, 0x16, 0xb5, 0xa4, 0xab, 0x79,
-	0x75, 0x0e, 0xfe, 0x4b, 0x7d, 0xfa, 0x1e, 0x3d, 0x82, 0x5e, 0x4b, 0xbb, 0xc8, 0xd2, 0x04, 0xcb,
-	0xcb, 0x6a, 0xbd, 0xbf, 0x12, 0x53, 0xc4, 0x1d, 0xf4, 0x0c, 0xb6, 0x1b, 0x91, 0xcd, 0x82, 0x2c,
-	0xeb, 0x76, 0x16, 0x64, 0x85, 0x7a, 0xdd, 0xdb, 0x32, 0xfb, 0x7d, 0xf4, 0x5e, 0x2b, 0xfb, 0x46,
-	0xaa, 0xa8, 0x80, 0x5e, 0x4b, 0x70, 0xc8, 0x99, 0x0f, 0xb3, 0xa4, 0x5f, 0xeb, 0x78, 0xbd, 0x83,
-	0x22, 0xb3, 0x25, 0x99, 0x89, 0xfa, 0x6d, 0xb2, 0x16, 0x45, 0x0a, 0x3b, 0x53, 0x31, 0xa1, 0x3b,
-	0x73, 0xe1, 0x16, 0xd5, 0x67, 0xd9, 0xeb, 0x60, 0xc5, 0x75, 0x24, 0xb9, 0xfa, 0xe8, 0xa0, 0xc5,
-	0x25, 0xff, 0x24, 0xa3, 0x8c, 0x8b, 0xe1, 0x93, 0x37, 0x57, 0xb6, 0xf1, 0xf6, 0xca, 0x36, 0xfe,
-	0xba, 0xb2, 0x8d, 0x57, 0xd7, 0x76, 0xe7, 0xed, 0xb5, 0xdd, 0xf9, 0xed, 0xda, 0xee, 0x3c, 0xfd,
-	0x34, 0xc9, 0x44, 0x5a, 0x45, 0x5e, 0xcc, 0x72, 0x3f, 0x2e, 0x27, 0x85, 0x60, 0xf7, 0x59, 0x99,
-	0xdc, 0x8f, 0x53, 0x9c, 0xd1, 0x69, 0xa8, 0x81, 0x3f, 0xd6, 0x67, 0x31, 0x29, 0x08, 0x8f, 0xb6,
-	0xe5, 0x2f, 0xec, 0xf4, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x17, 0xeb, 0x13, 0xde, 0x1d, 0x07,
+	// 994 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x55, 0x4d, 0x6f, 0xdb, 0x46,
+	0x13, 0x16, 0xe3, 0x8f, 0xd8, 0x23, 0xe7, 0x35, 0xb2, 0x76, 0x24, 0x9a, 0x71, 0x48, 0x85, 0x6f,
+	0xd1, 0xb8, 0x40, 0x42, 0x22, 0x72, 0xbf, 0xd0, 0x43, 0x0e, 0x72, 0x03, 0xe4, 0x90, 0x04, 0x2d,
+	0xeb, 0x1e, 0x1a, 0x04, 0x20, 0x96, 0xd4, 0x96, 0x22, 0x22, 0x72, 0x19, 0xee, 0xd2, 0xb0, 0x10,
+	0xe4, 0xd2, 0x5e, 0x7a, 0x0c, 0xd0, 0x3f, 0x90, 0x9f, 0x93, 0x63, 0x80, 0x5e, 0x8a, 0x1e, 0xda,
+	0xc2, 0xee, 0xa1, 0xd7, 0xfe, 0x83, 0x82, 0xcb, 0x5d, 0x8a, 0xb2, 0x24, 0xf7, 0xa4, 0xdd, 0x79,
+	0x66, 0x9f, 0x67, 0x3e, 0x38, 0x23, 0x40, 0x61, 0x4e, 0x53, 0xca, 0xdc, 0x97, 0x05, 0xc9, 0x27,
+	0x4e, 0x96, 0x53, 0x4e, 0xd1, 0x7a, 0x65, 0x33, 0x76, 0x23, 0x1a, 0x51, 0x61, 0x72, 0xcb, 0x53,
+	0x85, 0x1a, 0xfb, 0x11, 0xa5, 0xd1, 0x98, 0xb8, 0x38, 0x8b, 0x5d, 0x9c, 0xa6, 0x94, 0x63, 0x1e,
+	0xd3, 0x94, 0x49, 0xd4, 0x92, 0xa8, 0xb8, 0x05, 0xc5, 0xf7, 0x2e, 0x8f, 0x13, 0xc2, 0x38, 0x4e,
+	0x32, 0xe9, 0xb0, 0x47, 0xf8, 0x88, 0xe4, 0x49, 0x9c, 0x72, 0x97, 0x9c, 0x24, 0xee, 0xc9, 0x7d,
+	0x97, 0x9f, 0x4a, 0x68, 0x47, 0xc6, 0x52, 0xfd, 0x54, 0x46, 0xfb, 0x73, 0xe8, 0x1c, 0xd1, 0x94,
+	0xe7, 0x38, 0xe4, 0x83, 0xc9, 0x97, 0x24, 0xa5, 0x89, 0x47, 0x5e, 0x16, 0x84, 0x71, 0xb4, 0x0b,
+	0x6b, 0xc3, 0xf2, 0xae, 0x6b, 0x3d, 0xed, 0x60, 0xd3, 0xab, 0x2e, 0x5f, 0x6c, 0xfc, 0xf4, 0xd6,
+	0x6a, 0xfd, 0xfd, 0xd6, 0x6a, 0xd9, 0xcf, 0xa0, 0x3b, 0xf7, 0x92, 0x65, 0x34, 0x65, 0x04, 0x19,
+	0xb0, 0x11, 0x4a, 0x48, 0xbe, 0xae, 0xef, 0xe8, 0xff, 0x70, 0x0d, 0x17, 0x9c, 0xfa, 0xb5, 0xc3,
+	0x15, 0xe1, 0xb0, 0x55, 0x1a, 0x15, 0x9f, 0xfd, 0x00, 0x3a, 0x82, 0x71, 0x30, 0x51, 0x26, 0x15,
+	0xd5, 0x25, 0xd4, 0x8d, 0xd8, 0x5c, 0xe8, 0xce, 0xbd, 0x97, 0xb1, 0x2d, 0x4c, 0xcb, 0xfe, 0x4d,
+	0x03, 0xe4, 0x91, 0x6c, 0x8c, 0x27, 0x83, 0x31, 0x0d, 0x5f, 0x28, 0xb5, 0x43, 0x58, 0x4d, 0x58,
+	0xc4, 0x74, 0xad, 0xb7, 0x72, 0xd0, 0xee, 0x5b, 0x4e, 0x5d, 0x5c, 0x87, 0x9c, 0x24, 0xce, 0xc9,
+	0x7d, 0xe7, 0x09, 0x8b, 0x1e, 0x96, 0x36, 0x52, 0x24, 0xc7, 0xa7, 0x9e, 0x70, 0x46, 0xb7, 0x61,
+	0x2b, 0x28, 0x49, 0xfc, 0xb4, 0x48, 0x02, 0x92, 0x8b, 0x04, 0x57, 0xbc, 0xb6, 0xb0, 0x3d, 0x15,
+	0x26, 0x74, 0x0b, 0xa0, 0x72, 0x19, 0x61, 0x36, 0xd2, 0x57, 0x44, 0x24, 0x9b, 0xc2, 0xf2, 0x08,
+	0xb3, 0x11, 0x3a, 0x52, 0x70, 0xd9, 0x5d, 0x7d, 0xb5, 0xa7, 0x1d, 0xb4, 0xfb, 0x86, 0x53, 0xb5,
+	0xde, 0x51, 0xad, 0x77, 0x8e, 0x55, 0xeb, 0x07, 0x1b, 0xef, 0x7e, 0xb7, 0x5a, 0x6f, 0xfe, 0xb0,
+	0x34, 0x49, 0x52, 0x22, 0x8d, 0x6a, 0x3c, 0x87, 0x9d, 0x99, 0xdc, 0x64, 0x25, 0x1e, 0xc2, 0x66,
+	0x2e, 0xcf, 0x2a, 0xc3, 0x3b, 0xff, 0x95, 0xa1, 0xf4, 0xf7, 0xa6, 0x2f, 0xed, 0x5d, 0x40, 0x5f,
+	0x97, 0x5f, 0xf7, 0x57, 0x38, 0xc7, 0x09, 0x93, 0x95, 0xb3, 0x8f, 0x60, 0x67, 0xc6, 0x2a, 0x35,
+	0xef, 0xc2, 0x7a, 0x26, 0x2c, 0xa2, 0xfc, 0xed, 0xfe, 0xff, 0x1c, 0xf9, 0x35, 0x56, 0x7e, 0x83,
+	0xd5, 0x32, 0x13, 0x4f, 0xfa, 0xd8, 0x87, 0xd0, 0xad, 0x48, 0xca, 0x90, 0x18, 0x2b, 0xe7, 0x40,
+	0x75, 0x46, 0x87, 0xab, 0x78, 0x38, 0xcc, 0x09, 0x63, 0xb2, 0x91, 0xea, 0x6a, 0xbf, 0x02, 0x7d,
+	0xfe, 0x91, 0x94, 0xff, 0x0c, 0xf4, 0x10, 0xa7, 0x7e, 0x38, 0xc2, 0x69, 0x44, 0x7c, 0x4e, 0x5f,
+	0x90, 0xd4, 0x4f, 0x70, 0x96, 0xc5, 0x69, 0x24, 0x68, 0x36, 0xbc, 0x1b, 0x21, 0x4e, 0x8f, 0x04,
+	0x7c, 0x5c, 0xa2, 0x4f, 0x2a, 0x10, 0x7d, 0x08, 0xdb, 0xe5, 0x43, 0x5e, 0xe4, 0xa9, 0x1f, 0xe4,
+	0xf1, 0x30, 0x22, 0xa2, 0xad, 0x1b, 0xde, 0xb5, 0x10, 0xa7, 0xc7, 0x45, 0x9e, 0x0e, 0x84, 0xd1,
+	0xee, 0xc2, 0x0d, 0x21, 0x2e, 0x2a, 0xfd, 0x38, 0x66, 0xea, 0xbb, 0xb5, 0xef, 0x42, 0xe7, 0x22,
+	0x20, 0x63, 0x42, 0xb0, 0x1a, 0x8c, 0x69, 0x20, 0xf4, 0xb7, 0x3c, 0x71, 0xb6, 0x0d, 0x99, 0x43,
+	0xc5, 0xfa, 0x0d, 0xc7, 0xbc, 0xa8, 0x2b, 0xfb, 0x09, 0xec, 0x2d, 0xc0, 0x24, 0x99, 0x0e, 0x57,
+	0x49, 0x8a, 0x83, 0x31, 0x19, 0xca, 0x7c, 0xd4, 0xd5, 0x76, 0x64, 0x00, 0x1e, 0xe6, 0xe4, 0x71,
+	0x9c, 0xc4, 0x9c, 0x5d, 0x3a, 0xe8, 0xf6, 0x6b, 0xd8, 0xae, 0x5d, 0x2b, 0x11, 0xf4, 0x29, 0x40,
+	0x8e, 0x39, 0xf1, 0xc7, 0xa5, 0x4d, 0x36, 0xf0, 0xba, 0x6a, 0x60, 0xed, 0x2c, 0x7b, 0xb8, 0x99,
+	0x2b, 0x03, 0xea, 0xc3, 0x5a, 0xc1, 0xb0, 0x2c, 0x59, 0xbb, 0xdf, 0x99, 0x7b, 0xf2, 0x6d, 0x89,
+	0xca, 0x77, 0x95, 0xab, 0xfd, 0x9d, 0x6c, 0x7d, 0x33, 0x5c, 0x99, 0xe3, 0x03, 0x68, 0x4f, 0xc3,
+	0x50, 0x5f, 0x6e, 0x77, 0x8e, 0xb4, 0x0a, 0x5a, 0xb2, 0x42, 0x1d, 0x0d, 0xeb, 0xff, 0xb3, 0x0e,
+	0x6b, 0x82, 0x1b, 0x9d, 0xc2, 0xf6, 0x85, 0x15, 0x86, 0x4c, 0xc5, 0xb3, 0x78, 0x2b, 0x1a, 0xd6,
+	0x52, 0xbc, 0x8a, 0xce, 0xfe, 0xe0, 0x87, 0x5f, 0xfe, 0xfa, 0xf9, 0x8a, 0x89, 0xf6, 0xe5, 0x9e,
+	0x2d, 0x57, 0xb0, 0xda, 0x50, 0x7e, 0x30, 0xf1, 0x45, 0x75, 0xd1, 0x8f, 0x1a, 0x6c, 0x5f, 0xd8,
+	0x50, 0x53, 0xe9, 0xc5, 0xab, 0xcf, 0xb0, 0x96, 0xe2, 0x52, 0xda, 0x15, 0xd2, 0x1f, 0xa1, 0x3b,
+	0x0d, 0x69, 0x21, 0x57, 0xea, 0xaa, 0x18, 0xdc, 0x57, 0xea, 0xf4, 0x1a, 0x3d, 0x82, 0x76, 0x63,
+	0x31, 0x20, 0xa3, 0xae, 0xe1, 0xdc, 0x26, 0x34, 0x6e, 0x2e, 0xc4, 0xa4, 0x70, 0x0b, 0x3d, 0x87,
+	0xf5, 0x6a, 0x82, 0xa7, 0x24, 0xf3, 0x4b, 0xc1, 0xb8, 0xb9, 0x10, 0x93, 0x24, 0x7b, 0x22, 0xfa,
+	0x1d, 0x74, 0xbd, 0x11, 0x7d, 0xb5, 0x07, 0x50, 0x06, 0xed, 0xc6, 0x34, 0x23, 0x6b, 0x96, 0x66,
+	0x6e, 0x39, 0x18, 0xbd, 0xe5, 0x0e, 0x52, 0xcc, 0x14, 0x62, 0x3a, 0xea, 0x34, 0xc5, 0x1a, 0x12,
+	0x23, 0xd8, 0xac, 0x27, 0x15, 0xdd, 0x9a, 0xa1, 0xbb, 0x38, 0xda, 0x86, 0xb9, 0x0c, 0x96, 0x5a,
+	0xfb, 0x42, 0xab, 0x83, 0x76, 0x1b, 0x5a, 0x62, 0x4d, 0x8f, 0x4b, 0xf2, 0x02, 0xb6, 0x9a, 0x93,
+	0x8c, 0x66, 0x63, 0x5f, 0xb0, 0x00, 0x8c, 0xdb, 0x97, 0x78, 0x48, 0xc9, 0x9e, 0x90, 0x34, 0x90,
+	0xde, 0x94, 0x14, 0x8e, 0x3e, 0xab, 0x64, 0x12, 0x80, 0xe9, 0x68, 0xa1, 0xd9, 0x14, 0xe6, 0x56,
+	0x84, 0x61, 0x2d, 0xc5, 0x2f, 0xa9, 0x67, 0x63, 0x48, 0x07, 0x4f, 0xdf, 0x9d, 0x99, 0xda, 0xfb,
+	0x33, 0x53, 0xfb, 0xf3, 0xcc, 0xd4, 0xde, 0x9c, 0x9b, 0xad, 0xf7, 0xe7, 0x66, 0xeb, 0xd7, 0x73,
+	0xb3, 0xf5, 0xec, 0xe3, 0x28, 0xe6, 0xa3, 0x22, 0x70, 0x42, 0x9a, 0xb8, 0x61, 0x3e, 0xc9, 0x38,
+	0xbd, 0x47, 0xf3, 0xe8, 0x5e, 0x38, 0xc2, 0x71, 0x5a, 0x93, 0xf5, 0xdd, 0x53, 0x75, 0xe6, 0x93,
+	0x8c, 0xb0, 0x60, 0x5d, 0xfc, 0x0b, 0x1e, 0xfe, 0x3b, 0x00, 0x96, 0xdb, 0x18, 0xbc, 0x60, 0x09,
 	0x00, 0x00,
 }
 
@@ -659,6 +904,10 @@ type QueryClient interface {
 	Permissions(ctx context.Context, in *QueryPermissionsRequest, opts ...grpc.CallOption) (*QueryPermissionsResponse, error)
 	// BlockList
 	BlockList(ctx context.Context, in *QueryBlockListRequest, opts ...grpc.CallOption) (*QueryBlockListResponse, error)
+	// BridgeStatus queries if the bridge is enabled
+	BridgeStatus(ctx context.Context, in *QueryBridgeStatusRequest, opts ...grpc.CallOption) (*QueryBridgeStatusResponse, error)
+	// RateLimits queries the rate limits and the current usages
+	RateLimits(ctx context.Context, in *QueryRateLimitsRequest, opts ...grpc.CallOption) (*QueryRateLimitsResponse, error)
 }
 
 type queryClient struct {
@@ -723,6 +972,24 @@ func (c *queryClient) BlockList(ctx context.Context, in *QueryBlockListRequest,
 	return out, nil
 }
 
+func (c *queryClient) BridgeStatus(ctx context.Context, in *QueryBridgeStatusRequest, opts ...grpc.CallOption) (*QueryBridgeStatusResponse, error) {
+	out := new(QueryBridgeStatusResponse)
+	err := c.cc.Invoke(ctx, "/cronos.Query/BridgeStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) RateLimits(ctx context.Context, in *QueryRateLimitsRequest, opts ...grpc.CallOption) (*QueryRateLimitsResponse, error) {
+	out := new(QueryRateLimitsResponse)
+	err := c.cc.Invoke(ctx, "/cronos.Query/RateLimits", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // QueryServer is the server API for Query service.
 type QueryServer interface {
 	// ContractByDenom queries contract addresses by native denom from a query string.
@@ -738,6 +1005,10 @@ type QueryServer interface {
 	Permissions(context.Context, *QueryPermissionsRequest) (*QueryPermissionsResponse, error)
 	// BlockList
 	BlockList(context.Context, *QueryBlockListRequest) (*QueryBlockListResponse, error)
+	// BridgeStatus queries if the bridge is enabled
+	BridgeStatus(context.Context, *QueryBridgeStatusRequest) (*QueryBridgeStatusResponse, error)
+	// RateLimits queries the rate limits and the current usages
+	RateLimits(context.Context, *QueryRateLimitsRequest) (*QueryRateLimitsResponse, error)
 }
 
 // UnimplementedQueryServer can be embedded to have forward compatible implementations.
@@ -762,6 +1033,12 @@ func (*UnimplementedQueryServer) Permissions(ctx context.Context, req *QueryPerm
 func (*UnimplementedQueryServer) BlockList(ctx context.Context, req *QueryBlockListRequest) (*QueryBlockListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BlockList not implemented")
 }
+func (*UnimplementedQueryServer) BridgeStatus(ctx context.Context, req *QueryBridgeStatusRequest) (*QueryBridgeStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BridgeStatus not implemented")
+}
+func (*UnimplementedQueryServer) RateLimits(ctx context.Context, req *QueryRateLimitsRequest) (*QueryRateLimitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RateLimits not implemented")
+}
 
 func RegisterQueryServer(s grpc1.Server, srv QueryServer) {
 	s.RegisterService(&_Query_serviceDesc, srv)
@@ -875,6 +1152,43 @@ func _Query_BlockList_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Query_BridgeStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryBridgeStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).BridgeStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cronos.Query/BridgeStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).BridgeStatus(ctx, req.(*QueryBridgeStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_RateLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryRateLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).RateLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cronos.Query/RateLimits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).RateLimits(ctx, req.(*QueryRateLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var Query_serviceDesc = _Query_serviceDesc
 var _Query_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "cronos.Query",
 	HandlerType: (*QueryServer)(nil),
@@ -903,6 +1217,14 @@ var _Query_serviceDesc = grpc.ServiceDesc{
 			MethodName: "BlockList",
 			Handler:    _Query_BlockList_Handler,
 		},
+		{
+			MethodName: "BridgeStatus",
+			Handler:    _Query_BridgeStatus_Handler,
+		},
+		{
+			MethodName: "RateLimits",
+			Handler:    _Query_RateLimits_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "cronos/query.proto",
@@ -1311,64 +1633,230 @@ func (m *QueryBlockListResponse) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintQuery(dAtA []byte, offset int, v uint64) int {
-	offset -= sovQuery(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *QueryBridgeStatusRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *ContractByDenomRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Denom)
-	if l > 0 {
-		n += 1 + l + sovQuery(uint64(l))
-	}
-	return n
+
+func (m *QueryBridgeStatusRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ContractByDenomResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *QueryBridgeStatusRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Contract)
-	if l > 0 {
-		n += 1 + l + sovQuery(uint64(l))
-	}
-	l = len(m.AutoContract)
-	if l > 0 {
-		n += 1 + l + sovQuery(uint64(l))
-	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *DenomByContractRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *QueryBridgeStatusResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryBridgeStatusResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryBridgeStatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Contract)
-	if l > 0 {
-		n += 1 + l + sovQuery(uint64(l))
+	if m.Enabled {
+		i--
+		if m.Enabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *DenomByContractResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *QueryRateLimitsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryRateLimitsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryRateLimitsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Denom) > 0 {
+		i -= len(m.Denom)
+		copy(dAtA[i:], m.Denom)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Denom)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RateLimitStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RateLimitStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RateLimitStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Usage.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQuery(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	{
+		size, err := m.RateLimit.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQuery(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryRateLimitsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryRateLimitsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryRateLimitsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.RateLimits) > 0 {
+		for iNdEx := len(m.RateLimits) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.RateLimits[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQuery(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintQuery(dAtA []byte, offset int, v uint64) int {
+	offset -= sovQuery(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ContractByDenomRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Denom)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *ContractByDenomResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Contract)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	l = len(m.AutoContract)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *DenomByContractRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Contract)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *DenomByContractResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
 	var l int
 	_ = l
 	l = len(m.Denom)
@@ -1487,6 +1975,68 @@ func (m *QueryBlockListResponse) Size() (n int) {
 	return n
 }
 
+func (m *QueryBridgeStatusRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *QueryBridgeStatusResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Enabled {
+		n += 2
+	}
+	return n
+}
+
+func (m *QueryRateLimitsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Denom)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *RateLimitStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.RateLimit.Size()
+	n += 1 + l + sovQuery(uint64(l))
+	l = m.Usage.Size()
+	n += 1 + l + sovQuery(uint64(l))
+	return n
+}
+
+func (m *QueryRateLimitsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.RateLimits) > 0 {
+		for _, e := range m.RateLimits {
+			l = e.Size()
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovQuery(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -2544,6 +3094,408 @@ func (m *QueryBlockListResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *QueryBridgeStatusRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryBridgeStatusRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryBridgeStatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryBridgeStatusResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryBridgeStatusResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryBridgeStatusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Enabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Enabled = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryRateLimitsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryRateLimitsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryRateLimitsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Denom", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Denom = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RateLimitStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RateLimitStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RateLimitStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RateLimit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.RateLimit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Usage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Usage.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryRateLimitsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryRateLimitsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryRateLimitsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RateLimits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RateLimits = append(m.RateLimits, RateLimitStatus{})
+			if err := m.RateLimits[len(m.RateLimits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipQuery(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -0,0 +1,76 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
+)
+
+// NewRateLimit creates a new rate limit, an empty channel id means the limit applies to all the channels.
+func NewRateLimit(denom, channelID string, maxInflow, maxOutflow sdkmath.Int, window time.Duration) RateLimit {
+	return RateLimit{
+		Denom:      denom,
+		ChannelId:  channelID,
+		MaxInflow:  maxInflow,
+		MaxOutflow: maxOutflow,
+		Window:     window,
+	}
+}
+
+// IsEmpty returns if neither inflow nor outflow is limited, setting an empty rate limit removes it.
+func (l RateLimit) IsEmpty() bool {
+	return l.MaxInflow.IsZero() && l.MaxOutflow.IsZero()
+}
+
+// Validate checks the rate limit
+func (l RateLimit) Validate() error {
+	if err := sdk.ValidateDenom(l.Denom); err != nil {
+		return err
+	}
+	if l.ChannelId != "" {
+		if err := host.ChannelIdentifierValidator(l.ChannelId); err != nil {
+			return err
+		}
+	}
+	if l.MaxInflow.IsNil() || l.MaxInflow.IsNegative() {
+		return fmt.Errorf("invalid max inflow: %s", l.MaxInflow)
+	}
+	if l.MaxOutflow.IsNil() || l.MaxOutflow.IsNegative() {
+		return fmt.Errorf("invalid max outflow: %s", l.MaxOutflow)
+	}
+	if !l.IsEmpty() && l.Window <= 0 {
+		return fmt.Errorf("invalid window: %s", l.Window)
+	}
+	return nil
+}
+
+// NewRateLimitUsage creates an empty usage updated at the time.
+func NewRateLimitUsage(updatedAt time.Time) RateLimitUsage {
+	return RateLimitUsage{
+		Inflow:    sdkmath.ZeroInt(),
+		Outflow:   sdkmath.ZeroInt(),
+		UpdatedAt: updatedAt,
+	}
+}
+
+// DecayTo returns the usage at the time, the amounts decay linearly to zero in the window since the last update, so
+// the flows are limited in a sliding window, there's no burst of twice the quota around the window boundaries.
+func (u RateLimitUsage) DecayTo(now time.Time, window time.Duration) RateLimitUsage {
+	elapsed := now.Sub(u.UpdatedAt)
+	if elapsed <= 0 {
+		return u
+	}
+	if elapsed >= window {
+		return NewRateLimitUsage(now)
+	}
+	remaining := sdkmath.NewInt(int64(window - elapsed))
+	total := sdkmath.NewInt(int64(window))
+	return RateLimitUsage{
+		Inflow:    u.Inflow.Mul(remaining).Quo(total),
+		Outflow:   u.Outflow.Mul(remaining).Quo(total),
+		UpdatedAt: now,
+	}
+}
@@ -707,6 +707,98 @@ func (m *MsgStoreBlockListResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_MsgStoreBlockListResponse proto.InternalMessageInfo
 
+// MsgSetRateLimit defines the request type for setting a rate limit, the rate
+// limit is removed if both the max inflow and max outflow are zero.
+type MsgSetRateLimit struct {
+	// authority is the address of the governance account.
+	Authority string    `protobuf:"bytes,1,opt,name=authority,proto3" json:"authority,omitempty"`
+	RateLimit RateLimit `protobuf:"bytes,2,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit"`
+}
+
+func (m *MsgSetRateLimit) Reset()         { *m = MsgSetRateLimit{} }
+func (m *MsgSetRateLimit) String() string { return proto.CompactTextString(m) }
+func (*MsgSetRateLimit) ProtoMessage()    {}
+func (*MsgSetRateLimit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_28e09e4eabb18884, []int{14}
+}
+func (m *MsgSetRateLimit) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MsgSetRateLimit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MsgSetRateLimit.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MsgSetRateLimit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MsgSetRateLimit.Merge(m, src)
+}
+func (m *MsgSetRateLimit) XXX_Size() int {
+	return m.Size()
+}
+func (m *MsgSetRateLimit) XXX_DiscardUnknown() {
+	xxx_messageInfo_MsgSetRateLimit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MsgSetRateLimit proto.InternalMessageInfo
+
+func (m *MsgSetRateLimit) GetAuthority() string {
+	if m != nil {
+		return m.Authority
+	}
+	return ""
+}
+
+func (m *MsgSetRateLimit) GetRateLimit() RateLimit {
+	if m != nil {
+		return m.RateLimit
+	}
+	return RateLimit{}
+}
+
+// MsgSetRateLimitResponse defines the response type.
+type MsgSetRateLimitResponse struct {
+}
+
+func (m *MsgSetRateLimitResponse) Reset()         { *m = MsgSetRateLimitResponse{} }
+func (m *MsgSetRateLimitResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgSetRateLimitResponse) ProtoMessage()    {}
+func (*MsgSetRateLimitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_28e09e4eabb18884, []int{15}
+}
+func (m *MsgSetRateLimitResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MsgSetRateLimitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MsgSetRateLimitResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MsgSetRateLimitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MsgSetRateLimitResponse.Merge(m, src)
+}
+func (m *MsgSetRateLimitResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *MsgSetRateLimitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MsgSetRateLimitResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MsgSetRateLimitResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*MsgConvertVouchers)(nil), "cronos.MsgConvertVouchers")
 	proto.RegisterType((*MsgTransferTokens)(nil), "cronos.MsgTransferTokens")
@@ -722,60 +814,65 @@ func init() {
 	proto.RegisterType((*MsgUpdatePermissionsResponse)(nil), "cronos.MsgUpdatePermissionsResponse")
 	proto.RegisterType((*MsgStoreBlockList)(nil), "cronos.MsgStoreBlockList")
 	proto.RegisterType((*MsgStoreBlockListResponse)(nil), "cronos.MsgStoreBlockListResponse")
+	proto.RegisterType((*MsgSetRateLimit)(nil), "cronos.MsgSetRateLimit")
+	proto.RegisterType((*MsgSetRateLimitResponse)(nil), "cronos.MsgSetRateLimitResponse")
 }
 
 func init() { proto.RegisterFile("cronos/tx.proto", fileDescriptor_28e09e4eabb18884) }
 
 var fileDescriptor_28e09e4eabb18884 = []byte{
-	// 761 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0xbd, 0x6e, 0xe3, 0x46,
-	0x10, 0x16, 0xf5, 0x17, 0x7b, 0x64, 0xcb, 0xf0, 0xc6, 0xb6, 0x24, 0x46, 0xa6, 0x14, 0x21, 0x01,
-	0x04, 0x23, 0x16, 0x63, 0x25, 0x95, 0x4b, 0x19, 0x08, 0x52, 0x58, 0x46, 0xc2, 0x38, 0x09, 0xe0,
-	0x8e, 0x3f, 0x6b, 0x8a, 0xb0, 0xc8, 0x65, 0x76, 0x57, 0x82, 0xd5, 0x05, 0x79, 0x82, 0xbc, 0xc1,
-	0x5d, 0x73, 0xcd, 0x55, 0x7e, 0x86, 0xab, 0x5c, 0xba, 0xbc, 0xea, 0xee, 0x60, 0x17, 0x7e, 0x8d,
-	0x03, 0x97, 0x2b, 0x8a, 0xfa, 0x73, 0x77, 0x15, 0x77, 0xe6, 0xdb, 0x99, 0xef, 0x1b, 0xce, 0x0c,
-	0x09, 0x3b, 0x36, 0x25, 0x01, 0x61, 0x3a, 0xbf, 0xed, 0x84, 0x94, 0x70, 0x82, 0x8a, 0xb1, 0x43,
-	0xad, 0xd8, 0x84, 0xf9, 0x84, 0xe9, 0x3e, 0x73, 0xf5, 0xf1, 0x49, 0xf4, 0x88, 0x2f, 0xa8, 0x7b,
-	0x2e, 0x71, 0x89, 0x38, 0xea, 0xd1, 0x49, 0x7a, 0x35, 0x79, 0xdd, 0x32, 0x19, 0xd6, 0xc7, 0x27,
-	0x16, 0xe6, 0xe6, 0x89, 0x6e, 0x13, 0x2f, 0x90, 0xf8, 0xd7, 0x92, 0x27, 0x7e, 0xc4, 0xce, 0xd6,
-	0x2b, 0x05, 0x50, 0x9f, 0xb9, 0x67, 0x24, 0x18, 0x63, 0xca, 0xff, 0x22, 0x23, 0x7b, 0x80, 0x29,
-	0x43, 0x55, 0xf8, 0xca, 0x74, 0x1c, 0x8a, 0x19, 0xab, 0x2a, 0x4d, 0xa5, 0xbd, 0x69, 0x4c, 0x4d,
-	0x64, 0x42, 0x21, 0xca, 0xc9, 0xaa, 0xd9, 0x66, 0xae, 0x5d, 0xea, 0xd6, 0x3a, 0x31, 0x6b, 0x27,
-	0x62, 0xed, 0x48, 0xd6, 0xce, 0x19, 0xf1, 0x82, 0xde, 0x8f, 0xf7, 0x1f, 0x1a, 0x99, 0xb7, 0x1f,
-	0x1b, 0x6d, 0xd7, 0xe3, 0x83, 0x91, 0xd5, 0xb1, 0x89, 0xaf, 0x4b, 0x89, 0xf1, 0xe3, 0x98, 0x39,
-	0x37, 0x3a, 0x9f, 0x84, 0x98, 0x89, 0x00, 0x66, 0xc4, 0x99, 0x4f, 0xb7, 0xfe, 0x7b, 0xbe, 0x3b,
-	0x9a, 0x12, 0xb6, 0xde, 0x28, 0xb0, 0xdb, 0x67, 0xee, 0x25, 0x35, 0x03, 0x76, 0x8d, 0xe9, 0x25,
-	0xb9, 0xc1, 0x01, 0x43, 0x08, 0xf2, 0xd7, 0x94, 0xf8, 0x52, 0x9d, 0x38, 0xa3, 0x32, 0x64, 0x39,
-	0xa9, 0x66, 0x85, 0x27, 0xcb, 0xc9, 0x4c, 0x6a, 0xee, 0x8b, 0x49, 0xdd, 0x8c, 0xa4, 0x0a, 0xf6,
-	0x56, 0x1d, 0xd4, 0xe5, 0x17, 0x69, 0x60, 0x16, 0x92, 0x80, 0xe1, 0xd6, 0x37, 0x50, 0x5b, 0x2a,
-	0x22, 0x01, 0x5f, 0x2b, 0xb0, 0xdf, 0x67, 0xee, 0x9f, 0xa1, 0x63, 0x72, 0x2c, 0xb0, 0xbe, 0x19,
-	0x86, 0x5e, 0xe0, 0xa2, 0x03, 0x28, 0x32, 0x1c, 0x38, 0x98, 0xca, 0x42, 0xa5, 0x85, 0xf6, 0xa0,
-	0xe0, 0xe0, 0x80, 0xf8, 0xb2, 0xda, 0xd8, 0x40, 0x2a, 0x6c, 0xd8, 0x24, 0xe0, 0xd4, 0xb4, 0x79,
-	0x35, 0x27, 0x80, 0xc4, 0x16, 0x99, 0x26, 0xbe, 0x45, 0x86, 0xd5, 0xbc, 0xcc, 0x24, 0xac, 0xa8,
-	0xd3, 0x0e, 0xb6, 0x3d, 0xdf, 0x1c, 0x56, 0x0b, 0x4d, 0xa5, 0xbd, 0x6d, 0x4c, 0xcd, 0xd3, 0x52,
-	0x54, 0x9b, 0x24, 0x6c, 0x35, 0xe0, 0x70, 0xa5, 0xc2, 0xa4, 0x86, 0x73, 0xd8, 0x8e, 0x0a, 0x1c,
-	0xd1, 0xa0, 0x47, 0x3d, 0xc7, 0xc5, 0x6b, 0xa5, 0x1f, 0x40, 0x11, 0x07, 0xa6, 0x35, 0xc4, 0x42,
-	0xfb, 0x86, 0x21, 0xad, 0x79, 0xba, 0x8a, 0x78, 0x21, 0xb3, 0x6c, 0x09, 0x8d, 0x0f, 0x3b, 0x89,
-	0x8e, 0xdf, 0x4c, 0x6a, 0xfa, 0x0c, 0xd5, 0x61, 0xd3, 0x1c, 0xf1, 0x01, 0xa1, 0x1e, 0x9f, 0x48,
-	0xae, 0x99, 0x03, 0xfd, 0x00, 0xc5, 0x50, 0xdc, 0x13, 0x74, 0xa5, 0x6e, 0xb9, 0x23, 0xe7, 0x3f,
-	0x8e, 0xee, 0xe5, 0xa3, 0xd6, 0x1b, 0xf2, 0xce, 0x69, 0x39, 0x12, 0x31, 0x8b, 0x6e, 0xd5, 0xa0,
-	0xb2, 0x40, 0x97, 0x28, 0xf9, 0x07, 0xf6, 0x66, 0x10, 0xa6, 0xbe, 0xc7, 0x98, 0x47, 0xd6, 0x4c,
-	0x66, 0x6a, 0x9d, 0xb2, 0xf3, 0xeb, 0xd4, 0x84, 0x52, 0x38, 0x0b, 0x16, 0x5d, 0xcb, 0x1b, 0x69,
-	0x57, 0x7a, 0xc4, 0x34, 0xa8, 0xaf, 0xa2, 0x4c, 0x24, 0xfd, 0x22, 0x36, 0xe5, 0x0f, 0x4e, 0x28,
-	0xee, 0x0d, 0x89, 0x7d, 0x73, 0xee, 0x31, 0xbe, 0x52, 0x0f, 0x82, 0xbc, 0x35, 0x24, 0x96, 0x10,
-	0xb3, 0x65, 0x88, 0x73, 0x9a, 0x27, 0x1e, 0xd6, 0xf9, 0x3c, 0x53, 0x92, 0xee, 0xbb, 0x3c, 0xe4,
-	0xfa, 0xcc, 0x45, 0xbf, 0xc3, 0xce, 0xe2, 0x57, 0x43, 0x9d, 0xbe, 0xdb, 0xe5, 0x45, 0x50, 0x5b,
-	0xeb, 0xb1, 0x69, 0x6a, 0x74, 0x01, 0xe5, 0x85, 0x35, 0xaf, 0xa5, 0xa2, 0xe6, 0x21, 0xf5, 0xdb,
-	0xb5, 0x50, 0x92, 0xef, 0x0a, 0xd0, 0x8a, 0x9d, 0x3a, 0x4c, 0x05, 0x2e, 0xc3, 0xea, 0xf7, 0x2f,
-	0xc2, 0x49, 0xee, 0x1e, 0x40, 0x6a, 0xd8, 0xf7, 0xd3, 0x62, 0x12, 0xb7, 0x7a, 0xb8, 0xd2, 0x9d,
-	0xe4, 0xf8, 0x15, 0xb6, 0xe6, 0x26, 0xb9, 0xb2, 0x44, 0x1d, 0x03, 0x6a, 0x63, 0x0d, 0x90, 0x64,
-	0xfa, 0x1b, 0x76, 0x97, 0x27, 0xb1, 0xbe, 0x1c, 0x35, 0x43, 0xd5, 0xef, 0x5e, 0x42, 0xd3, 0x2d,
-	0x59, 0x98, 0xa7, 0x74, 0x4b, 0xe6, 0xa1, 0xb9, 0x96, 0xac, 0x9e, 0x1e, 0xb5, 0xf0, 0xef, 0xf3,
-	0xdd, 0x91, 0xd2, 0xbb, 0xb8, 0x7f, 0xd4, 0x94, 0x87, 0x47, 0x4d, 0xf9, 0xf4, 0xa8, 0x29, 0xff,
-	0x3f, 0x69, 0x99, 0x87, 0x27, 0x2d, 0xf3, 0xfe, 0x49, 0xcb, 0x5c, 0xfd, 0x9c, 0xfe, 0x04, 0xd3,
-	0x49, 0xc8, 0xc9, 0x31, 0xa1, 0xee, 0xb1, 0x3d, 0x30, 0xbd, 0x40, 0xfe, 0xba, 0xf4, 0x71, 0x57,
-	0xbf, 0x9d, 0x9e, 0xc5, 0x47, 0xd9, 0x2a, 0x8a, 0xbf, 0xd9, 0x4f, 0x9f, 0x03, 0x00, 0x00, 0xff,
-	0xff, 0x6d, 0x0b, 0x10, 0xeb, 0x4c, 0x07, 0x00, 0x00,
+	// 815 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0x41, 0x8f, 0xdb, 0x44,
+	0x14, 0x5e, 0x67, 0x93, 0xd0, 0x7d, 0xd9, 0x66, 0xb5, 0xc3, 0x6e, 0x93, 0x98, 0xac, 0x93, 0x46,
+	0x20, 0x45, 0x15, 0x1b, 0xb3, 0x01, 0x71, 0xd8, 0x63, 0x2a, 0x21, 0x0e, 0x9b, 0x0a, 0xdc, 0x02,
+	0x52, 0x2f, 0x68, 0x6c, 0x4f, 0x1d, 0x6b, 0x63, 0x8f, 0x99, 0x99, 0x84, 0xe6, 0x86, 0x38, 0x73,
+	0xe0, 0x1f, 0xc0, 0x85, 0x0b, 0xa7, 0xfe, 0x8c, 0x1e, 0x7b, 0xe4, 0x04, 0x68, 0xf7, 0xd0, 0xbf,
+	0x81, 0x3c, 0x1e, 0x3b, 0x76, 0x12, 0x2f, 0xa7, 0x9e, 0x3c, 0xf3, 0xbe, 0x79, 0xdf, 0xf7, 0xbd,
+	0xbc, 0x79, 0x19, 0x38, 0x72, 0x18, 0x0d, 0x29, 0x37, 0xc5, 0xcb, 0x51, 0xc4, 0xa8, 0xa0, 0xa8,
+	0x9e, 0x04, 0xf4, 0x96, 0x43, 0x79, 0x40, 0xb9, 0x19, 0x70, 0xcf, 0x5c, 0x5e, 0xc4, 0x9f, 0xe4,
+	0x80, 0x7e, 0xe2, 0x51, 0x8f, 0xca, 0xa5, 0x19, 0xaf, 0x54, 0xd4, 0x50, 0xc7, 0x6d, 0xcc, 0x89,
+	0xb9, 0xbc, 0xb0, 0x89, 0xc0, 0x17, 0xa6, 0x43, 0xfd, 0x50, 0xe1, 0xef, 0x2b, 0x9d, 0xe4, 0x93,
+	0x04, 0x07, 0xbf, 0x69, 0x80, 0xa6, 0xdc, 0x7b, 0x4c, 0xc3, 0x25, 0x61, 0xe2, 0x5b, 0xba, 0x70,
+	0x66, 0x84, 0x71, 0xd4, 0x86, 0xf7, 0xb0, 0xeb, 0x32, 0xc2, 0x79, 0x5b, 0xeb, 0x6b, 0xc3, 0x03,
+	0x2b, 0xdd, 0x22, 0x0c, 0xb5, 0x98, 0x93, 0xb7, 0x2b, 0xfd, 0xfd, 0x61, 0x63, 0xdc, 0x19, 0x25,
+	0xaa, 0xa3, 0x58, 0x75, 0xa4, 0x54, 0x47, 0x8f, 0xa9, 0x1f, 0x4e, 0x3e, 0x79, 0xfd, 0x77, 0x6f,
+	0xef, 0xcf, 0x7f, 0x7a, 0x43, 0xcf, 0x17, 0xb3, 0x85, 0x3d, 0x72, 0x68, 0x60, 0x2a, 0x8b, 0xc9,
+	0xe7, 0x9c, 0xbb, 0xd7, 0xa6, 0x58, 0x45, 0x84, 0xcb, 0x04, 0x6e, 0x25, 0xcc, 0x97, 0x87, 0x3f,
+	0xbf, 0x7d, 0xf5, 0x28, 0x15, 0x1c, 0xfc, 0xa1, 0xc1, 0xf1, 0x94, 0x7b, 0xcf, 0x18, 0x0e, 0xf9,
+	0x0b, 0xc2, 0x9e, 0xd1, 0x6b, 0x12, 0x72, 0x84, 0xa0, 0xfa, 0x82, 0xd1, 0x40, 0xb9, 0x93, 0x6b,
+	0xd4, 0x84, 0x8a, 0xa0, 0xed, 0x8a, 0x8c, 0x54, 0x04, 0x5d, 0x5b, 0xdd, 0x7f, 0x67, 0x56, 0x0f,
+	0x62, 0xab, 0x52, 0x7d, 0xd0, 0x05, 0x7d, 0xfb, 0x87, 0xb4, 0x08, 0x8f, 0x68, 0xc8, 0xc9, 0xe0,
+	0x03, 0xe8, 0x6c, 0x15, 0x91, 0x81, 0xbf, 0x6b, 0x70, 0x3a, 0xe5, 0xde, 0x37, 0x91, 0x8b, 0x05,
+	0x91, 0xd8, 0x14, 0x47, 0x91, 0x1f, 0x7a, 0xe8, 0x01, 0xd4, 0x39, 0x09, 0x5d, 0xc2, 0x54, 0xa1,
+	0x6a, 0x87, 0x4e, 0xa0, 0xe6, 0x92, 0x90, 0x06, 0xaa, 0xda, 0x64, 0x83, 0x74, 0xb8, 0xe7, 0xd0,
+	0x50, 0x30, 0xec, 0x88, 0xf6, 0xbe, 0x04, 0xb2, 0xbd, 0x64, 0x5a, 0x05, 0x36, 0x9d, 0xb7, 0xab,
+	0x8a, 0x49, 0xee, 0xe2, 0x4e, 0xbb, 0xc4, 0xf1, 0x03, 0x3c, 0x6f, 0xd7, 0xfa, 0xda, 0xf0, 0xbe,
+	0x95, 0x6e, 0x2f, 0x1b, 0x71, 0x6d, 0x4a, 0x70, 0xd0, 0x83, 0xb3, 0x9d, 0x0e, 0xb3, 0x1a, 0xae,
+	0xe0, 0x7e, 0x5c, 0xe0, 0x82, 0x85, 0x13, 0xe6, 0xbb, 0x1e, 0x29, 0xb5, 0xfe, 0x00, 0xea, 0x24,
+	0xc4, 0xf6, 0x9c, 0x48, 0xef, 0xf7, 0x2c, 0xb5, 0x2b, 0xca, 0xb5, 0xe0, 0xb4, 0xc0, 0x96, 0xc9,
+	0x04, 0x70, 0x94, 0xf9, 0xf8, 0x0a, 0x33, 0x1c, 0x70, 0xd4, 0x85, 0x03, 0xbc, 0x10, 0x33, 0xca,
+	0x7c, 0xb1, 0x52, 0x5a, 0xeb, 0x00, 0xfa, 0x18, 0xea, 0x91, 0x3c, 0x27, 0xe5, 0x1a, 0xe3, 0xe6,
+	0x48, 0xdd, 0xff, 0x24, 0x7b, 0x52, 0x8d, 0x5b, 0x6f, 0xa9, 0x33, 0x97, 0xcd, 0xd8, 0xc4, 0x3a,
+	0x7b, 0xd0, 0x81, 0xd6, 0x86, 0x5c, 0xe6, 0xe4, 0x07, 0x38, 0x59, 0x43, 0x84, 0x05, 0x3e, 0xe7,
+	0x3e, 0x2d, 0xb9, 0x99, 0xb9, 0x71, 0xaa, 0x14, 0xc7, 0xa9, 0x0f, 0x8d, 0x68, 0x9d, 0x2c, 0xbb,
+	0x56, 0xb5, 0xf2, 0xa1, 0xfc, 0x15, 0x33, 0xa0, 0xbb, 0x4b, 0x32, 0xb3, 0xf4, 0x85, 0x9c, 0x94,
+	0xa7, 0x82, 0x32, 0x32, 0x99, 0x53, 0xe7, 0xfa, 0xca, 0xe7, 0x62, 0xa7, 0x1f, 0x04, 0x55, 0x7b,
+	0x4e, 0x6d, 0x69, 0xe6, 0xd0, 0x92, 0xeb, 0xbc, 0x4e, 0x72, 0x59, 0x8b, 0x3c, 0x99, 0xc8, 0x8f,
+	0xb2, 0x03, 0x4f, 0x89, 0xb0, 0xb0, 0x20, 0x57, 0x7e, 0xe0, 0x8b, 0xff, 0xe9, 0xc0, 0xe7, 0x00,
+	0x0c, 0x0b, 0xf2, 0xfd, 0x3c, 0x3e, 0xab, 0xba, 0x70, 0x9c, 0x76, 0x21, 0x23, 0x51, 0x8d, 0x38,
+	0x60, 0x69, 0xa0, 0xa4, 0x17, 0x79, 0xe1, 0xd4, 0xd3, 0xf8, 0x97, 0x1a, 0xec, 0x4f, 0xb9, 0x87,
+	0xbe, 0x86, 0xa3, 0xcd, 0x7f, 0x32, 0x3d, 0x55, 0xda, 0x1e, 0x4e, 0x7d, 0x50, 0x8e, 0xa5, 0xd4,
+	0xe8, 0x09, 0x34, 0x37, 0xfe, 0x7a, 0x3a, 0xb9, 0xac, 0x22, 0xa4, 0x3f, 0x2c, 0x85, 0x32, 0xbe,
+	0xe7, 0x80, 0x76, 0xcc, 0xf9, 0x59, 0x2e, 0x71, 0x1b, 0xd6, 0x3f, 0xba, 0x13, 0xce, 0xb8, 0x27,
+	0x00, 0xb9, 0x01, 0x3c, 0xcd, 0x9b, 0xc9, 0xc2, 0xfa, 0xd9, 0xce, 0x70, 0xc6, 0xf1, 0x25, 0x1c,
+	0x16, 0xa6, 0xab, 0xb5, 0x25, 0x9d, 0x00, 0x7a, 0xaf, 0x04, 0xc8, 0x98, 0xbe, 0x83, 0xe3, 0xed,
+	0xe9, 0xe8, 0x6e, 0x67, 0xad, 0x51, 0xfd, 0xc3, 0xbb, 0xd0, 0x7c, 0x4b, 0x36, 0xee, 0x78, 0xbe,
+	0x25, 0x45, 0x48, 0x7f, 0x58, 0x0a, 0xe5, 0x4b, 0x2e, 0x5c, 0xe7, 0x7c, 0xc9, 0x79, 0x40, 0xef,
+	0x95, 0x00, 0x29, 0x93, 0x5e, 0xfb, 0xe9, 0xed, 0xab, 0x47, 0xda, 0xe4, 0xc9, 0xeb, 0x1b, 0x43,
+	0x7b, 0x73, 0x63, 0x68, 0xff, 0xde, 0x18, 0xda, 0xaf, 0xb7, 0xc6, 0xde, 0x9b, 0x5b, 0x63, 0xef,
+	0xaf, 0x5b, 0x63, 0xef, 0xf9, 0x67, 0xf9, 0x07, 0x86, 0xad, 0x22, 0x41, 0xcf, 0x29, 0xf3, 0xce,
+	0x9d, 0x19, 0xf6, 0x43, 0xf5, 0x30, 0x9b, 0xcb, 0xb1, 0xf9, 0x32, 0x5d, 0xcb, 0x27, 0xc7, 0xae,
+	0xcb, 0xb7, 0xfa, 0xd3, 0xff, 0x06, 0x00, 0x22, 0xd2, 0x5f, 0x1d, 0x2a, 0x08, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -806,6 +903,9 @@ type MsgClient interface {
 	UpdatePermissions(ctx context.Context, in *MsgUpdatePermissions, opts ...grpc.CallOption) (*MsgUpdatePermissionsResponse, error)
 	// StoreBlockList
 	StoreBlockList(ctx context.Context, in *MsgStoreBlockList, opts ...grpc.CallOption) (*MsgStoreBlockListResponse, error)
+	// SetRateLimit defines a method to set or remove a rate limit of the bridge
+	// flows
+	SetRateLimit(ctx context.Context, in *MsgSetRateLimit, opts ...grpc.CallOption) (*MsgSetRateLimitResponse, error)
 }
 
 type msgClient struct {
@@ -879,6 +979,15 @@ func (c *msgClient) StoreBlockList(ctx context.Context, in *MsgStoreBlockList, o
 	return out, nil
 }
 
+func (c *msgClient) SetRateLimit(ctx context.Context, in *MsgSetRateLimit, opts ...grpc.CallOption) (*MsgSetRateLimitResponse, error) {
+	out := new(MsgSetRateLimitResponse)
+	err := c.cc.Invoke(ctx, "/cronos.Msg/SetRateLimit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MsgServer is the server API for Msg service.
 type MsgServer interface {
 	// ConvertVouchers defines a method for converting ibc voucher to cronos evm
@@ -897,6 +1006,9 @@ type MsgServer interface {
 	UpdatePermissions(context.Context, *MsgUpdatePermissions) (*MsgUpdatePermissionsResponse, error)
 	// StoreBlockList
 	StoreBlockList(context.Context, *MsgStoreBlockList) (*MsgStoreBlockListResponse, error)
+	// SetRateLimit defines a method to set or remove a rate limit of the bridge
+	// flows
+	SetRateLimit(context.Context, *MsgSetRateLimit) (*MsgSetRateLimitResponse, error)
 }
 
 // UnimplementedMsgServer can be embedded to have forward compatible implementations.
@@ -924,6 +1036,9 @@ func (*UnimplementedMsgServer) UpdatePermissions(ctx context.Context, req *MsgUp
 func (*UnimplementedMsgServer) StoreBlockList(ctx context.Context, req *MsgStoreBlockList) (*MsgStoreBlockListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StoreBlockList not implemented")
 }
+func (*UnimplementedMsgServer) SetRateLimit(ctx context.Context, req *MsgSetRateLimit) (*MsgSetRateLimitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRateLimit not implemented")
+}
 
 func RegisterMsgServer(s grpc1.Server, srv MsgServer) {
 	s.RegisterService(&_Msg_serviceDesc, srv)
@@ -1055,6 +1170,25 @@ func _Msg_StoreBlockList_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Msg_SetRateLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgSetRateLimit)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).SetRateLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cronos.Msg/SetRateLimit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).SetRateLimit(ctx, req.(*MsgSetRateLimit))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var Msg_serviceDesc = _Msg_serviceDesc
 var _Msg_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "cronos.Msg",
 	HandlerType: (*MsgServer)(nil),
@@ -1087,6 +1221,10 @@ var _Msg_serviceDesc = grpc.ServiceDesc{
 			MethodName: "StoreBlockList",
 			Handler:    _Msg_StoreBlockList_Handler,
 		},
+		{
+			MethodName: "SetRateLimit",
+			Handler:    _Msg_SetRateLimit_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "cronos/tx.proto",
@@ -1563,6 +1701,69 @@ func (m *MsgStoreBlockListResponse) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	return len(dAtA) - i, nil
 }
 
+func (m *MsgSetRateLimit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MsgSetRateLimit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MsgSetRateLimit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.RateLimit.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTx(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if len(m.Authority) > 0 {
+		i -= len(m.Authority)
+		copy(dAtA[i:], m.Authority)
+		i = encodeVarintTx(dAtA, i, uint64(len(m.Authority)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MsgSetRateLimitResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MsgSetRateLimitResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MsgSetRateLimitResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintTx(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTx(v)
 	base := offset
@@ -1775,6 +1976,30 @@ func (m *MsgStoreBlockListResponse) Size() (n int) {
 	return n
 }
 
+func (m *MsgSetRateLimit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Authority)
+	if l > 0 {
+		n += 1 + l + sovTx(uint64(l))
+	}
+	l = m.RateLimit.Size()
+	n += 1 + l + sovTx(uint64(l))
+	return n
+}
+
+func (m *MsgSetRateLimitResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
 func sovTx(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -3058,6 +3283,171 @@ func (m *MsgStoreBlockListResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *MsgSetRateLimit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MsgSetRateLimit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MsgSetRateLimit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Authority", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Authority = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RateLimit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.RateLimit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MsgSetRateLimitResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MsgSetRateLimitResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MsgSetRateLimitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipTx(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0